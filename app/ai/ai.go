@@ -13,6 +13,8 @@ func TransformText(pattern, text string) (string, error) { // Öffentliche API:
 	switch provider {                                             // Wählt je nach Provider-Name die Implementierung.
 	case "", "huggingface": // Default: leer oder explizit "huggingface" → Hugging Face verwenden.
 		return transformWithHuggingFace(prompt) // Delegiert an HF-Implementierung (HTTP Chat Completions).
+	case "openai": // OpenAI (OPENAI_API_KEY, OPENAI_MODEL).
+		return transformWithOpenAI(prompt)
 	default: // Jede andere Eingabe gilt als nicht unterstützt.
 		return "", fmt.Errorf("unknown ai provider: %s", provider) // Klarer Fehler: falscher Provider-Wert.
 	}
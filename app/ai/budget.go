@@ -0,0 +1,121 @@
+package ai // Paket "ai": Budget pro Lauf (max. Aufrufe, max. Tokens) und Rate-Limit für alle Chat-Completions-Aufrufe.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"errors"  // ErrBudgetExhausted.
+	"fmt"     // Warnung auf Stderr.
+	"os"      // Stderr.
+	"strconv" // Limits aus ENV parsen.
+	"sync"    // Provider laufen parallel (Prefetch).
+	"time"    // Mindestabstand zwischen Aufrufen.
+
+	"wapuugotchi/feed/app/env"
+)
+
+var ErrBudgetExhausted = errors.New("ai budget exhausted") // Budget des Laufs aufgebraucht: Aufrufer fallen auf den Originaltext zurück.
+
+type Usage struct { // Verbrauch im aktuellen Lauf (für den Report).
+	Calls     int  `json:"calls"`               // Ausgeführte Aufrufe (auch fehlgeschlagene).
+	Tokens    int  `json:"tokens"`              // Tokens laut API (usage.total_tokens), sonst geschätzt.
+	Skipped   int  `json:"skipped"`             // Wegen Budget übersprungene Aufrufe.
+	Exhausted bool `json:"exhausted,omitempty"` // Budget wurde erreicht.
+}
+
+var budget struct { // Zustand des Laufs; per Mutex geschützt.
+	sync.Mutex
+	loaded    bool          // Limits aus ENV gelesen.
+	maxCalls  int           // AI_MAX_CALLS; 0 = unbegrenzt.
+	maxTokens int           // AI_MAX_TOKENS; 0 = unbegrenzt.
+	interval  time.Duration // Aus AI_RATE_LIMIT (Aufrufe pro Minute); 0 = kein Limit.
+	next      time.Time     // Frühester Zeitpunkt für den nächsten Aufruf.
+	usage     Usage
+}
+
+func ResetUsage() { // Neuer Lauf: Verbrauch auf 0, Limits neu aus ENV lesen.
+	budget.Lock()
+	defer budget.Unlock()
+	budget.loaded = false
+	budget.next = time.Time{}
+	budget.usage = Usage{}
+}
+
+func CurrentUsage() Usage { // Verbrauch seit dem letzten ResetUsage.
+	budget.Lock()
+	defer budget.Unlock()
+	return budget.usage
+}
+
+func loadBudget() { // Liest AI_MAX_CALLS, AI_MAX_TOKENS und AI_RATE_LIMIT (einmal pro Lauf; Aufrufer hält den Mutex).
+	if budget.loaded {
+		return
+	}
+	_ = env.LoadDotEnv()
+	budget.maxCalls = budgetLimit("AI_MAX_CALLS")
+	budget.maxTokens = budgetLimit("AI_MAX_TOKENS")
+	budget.interval = 0
+	if perMinute := budgetLimit("AI_RATE_LIMIT"); perMinute > 0 {
+		budget.interval = time.Minute / time.Duration(perMinute)
+	}
+	budget.loaded = true
+}
+
+func budgetLimit(key string) int { // Positive Zahl aus ENV; leer oder ungültig = 0 (kein Limit).
+	value := env.ReadEnv(key)
+	if value == "" {
+		return 0
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		fmt.Fprintf(os.Stderr, "ai: invalid %s %q, ignoring\n", key, value)
+		return 0
+	}
+	return limit
+}
+
+func reserveCall(prompt string) error { // Vor jedem Aufruf: Budget prüfen, Aufruf zählen und ggf. auf das Rate-Limit warten.
+	if err := runContext().Err(); err != nil { // Lauf abgebrochen: keine neuen Aufrufe.
+		return err
+	}
+	budget.Lock()
+	loadBudget()
+	exhausted := budget.maxCalls > 0 && budget.usage.Calls >= budget.maxCalls ||
+		budget.maxTokens > 0 && budget.usage.Tokens+estimateTokens(prompt) > budget.maxTokens
+	if exhausted {
+		if !budget.usage.Exhausted { // Nur einmal pro Lauf melden.
+			fmt.Fprintf(os.Stderr, "ai: budget exhausted after %d calls / %d tokens, keeping original text\n", budget.usage.Calls, budget.usage.Tokens)
+		}
+		budget.usage.Exhausted = true
+		budget.usage.Skipped++
+		budget.Unlock()
+		return ErrBudgetExhausted
+	}
+	budget.usage.Calls++
+	wait := time.Duration(0)
+	if budget.interval > 0 { // Slot reservieren, dann außerhalb des Mutex warten.
+		now := time.Now()
+		if budget.next.After(now) {
+			wait = budget.next.Sub(now)
+		} else {
+			budget.next = now
+		}
+		budget.next = budget.next.Add(budget.interval)
+	}
+	budget.Unlock()
+	if wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-runContext().Done(): // Lauf abgebrochen: nicht weiter auf den Slot warten – und nicht mehr aufrufen.
+			return runContext().Err()
+		}
+	}
+	return runContext().Err()
+}
+
+func recordTokens(tokens int) { // Nach einem erfolgreichen Aufruf: Tokens verbuchen.
+	budget.Lock()
+	defer budget.Unlock()
+	budget.usage.Tokens += tokens
+}
+
+func estimateTokens(text string) int { // Grobe Schätzung (~4 Zeichen pro Token), wenn die API keine usage liefert.
+	return (len(text) + 3) / 4
+}
@@ -0,0 +1,31 @@
+package ai // Paket "ai": Laufkontext für alle KI-Requests – bricht laufende Aufrufe bei SIGINT/SIGTERM ab.
+
+import ( // Import-Block: Standardbibliothek.
+	"context" // Abbruch + Timeout.
+	"sync"    // SetContext und Requests aus parallelen Providern.
+	"time"    // Frist pro Request.
+)
+
+var run = struct { // Kontext des laufenden Updates; ohne SetContext: context.Background().
+	sync.Mutex
+	ctx context.Context
+}{ctx: context.Background()}
+
+func SetContext(ctx context.Context) { // Laufkontext setzen (RunFeedUpdate); nil = ohne Abbruch.
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	run.Lock()
+	defer run.Unlock()
+	run.ctx = ctx
+}
+
+func runContext() context.Context {
+	run.Lock()
+	defer run.Unlock()
+	return run.ctx
+}
+
+func requestContext(timeout time.Duration) (context.Context, context.CancelFunc) { // Frist pro Request, abgeleitet vom Laufkontext.
+	return context.WithTimeout(runContext(), timeout)
+}
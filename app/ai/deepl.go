@@ -0,0 +1,97 @@
+package ai // Paket "ai": DeepL API v2 als Übersetzer (DEEPL_API_KEY).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"bytes"         // Request-Body.
+	"encoding/json" // Request/Response.
+	"fmt"           // Fehlertexte.
+	"io"            // Response-Body.
+	"net/http"      // API-Call.
+	"strings"       // Sprachcodes + HTML-Erkennung.
+	"time"          // Timeout.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const ( // DeepL-Endpoints: Free-Keys enden auf ":fx".
+	deepLEndpoint     = "https://api.deepl.com/v2/translate"
+	deepLFreeEndpoint = "https://api-free.deepl.com/v2/translate"
+)
+
+type deepLTranslator struct { // DeepL: echte Übersetzung statt Prompt, HTML wird tag-genau erhalten.
+	key      string
+	endpoint string
+}
+
+type deepLRequest struct {
+	Text        []string `json:"text"`                   // Zu übersetzende Texte.
+	TargetLang  string   `json:"target_lang"`            // Zielsprache (z.B. "DE", "EN-US").
+	TagHandling string   `json:"tag_handling,omitempty"` // "html": Markup bleibt erhalten.
+}
+
+type deepLResponse struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+}
+
+func newDeepLTranslator() (Translator, error) { // Liest DEEPL_API_KEY; der Endpoint folgt aus dem Key-Typ.
+	key := env.ReadEnv("DEEPL_API_KEY")
+	if key == "" {
+		return nil, fmt.Errorf("missing DeepL key: set DEEPL_API_KEY")
+	}
+	endpoint := deepLEndpoint
+	if strings.HasSuffix(key, ":fx") {
+		endpoint = deepLFreeEndpoint
+	}
+	return deepLTranslator{key: key, endpoint: endpoint}, nil
+}
+
+func (translator deepLTranslator) Translate(text, target string) (string, error) {
+	payload := deepLRequest{Text: []string{text}, TargetLang: deepLLanguage(target)}
+	if strings.Contains(text, "<") { // Inhalt mit Markup; Titel bleiben Klartext (sonst kämen Entities zurück).
+		payload.TagHandling = "html"
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := requestContext(30 * time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, translator.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+translator.key)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("deepl api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var result deepLResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", err
+	}
+	if len(result.Translations) == 0 || strings.TrimSpace(result.Translations[0].Text) == "" {
+		return "", fmt.Errorf("deepl api returned empty translation")
+	}
+	return result.Translations[0].Text, nil
+}
+
+func deepLLanguage(target string) string { // BCP 47 → DeepL-Code; Englisch/Portugiesisch brauchen eine Variante.
+	code := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(target), "_", "-"))
+	switch code {
+	case "EN":
+		return "EN-US"
+	case "PT":
+		return "PT-PT"
+	}
+	return code
+}
@@ -2,7 +2,6 @@ package ai // Paket "ai": kapselt alles rund um KI-Integration (hier: Hugging Fa
 
 import ( // Import-Block: Standardbibliothek-Module, die für HTTP, JSON und Timeouts benötigt werden.
 	"bytes"         // Baut einen io.Reader aus []byte für den HTTP-Request-Body (bytes.NewReader).
-	"context"       // Ermöglicht Timeouts/Cancel für HTTP-Requests (context.WithTimeout).
 	"encoding/json" // JSON (Marshal/Unmarshal) für Request/Response an/von der API.
 	"fmt"           // Formatierte Fehlermeldungen mit Kontext (fmt.Errorf).
 	"io"            // io.ReadAll: liest Response-Body vollständig.
@@ -35,11 +34,25 @@ type chatResponse struct { // Minimaler Ausschnitt der erwarteten API-Response-S
 			Content string `json:"content"` // Der generierte Text der KI.
 		} `json:"message"` // Mappt das "message"-Objekt.
 	} `json:"choices"` // Mappt das "choices"-Array.
+	Usage struct { // Verbrauch laut API (fürs Budget, siehe budget.go).
+		TotalTokens int `json:"total_tokens"` // Prompt + Antwort.
+	} `json:"usage"`
 }
 
 func transformWithHuggingFace(prompt string) (string, error) { // High-Level Funktion: Prompt rein, fertiger Text raus.
-	raw, err := postChatCompletion(prompt) // Sendet den Prompt an die API und bekommt Raw-JSON-Response zurück.
-	if err != nil {                        // Wenn HTTP/Token/Status/Netzwerk fehlschlägt…
+	token, err := loadHuggingFaceToken() // Holt das HF-Token aus ENV oder .env.
+	if err != nil {                      // Wenn kein Token vorhanden oder .env Laden fehlschlägt…
+		return "", err // …Fehler zurück (ohne Token keine Auth).
+	}
+	return chatCompletion("huggingface", hfEndpoint, token, hfModel, prompt) // Gleiche Chat-Completions-API wie OpenAI.
+}
+
+func chatCompletion(vendor, endpoint, token, model, prompt string) (string, error) { // OpenAI-kompatible Chat Completions (Hugging Face Router, OpenAI): Prompt rein, Text raus.
+	if err := reserveCall(prompt); err != nil { // Budget/Rate-Limit des Laufs (AI_MAX_CALLS, AI_MAX_TOKENS, AI_RATE_LIMIT).
+		return "", err
+	}
+	raw, err := postChatCompletion(vendor, endpoint, token, model, prompt) // Sendet den Prompt an die API und bekommt Raw-JSON-Response zurück.
+	if err != nil {                                                        // Wenn HTTP/Status/Netzwerk fehlschlägt…
 		return "", err // …Fehler nach oben durchreichen.
 	}
 
@@ -47,24 +60,24 @@ func transformWithHuggingFace(prompt string) (string, error) { // High-Level Fun
 	if err := json.Unmarshal([]byte(raw), &resp); err != nil { // Parse des JSON-Strings in die Struktur.
 		return "", err // Wenn Response kein gültiges JSON ist oder Struktur unerwartet: Fehler zurück.
 	}
+	if tokens := resp.Usage.TotalTokens; tokens > 0 { // Tokens verbuchen: laut API, sonst geschätzt.
+		recordTokens(tokens)
+	} else {
+		recordTokens(estimateTokens(prompt) + estimateTokens(raw))
+	}
 	if len(resp.Choices) == 0 { // Wenn die API keine Antwortoptionen liefert…
-		return "", fmt.Errorf("huggingface api returned no choices") // …ist das ein harter Fehler (nichts zum Weiterverarbeiten).
+		return "", fmt.Errorf("%s api returned no choices", vendor) // …ist das ein harter Fehler (nichts zum Weiterverarbeiten).
 	}
 	translated := strings.TrimSpace(resp.Choices[0].Message.Content) // Nimmt die erste Choice und trimmt Whitespace.
 	if translated == "" {                                            // Wenn der resultierende Text leer ist…
-		return "", fmt.Errorf("huggingface api returned empty translation") // …Fehler: leere Transformation ist i.d.R. nicht brauchbar.
+		return "", fmt.Errorf("%s api returned empty translation", vendor) // …Fehler: leere Transformation ist i.d.R. nicht brauchbar.
 	}
 	return translated, nil // Erfolgsfall: normalisierter Output der KI.
 }
 
-func postChatCompletion(prompt string) (string, error) { // Low-Level Funktion: baut Request, macht HTTP Call, liefert Raw-Response.
-	token, err := loadHuggingFaceToken() // Holt das HF-Token aus ENV oder .env.
-	if err != nil {                      // Wenn kein Token vorhanden oder .env Laden fehlschlägt…
-		return "", err // …Fehler zurück (ohne Token keine Auth).
-	}
-
-	payload := chatRequest{ // Baut das Request-Payload passend zur Hugging Face Chat Completions API.
-		Model: hfModel, // Nutzt die oben definierte Modell-Konstante.
+func postChatCompletion(vendor, endpoint, token, model, prompt string) (string, error) { // Low-Level Funktion: baut Request, macht HTTP Call, liefert Raw-Response.
+	payload := chatRequest{ // Baut das Request-Payload passend zur Chat Completions API.
+		Model: model, // Modell des Anbieters.
 		Messages: []chatMessage{ // Chat-Verlauf: hier nur eine User-Message.
 			{Role: "user", Content: prompt}, // Übergibt den Prompt als User-Content.
 		},
@@ -76,15 +89,15 @@ func postChatCompletion(prompt string) (string, error) { // Low-Level Funktion:
 		return "", err // Fehler zurück.
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second) // Timeout-Kontext: verhindert Hängen bei API/Netzwerk.
-	defer cancel()                                                           // Stellt sicher, dass Ressourcen des Contexts freigegeben werden.
+	ctx, cancel := requestContext(30 * time.Second) // Timeout-Kontext (abgeleitet vom Laufkontext): verhindert Hängen bei API/Netzwerk, bricht bei SIGINT/SIGTERM ab.
+	defer cancel()                                  // Stellt sicher, dass Ressourcen des Contexts freigegeben werden.
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hfEndpoint, bytes.NewReader(body)) // Baut POST-Request mit Timeout.
-	if err != nil {                                                                                 // Fehler bei ungültiger URL oder Reader.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body)) // Baut POST-Request mit Timeout.
+	if err != nil {                                                                               // Fehler bei ungültiger URL oder Reader.
 		return "", err // Fehler zurück.
 	}
 	req.Header.Set("Content-Type", "application/json") // API erwartet JSON.
-	req.Header.Set("Authorization", "Bearer "+token)   // Auth via Bearer Token (Standard bei HF).
+	req.Header.Set("Authorization", "Bearer "+token)   // Auth via Bearer Token (HF und OpenAI).
 
 	resp, err := http.DefaultClient.Do(req) // Führt den HTTP Request aus (DefaultClient nutzt u.a. Keep-Alive).
 	if err != nil {                         // Netzwerkfehler, TLS, Timeout, DNS, etc.
@@ -98,7 +111,7 @@ func postChatCompletion(prompt string) (string, error) { // Low-Level Funktion:
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 { // Nicht-2xx Status als Fehler behandeln.
-		return "", fmt.Errorf("huggingface api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+		return "", fmt.Errorf("%s api status: %s: %s", vendor, resp.Status, strings.TrimSpace(string(respBody)))
 		// Liefert Status + Body-Text (trimmed) zurück, damit man API-Fehler sieht (Quota, Auth, Invalid payload).
 	}
 
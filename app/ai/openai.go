@@ -0,0 +1,29 @@
+package ai // Paket "ai": OpenAI Chat Completions (gleiches Format wie der Hugging Face Router).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt" // Fehlertexte.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const ( // Konstanten: OpenAI-Defaults.
+	openAIEndpoint = "https://api.openai.com/v1/chat/completions" // Chat Completions Endpoint.
+	openAIModel    = "gpt-4o-mini"                                // Default-Modell (günstig, gut genug für Übersetzungen).
+)
+
+func transformWithOpenAI(prompt string) (string, error) { // Prompt rein, Text raus (OPENAI_API_KEY, optional OPENAI_MODEL/OPENAI_BASE_URL).
+	_ = env.LoadDotEnv()
+	token := env.ReadEnv("OPENAI_API_KEY")
+	if token == "" {
+		return "", fmt.Errorf("missing OpenAI key: set OPENAI_API_KEY")
+	}
+	model := env.ReadEnv("OPENAI_MODEL")
+	if model == "" {
+		model = openAIModel
+	}
+	endpoint := openAIEndpoint
+	if base := env.ReadEnv("OPENAI_BASE_URL"); base != "" { // OpenAI-kompatible Gateways (Azure-Proxy, lokale Modelle, …).
+		endpoint = base + "/chat/completions"
+	}
+	return chatCompletion("openai", endpoint, token, model, prompt)
+}
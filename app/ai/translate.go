@@ -0,0 +1,47 @@
+package ai // Paket "ai": Übersetzer-Schnittstelle mit austauschbaren Backends (KI-Prompt, OpenAI, DeepL, keiner).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"     // Prompt + Fehlertexte.
+	"strings" // Namen normalisieren.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const translatePattern = "Translate the following text into %s. Keep all HTML tags and URLs unchanged. Output only the translation, without any explanation.\n\n" // Prompt für KI-Übersetzer; der Text wird angehängt.
+
+type Translator interface { // Übersetzt Text (auch HTML) in die Zielsprache target (BCP 47, z.B. "de").
+	Translate(text, target string) (string, error)
+}
+
+func NewTranslator() (Translator, error) { // Wählt das Backend per TRANSLATE_PROVIDER: "ai" (Default, folgt AI_PROVIDER), "huggingface", "openai", "deepl" oder "none".
+	_ = env.LoadDotEnv()
+	name := strings.ToLower(strings.TrimSpace(env.ReadEnv("TRANSLATE_PROVIDER")))
+	switch name {
+	case "", "ai":
+		return promptTranslator{transform: func(prompt string) (string, error) { return TransformText("", prompt) }}, nil
+	case "huggingface":
+		return promptTranslator{transform: transformWithHuggingFace}, nil
+	case "openai":
+		return promptTranslator{transform: transformWithOpenAI}, nil
+	case "deepl":
+		return newDeepLTranslator()
+	case "none", "passthrough":
+		return passthroughTranslator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown translate provider: %s", name)
+	}
+}
+
+type promptTranslator struct { // Übersetzung per LLM-Prompt.
+	transform func(prompt string) (string, error)
+}
+
+func (translator promptTranslator) Translate(text, target string) (string, error) {
+	return translator.transform(buildPrompt(fmt.Sprintf(translatePattern, target), text))
+}
+
+type passthroughTranslator struct{} // Kein Übersetzer: Text bleibt unverändert (z.B. lokal ohne API-Key).
+
+func (passthroughTranslator) Translate(text, _ string) (string, error) {
+	return text, nil
+}
@@ -0,0 +1,141 @@
+package cmd // Paket "cmd": optionaler Atom-1.0-Feed (atom.xml) neben jedem RSS-Feed.
+
+import ( // Import-Block: Standardbibliothek + Env-/Publish-Paket.
+	"bytes"         // Puffer für den Encoder.
+	"encoding/xml"  // Atom-XML schreiben.
+	"path/filepath" // Dateinamen ableiten.
+	"strings"       // Dateinamen/URLs.
+	"time"          // Datumsformat.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/publish"
+)
+
+var atomRequested bool // Per CLI (-atom) eingeschaltet; sonst entscheidet FEED_ATOM.
+
+type AtomFeed struct { // Root-Element nach RFC 4287.
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"` // <feed xmlns="http://www.w3.org/2005/Atom">.
+	Lang     string      `xml:"xml:lang,attr,omitempty"`          // Sprache des Feeds.
+	ID       string      `xml:"id"`                               // Dauerhafte ID des Feeds (öffentliche URL oder Website).
+	Title    string      `xml:"title"`                            // Feed-Titel.
+	Subtitle string      `xml:"subtitle,omitempty"`               // Feed-Beschreibung.
+	Updated  string      `xml:"updated"`                          // Neuester Entry (RFC3339).
+	Links    []AtomLink  `xml:"link"`                             // alternate (Website) + self (dieser Feed).
+	Author   AtomAuthor  `xml:"author"`                           // Pflicht, wenn nicht jeder Entry einen Autor hat.
+	Icon     string      `xml:"icon,omitempty"`                   // Favicon.
+	Logo     string      `xml:"logo,omitempty"`                   // Großes Icon.
+	Entries  []AtomEntry `xml:"entry"`                            // Entries (bereits sortiert).
+}
+
+type AtomLink struct { // <link rel="…" href="…"/>.
+	Rel  string `xml:"rel,attr,omitempty"`  // "alternate" oder "self".
+	Type string `xml:"type,attr,omitempty"` // MIME-Type des Ziels (optional).
+	Href string `xml:"href,attr"`           // Ziel-URL.
+}
+
+type AtomAuthor struct { // <author><name>…</name></author>.
+	Name string `xml:"name"` // Name des Feeds/der Site.
+}
+
+type AtomEntry struct { // Ein Entry im Atom-Feed.
+	Lang       string         `xml:"xml:lang,attr,omitempty"` // Eigene Sprache des Entries (falls abweichend).
+	ID         string         `xml:"id"`                      // Stabile Entry-ID als URN.
+	Title      string         `xml:"title"`                   // Titel.
+	Link       *AtomLink      `xml:"link,omitempty"`          // Link zum Original.
+	Published  string         `xml:"published"`               // Veröffentlichung (RFC3339).
+	Updated    string         `xml:"updated"`                 // Entries werden nicht nachträglich geändert: = published.
+	Categories []AtomCategory `xml:"category,omitempty"`      // Kategorien.
+	Summary    string         `xml:"summary,omitempty"`       // KI-Zusammenfassung (Klartext).
+	Content    *AtomContent   `xml:"content,omitempty"`       // HTML-Inhalt (escaped, type="html").
+}
+
+type AtomCategory struct { // <category term="…"/>.
+	Term string `xml:"term,attr"` // Kategorie.
+}
+
+type AtomContent struct { // <content type="html">…</content>.
+	Type string `xml:"type,attr"` // Immer "html".
+	Body string `xml:",chardata"` // HTML als Text (der Encoder escaped).
+}
+
+func EnableAtom() { // CLI-Schalter: Atom-Feeds in diesem Lauf zusätzlich schreiben.
+	atomRequested = true
+}
+
+func atomEnabled() bool { // -atom oder FEED_ATOM=true.
+	if atomRequested {
+		return true
+	}
+	_ = env.LoadDotEnv()
+	return env.ReadEnv("FEED_ATOM") == "true"
+}
+
+func atomFeedPath(path string) string { // feed.xml → atom.xml, feed.videos.de.xml → atom.videos.de.xml, news.xml → news.atom.xml.
+	dir, name := filepath.Split(path)
+	if rest, ok := strings.CutPrefix(name, "feed."); ok {
+		return filepath.Join(dir, "atom."+rest)
+	}
+	return filepath.Join(dir, strings.TrimSuffix(name, filepath.Ext(name))+".atom.xml")
+}
+
+func buildAtomFeed(site Site, entries []Entry, outputPath string) error { // Schreibt die Entries (bereits sortiert) als Atom-Feed.
+	out := AtomFeed{
+		Lang:     site.Language,
+		ID:       site.Link,
+		Title:    site.Title,
+		Subtitle: site.Description,
+		Author:   AtomAuthor{Name: site.Title},
+		Icon:     site.Favicon,
+		Logo:     site.Icon,
+	}
+	if site.Link != "" {
+		out.Links = append(out.Links, AtomLink{Rel: "alternate", Type: "text/html", Href: site.Link})
+	}
+	if feedURL := publish.FeedURL(); feedURL != "" && strings.HasSuffix(feedURL, "/feed.xml") { // Gleicher Ort wie feed.xml, nur mit dem eigenen Dateinamen.
+		self := strings.TrimSuffix(feedURL, "feed.xml") + filepath.Base(outputPath)
+		out.ID = self // Die öffentliche Feed-URL ist die stabilste ID.
+		out.Links = append(out.Links, AtomLink{Rel: "self", Type: "application/atom+xml", Href: self})
+	}
+	if out.ID == "" { // Weder Website noch öffentliche URL: wenigstens eine gültige, stabile URN.
+		out.ID = "urn:wapuugotchi:feed:" + hashString(site.Title)
+	}
+	out.Updated = time.Now().UTC().Format(time.RFC3339) // Leerer Feed: Zeitpunkt des Builds.
+	if newest, err := parseTime(newestCreatedAt(entries)); err == nil {
+		out.Updated = newest.UTC().Format(time.RFC3339)
+	}
+	params := linkParams()
+	for _, entry := range entries {
+		createdAt, err := parseTime(entry.CreatedAt)
+		if err != nil { // Wie im RSS: kaputte Zeitstempel überspringen.
+			continue
+		}
+		item := AtomEntry{
+			ID:        "urn:wapuugotchi:entry:" + entry.ID,
+			Title:     entry.Title,
+			Published: createdAt.UTC().Format(time.RFC3339),
+			Updated:   createdAt.UTC().Format(time.RFC3339),
+			Summary:   entry.Summary,
+		}
+		if entry.Link != "" {
+			item.Link = &AtomLink{Rel: "alternate", Href: decorateLink(entry.Link, params)}
+		}
+		for _, category := range entry.Categories {
+			item.Categories = append(item.Categories, AtomCategory{Term: category})
+		}
+		if entry.Content != "" {
+			item.Content = &AtomContent{Type: "html", Body: entry.Content}
+		}
+		if entry.Language != "" && entry.Language != site.Language {
+			item.Lang = entry.Language
+		}
+		out.Entries = append(out.Entries, item)
+	}
+	var data bytes.Buffer
+	data.WriteString(xml.Header)
+	enc := xml.NewEncoder(&data)
+	enc.Indent("", outputIndent()) // Leer bei FEED_MINIFY: kompakt.
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	return writeFileAtomic(outputPath, data.Bytes(), 0o644)
+}
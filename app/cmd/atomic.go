@@ -0,0 +1,30 @@
+package cmd // Paket "cmd": atomare Dateiwrites (Temp-Datei im Zielverzeichnis + Rename).
+
+import ( // Import-Block: Standardbibliothek.
+	"os"            // Temp-Datei, Rename.
+	"path/filepath" // Zielverzeichnis.
+)
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error { // Ersetzt path in einem Schritt: bricht der Prozess ab, bleibt die alte Datei vollständig erhalten.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*") // Gleiches Verzeichnis = gleiches Dateisystem, sonst ist Rename nicht atomar.
+	if err != nil {
+		return err
+	}
+	name := tmp.Name()
+	defer os.Remove(name) // Nach erfolgreichem Rename ein No-op.
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil { // Erst auf der Platte, dann sichtbar.
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(name, perm); err != nil { // CreateTemp legt 0600 an.
+		return err
+	}
+	return os.Rename(name, path)
+}
@@ -0,0 +1,49 @@
+package cmd // Paket "cmd": Quellenangabe (Attribution) unter übernommenen Inhalten.
+
+import ( // Import-Block: Standardbibliothek + internes Feed-Paket.
+	"fmt"           // Log-Ausgabe bei Template-Fehlern.
+	"html/template" // Template mit automatischem HTML-Escaping.
+	"net/url"       // Host aus dem Link.
+	"os"            // Stderr.
+	"strings"       // Builder + Trim.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+type attributionData struct { // Werte, die im Attribution-Template zur Verfügung stehen.
+	Provider string // Name der Quelle.
+	Title    string // Titel des Items.
+	Link     string // Link zum Original.
+	Host     string // Host des Originals (z.B. "wordpress.org").
+	Source   string // Host + Pfad ohne Schema (z.B. "wordpress.org/news").
+}
+
+func attribute(provider feedProvider, item feed.Item, content string) string { // Hängt die gerenderte Quellenzeile an; ohne Template bleibt content unverändert.
+	text := strings.TrimSpace(provider.Settings.Attribution)
+	if text == "" {
+		return content
+	}
+	tmpl, err := template.New(provider.Name).Parse(text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "attribution %s: %v\n", provider.Name, err) // Kaputtes Template: Entry trotzdem übernehmen.
+		return content
+	}
+	data := attributionData{Provider: provider.Name, Title: item.Title, Link: item.Link}
+	if parsed, err := url.Parse(strings.TrimSpace(item.Link)); err == nil {
+		data.Host = parsed.Hostname()
+		data.Source = data.Host
+		if section, _, _ := strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/"); section != "" && strings.Count(strings.Trim(parsed.Path, "/"), "/") > 0 { // Nur die Sektion (z.B. "/news"), nicht der Artikel-Slug.
+			data.Source += "/" + section
+		}
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		fmt.Fprintf(os.Stderr, "attribution %s: %v\n", provider.Name, err)
+		return content
+	}
+	line := strings.TrimSpace(out.String())
+	if !strings.HasPrefix(line, "<") { // Reiner Text wird als eigener Absatz ausgegeben.
+		line = `<p class="attribution"><small>` + line + `</small></p>`
+	}
+	return content + "\n" + line
+}
@@ -0,0 +1,77 @@
+package cmd // Paket "cmd": Response-Bodies lesen – gzip/deflate selbst entpacken und die Größe begrenzen (keine OOMs, keine Zip-Bomben).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"bufio"          // zlib-Header erkennen.
+	"compress/flate" // deflate ohne zlib-Header (manche Server).
+	"compress/gzip"  // gzip.
+	"compress/zlib"  // deflate laut RFC 9110 (zlib-Format).
+	"fmt"            // Fehlertexte.
+	"io"             // LimitReader.
+	"net/http"       // Response.
+	"os"             // Stderr.
+	"strconv"        // FEED_MAX_BODY_SIZE parsen.
+	"strings"        // Content-Encoding normalisieren.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const acceptEncoding = "gzip, deflate" // Explizit: Go entpackt dann nicht mehr selbst, das Limit gilt für die entpackten Bytes.
+
+const defaultMaxBodySize = 5 << 20 // 5 MB entpackt; echte Feeds sind weit kleiner.
+
+func maxBodySize() int64 { // FEED_MAX_BODY_SIZE in Bytes, Default 5 MB.
+	_ = env.LoadDotEnv()
+	value := env.ReadEnv("FEED_MAX_BODY_SIZE")
+	if value == "" {
+		return defaultMaxBodySize
+	}
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || size <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid FEED_MAX_BODY_SIZE %q, using %d\n", value, defaultMaxBodySize)
+		return defaultMaxBodySize
+	}
+	return size
+}
+
+func readBody(resp *http.Response, limit int64) ([]byte, error) { // Entpackt laut Content-Encoding und liest höchstens limit Bytes; mehr ist ein Fehler.
+	var reader io.Reader = resp.Body
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("gzip: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	case "deflate":
+		inflated, err := deflateReader(reader)
+		if err != nil {
+			return nil, fmt.Errorf("deflate: %w", err)
+		}
+		defer inflated.Close()
+		reader = inflated
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+	body, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("response body larger than %d bytes", limit)
+	}
+	return body, nil
+}
+
+func deflateReader(reader io.Reader) (io.ReadCloser, error) { // zlib (RFC) oder rohes deflate (verbreiteter Fehler von Servern).
+	buffered := bufio.NewReader(reader)
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 { // CM=8 + Prüfsumme: zlib-Header.
+		return zlib.NewReader(buffered)
+	}
+	return flate.NewReader(buffered), nil
+}
@@ -0,0 +1,84 @@
+package cmd // Tests für readBody: Entpacken (gzip, zlib, rohes deflate) und Größenlimit auch bei Zip-Bomben.
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func compressed(t *testing.T, encoding string, data []byte) []byte { // data gepackt wie ein Server mit Content-Encoding encoding.
+	t.Helper()
+	var out bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case "gzip":
+		writer = gzip.NewWriter(&out)
+	case "zlib":
+		writer = zlib.NewWriter(&out)
+	case "raw":
+		writer, _ = flate.NewWriter(&out, flate.BestCompression)
+	default:
+		return data
+	}
+	if _, err := writer.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return out.Bytes()
+}
+
+func TestReadBody(t *testing.T) {
+	small := []byte("<rss>" + strings.Repeat("x", 1000) + "</rss>")
+	bomb := make([]byte, 64<<20) // 64 MB Nullen: gepackt nur wenige KB.
+	tests := []struct {
+		name     string
+		header   string // Content-Encoding.
+		packing  string // Wie der Body gepackt ist.
+		data     []byte
+		limit    int64
+		wantErr  string
+		wantBody []byte
+	}{
+		{"unkomprimiert", "", "", small, 4096, "", small},
+		{"identity", "identity", "", small, 4096, "", small},
+		{"gzip", "gzip", "gzip", small, 4096, "", small},
+		{"x-gzip", "x-gzip", "gzip", small, 4096, "", small},
+		{"deflate (zlib)", "deflate", "zlib", small, 4096, "", small},
+		{"deflate (roh)", "deflate", "raw", small, 4096, "", small},
+		{"genau am Limit", "gzip", "gzip", small, int64(len(small)), "", small},
+		{"unkomprimiert zu groß", "", "", small, 100, "larger than 100 bytes", nil},
+		{"gzip-Bombe", "gzip", "gzip", bomb, 1 << 20, "larger than 1048576 bytes", nil},
+		{"deflate-Bombe (zlib)", "deflate", "zlib", bomb, 1 << 20, "larger than 1048576 bytes", nil},
+		{"deflate-Bombe (roh)", "deflate", "raw", bomb, 1 << 20, "larger than 1048576 bytes", nil},
+		{"kaputtes gzip", "gzip", "", small, 4096, "gzip:", nil},
+		{"unbekanntes Encoding", "br", "", small, 4096, `unsupported content encoding "br"`, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}, Body: io.NopCloser(bytes.NewReader(compressed(t, test.packing, test.data)))}
+			if test.header != "" {
+				resp.Header.Set("Content-Encoding", test.header)
+			}
+			body, err := readBody(resp, test.limit)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("readBody error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(body, test.wantBody) {
+				t.Errorf("readBody = %d bytes, want %d", len(body), len(test.wantBody))
+			}
+		})
+	}
+}
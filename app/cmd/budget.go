@@ -0,0 +1,64 @@
+package cmd // Paket "cmd": minifizierte Ausgabe + Größenbudget für die generierten Feeds.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"     // Warnungen + Fehler.
+	"os"      // Dateigröße, Stderr.
+	"strconv" // Zahl parsen.
+	"strings" // Einheit abtrennen.
+
+	"wapuugotchi/feed/app/env"
+)
+
+func outputIndent() string { // Einrückung für RSS/JSON; FEED_MINIFY=true liefert kompakte Ausgabe ohne Whitespace.
+	_ = env.LoadDotEnv()
+	if env.ReadEnv("FEED_MINIFY") == "true" {
+		return ""
+	}
+	return "  "
+}
+
+func parseSize(value string) (int64, error) { // "300000", "300KB", "1.5MB" → Bytes (KB/MB = 1024er-Einheiten).
+	value = strings.ToUpper(strings.TrimSpace(value))
+	factor := 1.0
+	for _, unit := range []struct {
+		suffix string
+		factor float64
+	}{{"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(value, unit.suffix) {
+			value, factor = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix)), unit.factor
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid size %q", value)
+	}
+	return int64(number * factor), nil
+}
+
+func checkSizeBudget(paths Paths) error { // FEED_SIZE_BUDGET: Feeds über dem Limit werden gemeldet; FEED_SIZE_BUDGET_MODE=fail bricht ab.
+	_ = env.LoadDotEnv()
+	raw := env.ReadEnv("FEED_SIZE_BUDGET")
+	if raw == "" {
+		return nil
+	}
+	budget, err := parseSize(raw)
+	if err != nil {
+		return fmt.Errorf("FEED_SIZE_BUDGET: %w", err)
+	}
+	fail := env.ReadEnv("FEED_SIZE_BUDGET_MODE") == "fail"
+	over := []string{}
+	for _, file := range feedFiles(paths) {
+		info, err := os.Stat(file)
+		if err != nil || info.Size() <= budget {
+			continue
+		}
+		message := fmt.Sprintf("%s is %d bytes, budget is %d bytes", info.Name(), info.Size(), budget)
+		fmt.Fprintln(os.Stderr, "warning: "+message)
+		over = append(over, message)
+	}
+	if fail && len(over) > 0 {
+		return fmt.Errorf("size budget exceeded: %s", strings.Join(over, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package cmd // Paket "cmd": Feeds aus den gespeicherten Entries neu bauen, ohne etwas abzurufen.
+
+import "fmt" // Ausgabe.
+
+func RunBuild() error { // Baut feed.xml + abgeleitete Feeds aus entries.json (kein Fetch, kein Publish, keine Notifier).
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	entries := loadEntries(paths.entries)
+	if err := buildOutputs(paths, loadSite(paths.site), entries); err != nil {
+		return err
+	}
+	fmt.Printf("built feeds from %d entries\n", len(entries))
+	return nil
+}
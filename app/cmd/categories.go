@@ -0,0 +1,55 @@
+package cmd // Paket "cmd": Kategorien vereinheitlichen (data/categories.json: Mapping + Drop-Liste), damit das Plugin zuverlässig danach filtern kann.
+
+import ( // Import-Block: Standardbibliothek.
+	"html"    // Doppelt escapte Entities ("News &amp; Events").
+	"strings" // Normalisieren.
+)
+
+type CategoryConfig struct { // Inhalt von data/categories.json.
+	Map  map[string]string `json:"map,omitempty"`  // Upstream-Kategorie → eigene Kategorie (Schlüssel case-insensitive), z.B. "Releases": "release", "Veröffentlichungen": "release"; "" = verwerfen.
+	Drop []string          `json:"drop,omitempty"` // Rausch-Kategorien, die nie übernommen werden (z.B. "Featured").
+}
+
+var defaultDroppedCategories = []string{"uncategorized"} // WordPress-Standardkategorie: sagt nichts aus.
+
+type categoryMap struct { // Vorbereitete Abbildung (Schlüssel normalisiert).
+	mapped  map[string]string
+	dropped map[string]bool
+}
+
+var categoryMapping = newCategoryMap(CategoryConfig{}) // Aktive Abbildung; RunFeedUpdate lädt data/categories.json.
+
+func loadCategoryMap(path string) categoryMap { // Fehlt die Datei, bleibt es bei Kleinschreibung, Dubletten und den Default-Drops.
+	config := CategoryConfig{}
+	readJSON(path, &config)
+	return newCategoryMap(config)
+}
+
+func newCategoryMap(config CategoryConfig) categoryMap {
+	categories := categoryMap{mapped: map[string]string{}, dropped: map[string]bool{}}
+	for from, to := range config.Map {
+		categories.mapped[categoryKey(from)] = categoryKey(to)
+	}
+	for _, category := range append(defaultDroppedCategories, config.Drop...) {
+		categories.dropped[categoryKey(category)] = true
+	}
+	return categories
+}
+
+func categoryKey(value string) string { // Entities auflösen, Whitespace zusammenfassen, Kleinbuchstaben.
+	return strings.ToLower(strings.Join(strings.Fields(html.UnescapeString(value)), " "))
+}
+
+func (categories categoryMap) normalize(value string) string { // Normalisierte, abgebildete Kategorie; "" = verwerfen.
+	key := categoryKey(value)
+	if categories.dropped[key] {
+		return ""
+	}
+	if mapped, ok := categories.mapped[key]; ok {
+		key = mapped
+	}
+	if categories.dropped[key] {
+		return ""
+	}
+	return key
+}
@@ -0,0 +1,51 @@
+package cmd // Paket "cmd": Checkpoint für abgebrochene Läufe (data/checkpoint.json).
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"  // Ausgabe.
+	"os"   // Checkpoint löschen.
+	"time" // Zeitstempel.
+)
+
+type Checkpoint struct { // Geholte und verarbeitete (KI!) Entries, deren Lauf noch nicht bis zur Veröffentlichung kam.
+	CreatedAt  string            `json:"created_at"`           // Zeitpunkt des abgebrochenen Laufs (RFC3339).
+	Entries    []Entry           `json:"entries"`              // Neue Entries dieses Laufs.
+	Watermarks map[string]string `json:"watermarks,omitempty"` // Watermarks nach diesen Entries: der fortgesetzte Lauf holt (und transformiert) sie nicht erneut.
+}
+
+func loadCheckpoint(path string) Checkpoint { // Lädt den Checkpoint; fehlt die Datei, gibt es nichts fortzusetzen.
+	checkpoint := Checkpoint{}
+	readJSON(path, &checkpoint)
+	return checkpoint
+}
+
+func saveCheckpoint(path string, entries []Entry, watermarks map[string]string) { // Sichert die neuen Entries samt Watermarks, bevor gespeichert/gebaut/veröffentlicht wird.
+	writeJSON(path, Checkpoint{CreatedAt: time.Now().UTC().Format(time.RFC3339), Entries: entries, Watermarks: watermarks})
+}
+
+func clearCheckpoint(path string) { // Lauf vollständig abgeschlossen: Checkpoint entfernen.
+	if err := removeData(path); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func resumeCheckpoint(checkpoint Checkpoint, entries *[]Entry, watermarks map[string]string) []Entry { // Übernimmt fehlende Entries und neuere Watermarks aus dem Checkpoint; liefert die Entries, die schon gespeichert waren.
+	stored := []Entry{} // Schon in entries.json, aber nie veröffentlicht/angekündigt.
+	for _, entry := range checkpoint.Entries {
+		if idExists(*entries, entry.ID) {
+			stored = append(stored, entry)
+			continue
+		}
+		*entries = append(*entries, entry) // Nicht neu holen: Inhalt (inkl. KI-Ergebnis) kommt aus dem Checkpoint.
+	}
+	for name, value := range checkpoint.Watermarks { // Ohne sie lieferte FetchNew dieselben Items erneut, und die Transformer (KI) liefen vor dem Dedupe noch einmal.
+		at, err := parseTime(value)
+		if err != nil {
+			continue
+		}
+		if current, err := parseTime(watermarks[name]); err != nil || at.After(current) {
+			watermarks[name] = value
+		}
+	}
+	fmt.Printf("resuming %d entries from checkpoint of %s\n", len(checkpoint.Entries), checkpoint.CreatedAt)
+	return stored
+}
@@ -0,0 +1,76 @@
+package cmd // Tests für den Checkpoint: ein fortgesetzter Lauf übernimmt Entries und Watermarks, ohne die Items erneut zu transformieren (KI-Kosten).
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"wapuugotchi/feed/app/feed"
+)
+
+const checkpointRSS = `<rss version="2.0"><channel>
+<item><title>Drei</title><link>https://example.com/3</link><pubDate>Fri, 03 May 2024 12:00:00 +0000</pubDate><description>c</description></item>
+<item><title>Zwei</title><link>https://example.com/2</link><pubDate>Thu, 02 May 2024 12:00:00 +0000</pubDate><description>b</description></item>
+<item><title>Eins</title><link>https://example.com/1</link><pubDate>Wed, 01 May 2024 12:00:00 +0000</pubDate><description>a</description></item>
+</channel></rss>`
+
+func TestResumeCheckpointSkipsTransformers(t *testing.T) {
+	calls := 0 // Aufrufe des Transformers (steht für ai.TransformText).
+	hook := func(_ func(url, source string) ([]byte, error), item feed.Item) feed.Item {
+		calls++
+		return item
+	}
+	fetch := func(string, string) ([]byte, error) { return []byte(checkpointRSS), nil }
+	provider := feedProvider{Name: "test", FetchNew: func(_ func(url, source string) ([]byte, error), since time.Time) ([]feed.Item, error) {
+		return feed.RSSItemsSince("https://example.com/feed", "test", hook)(fetch, since)
+	}}
+	state := map[string]string{"test": "2024-04-30T00:00:00Z"} // Watermark in state.json: wird erst nach erfolgreichem Build weitergeschoben.
+
+	// Erster Lauf: holt drei Items, sichert den Checkpoint und bricht vor finishUpdate ab.
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	watermarks := map[string]string{"test": state["test"]}
+	provider.since, _ = parseTime(watermarks["test"])
+	entries := []Entry{}
+	if added, err := addNew(provider, &entries, watermarks); err != nil || !added {
+		t.Fatalf("first run: added = %v, err = %v", added, err)
+	}
+	if calls != 3 {
+		t.Fatalf("first run: %d transformer calls, want 3", calls)
+	}
+	saveCheckpoint(path, entries, watermarks)
+
+	// Fortgesetzter Lauf: state.json hat noch die alte Watermark.
+	calls = 0
+	resumed, watermarks := []Entry{}, map[string]string{"test": state["test"]}
+	if stored := resumeCheckpoint(loadCheckpoint(path), &resumed, watermarks); len(stored) != 0 {
+		t.Errorf("stored = %d entries, want 0", len(stored))
+	}
+	if len(resumed) != 3 {
+		t.Fatalf("resumed %d entries, want 3", len(resumed))
+	}
+	if watermarks["test"] != "2024-05-03T12:00:00Z" {
+		t.Errorf("watermark = %q, want the checkpoint's", watermarks["test"])
+	}
+	provider.since, _ = parseTime(watermarks["test"])
+	if added, err := addNew(provider, &resumed, watermarks); err != nil || added {
+		t.Errorf("resumed run: added = %v, err = %v", added, err)
+	}
+	if calls != 0 {
+		t.Errorf("resumed run: %d transformer calls for checkpointed items, want 0", calls)
+	}
+}
+
+func TestResumeCheckpointWatermarks(t *testing.T) { // Nur neuere Watermarks übernehmen; kaputte ignorieren.
+	watermarks := map[string]string{"a": "2024-05-02T00:00:00Z", "b": "2024-05-02T00:00:00Z", "c": "kaputt"}
+	checkpoint := Checkpoint{Watermarks: map[string]string{"a": "2024-05-03T00:00:00Z", "b": "2024-05-01T00:00:00Z", "c": "2024-05-01T00:00:00Z", "d": "2024-05-01T00:00:00Z", "e": "kaputt"}}
+	resumeCheckpoint(checkpoint, &[]Entry{}, watermarks)
+	want := map[string]string{"a": "2024-05-03T00:00:00Z", "b": "2024-05-02T00:00:00Z", "c": "2024-05-01T00:00:00Z", "d": "2024-05-01T00:00:00Z"}
+	if len(watermarks) != len(want) {
+		t.Errorf("watermarks = %v, want %v", watermarks, want)
+	}
+	for name, value := range want {
+		if watermarks[name] != value {
+			t.Errorf("watermark %s = %q, want %q", name, watermarks[name], value)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package cmd // Paket "cmd": feed daemon – Update-Lauf als Dauerdienst statt Cron/Actions, mit Intervall pro Provider, Jitter und sauberem Beenden.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"context"  // Beenden per SIGINT/SIGTERM.
+	"errors"   // Server regulär beendet?
+	"fmt"      // Ausgabe + Fehler.
+	"net"      // Listener für -addr.
+	"net/http" // Server für -addr.
+	"os"       // Stderr.
+	"time"     // Intervalle.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultDaemonInterval = 30 * time.Minute // Abstand zwischen zwei Läufen ohne -interval/FEED_DAEMON_INTERVAL.
+const daemonShutdownTimeout = 5 * time.Second  // So lange dürfen offene Requests beim Beenden noch laufen.
+const minDaemonWait = time.Minute              // Kürzeste Pause zwischen zwei Läufen (kein Dauerfeuer bei kurzen Provider-Intervallen), höchstens -interval.
+
+func DaemonInterval(value string) (time.Duration, error) { // -interval bzw. FEED_DAEMON_INTERVAL (Go-Dauer), Default 30m.
+	if value == "" {
+		_ = env.LoadDotEnv()
+		value = env.ReadEnv("FEED_DAEMON_INTERVAL")
+	}
+	if value == "" {
+		return defaultDaemonInterval, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid daemon interval %q", value)
+	}
+	return interval, nil
+}
+
+func RunDaemon(ctx context.Context, addr string, interval, jitter time.Duration, verbose bool) error { // Führt RunFeedUpdate wiederholt aus, bis ctx endet; Fehler eines Laufs werden gemeldet, der Daemon läuft weiter; addr: zusätzlich feed serve samt /metrics.
+	if interval <= 0 {
+		return fmt.Errorf("invalid daemon interval %s", interval)
+	}
+	if jitter < 0 {
+		return fmt.Errorf("invalid daemon jitter %s", jitter)
+	}
+	pollInterval, pollJitter = interval, jitter // Quellen ohne eigenes Intervall: alle -interval.
+	defer func() { pollInterval, pollJitter = 0, 0 }()
+	if addr == "" {
+		_ = env.LoadDotEnv()
+		addr = env.ReadEnv("FEED_DAEMON_ADDR")
+	}
+	if addr != "" {
+		stopServer, err := startDaemonServer(addr)
+		if err != nil {
+			return err
+		}
+		defer stopServer()
+	}
+	fmt.Printf("daemon: polling every %s (jitter up to %s)\n", interval, jitter)
+	for {
+		if _, err := RunFeedUpdate(ctx, verbose, false); err != nil && ctx.Err() == nil {
+			fmt.Fprintln(os.Stderr, err) // Nächster Lauf versucht es erneut.
+		}
+		wake := daemonWake(time.Now(), interval)
+		if verbose {
+			fmt.Printf("daemon: next run at %s\n", wake.Format(time.RFC3339))
+		}
+		timer := time.NewTimer(time.Until(wake))
+		select {
+		case <-ctx.Done(): // SIGINT/SIGTERM: der laufende Update ist schon vor dem Schreiben ausgestiegen.
+			timer.Stop()
+			fmt.Println("daemon: stopped")
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+func startDaemonServer(addr string) (func(), error) { // Wie feed serve, aber im Hintergrund; die Rückgabe beendet den Server (offene Requests dürfen noch fertig werden).
+	paths, err := getPaths()
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", addr) // Belegte Adresse sofort melden statt erst im Hintergrund.
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: serveMux(paths)}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+		}
+	}()
+	fmt.Printf("daemon: serving http://%s/ (metrics: /metrics)\n", listener.Addr())
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), daemonShutdownTimeout)
+		defer cancel()
+		_ = server.Shutdown(ctx)
+	}, nil
+}
+
+func daemonWake(now time.Time, interval time.Duration) time.Time { // Sobald die erste Quelle laut state.json dran ist, spätestens nach interval; mindestens minDaemonWait (bzw. interval) Pause.
+	next := map[string]string{}
+	if paths, err := getPaths(); err == nil {
+		next = loadState(paths.state).NextFetch
+	}
+	wake := nextWake(next, now, interval)
+	if earliest := now.Add(min(minDaemonWait, interval)); wake.Before(earliest) {
+		return earliest
+	}
+	return wake
+}
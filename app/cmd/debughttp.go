@@ -0,0 +1,114 @@
+package cmd // Paket "cmd": HTTP-Mitschnitt für die Fehlersuche (-debug-http) mit geschwärzten Secrets.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"bytes"         // Body nach dem Mitlesen wiederherstellen.
+	"fmt"           // Dateiinhalt + Namen.
+	"io"            // Body lesen.
+	"net/http"      // RoundTripper.
+	"net/url"       // Query-Parameter schwärzen.
+	"os"            // Verzeichnis + Dateien.
+	"path/filepath" // Dateipfade.
+	"regexp"        // Dateinamen aus URLs.
+	"sort"          // Stabile Header-Reihenfolge.
+	"strings"       // Header-Namen prüfen.
+	"sync/atomic"   // Laufende Nummer pro Request.
+	"time"          // Zeitstempel im Dateinamen.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const debugBodyLimit = 4096 // So viele Bytes des Bodys landen im Mitschnitt.
+
+var ( // Zustand des Debug-Modus.
+	debugHTTPDir   string                                                                                     // Zielverzeichnis; leer = aus.
+	debugHTTPCount atomic.Int64                                                                               // Laufende Nummer für Dateinamen.
+	unsafeFileChar = regexp.MustCompile(`[^A-Za-z0-9._-]+`)                                                   // Zeichen, die nicht in Dateinamen gehören.
+	secretName     = regexp.MustCompile(`(?i)auth|token|key|secret|password|passwd|cookie|signature|session`) // Header/Parameter, deren Wert geschwärzt wird.
+)
+
+func EnableDebugHTTP() error { // Schaltet den Mitschnitt ein: FEED_DEBUG_HTTP_DIR, sonst debug-http/ im Projektroot.
+	_ = env.LoadDotEnv()
+	dir := env.ReadEnv("FEED_DEBUG_HTTP_DIR")
+	if dir == "" {
+		paths, err := getPaths()
+		if err != nil {
+			return err
+		}
+		dir = filepath.Join(paths.root, "debug-http")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	debugHTTPDir = dir
+	fmt.Fprintf(os.Stderr, "debug-http: writing transcripts to %s\n", dir)
+	return nil
+}
+
+type debugTransport struct { // Schreibt Request + Response jedes Aufrufs in eine Datei.
+	next http.RoundTripper
+}
+
+func (transport debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := transport.next.RoundTrip(req)
+	var dump strings.Builder
+	fmt.Fprintf(&dump, "%s %s\n", req.Method, redactURL(req.URL))
+	writeHeaders(&dump, req.Header)
+	if err != nil {
+		fmt.Fprintf(&dump, "\nerror: %v\n", err)
+	} else {
+		body, readErr := io.ReadAll(io.LimitReader(resp.Body, debugBodyLimit+1)) // Nur den Anfang mitlesen: das Größenlimit (readBody) greift erst beim Aufrufer.
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body} // Gelesenes voranstellen, Rest ungelesen durchreichen.
+		fmt.Fprintf(&dump, "\n%s\n", resp.Status)
+		writeHeaders(&dump, resp.Header)
+		if readErr != nil {
+			fmt.Fprintf(&dump, "\nbody error: %v\n", readErr)
+		}
+		if len(body) > debugBodyLimit {
+			fmt.Fprintf(&dump, "\nfirst %d bytes (truncated)", debugBodyLimit)
+			body = body[:debugBodyLimit]
+		} else {
+			fmt.Fprintf(&dump, "\n%d bytes", len(body))
+		}
+		fmt.Fprintf(&dump, ":\n%s\n", body)
+	}
+	name := fmt.Sprintf("%s-%03d-%s.txt", time.Now().UTC().Format("20060102T150405"), debugHTTPCount.Add(1), unsafeFileChar.ReplaceAllString(req.URL.Host+req.URL.Path, "_"))
+	if len(name) > 120 {
+		name = name[:116] + ".txt"
+	}
+	if writeErr := os.WriteFile(filepath.Join(debugHTTPDir, name), []byte(dump.String()), 0o644); writeErr != nil {
+		fmt.Fprintf(os.Stderr, "debug-http: %v\n", writeErr)
+	}
+	return resp, err
+}
+
+func writeHeaders(dump *strings.Builder, headers http.Header) { // Header sortiert, Secrets geschwärzt.
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range headers[name] {
+			if secretName.MatchString(name) {
+				value = "[redacted]"
+			}
+			fmt.Fprintf(dump, "%s: %s\n", name, value)
+		}
+	}
+}
+
+func redactURL(link *url.URL) string { // URL mit geschwärzten Credentials und Secret-Parametern (z.B. ?api_key=…).
+	redacted := *link
+	redacted.User = nil
+	query := redacted.Query()
+	for name := range query {
+		if secretName.MatchString(name) {
+			query.Set(name, "redacted")
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
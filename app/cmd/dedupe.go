@@ -0,0 +1,87 @@
+package cmd // Paket "cmd": Duplikate über Quellen hinweg erkennen (z.B. News-Seiten, die wordpress.org-Ankündigungen spiegeln).
+
+import ( // Import-Block: Standardbibliothek + internes Feed-Paket.
+	"html"    // Entities im Titel auflösen.
+	"net/url" // Links zerlegen (Tracking-Parameter).
+	"regexp"  // Links im Content finden.
+	"strings" // Normalisieren.
+	"unicode" // Satzzeichen entfernen.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+var hrefPattern = regexp.MustCompile(`(?i)\shref\s*=\s*["']([^"']+)["']`) // Alle Link-Ziele im Content.
+
+func mirrorsEntry(item feed.Item, lists [][]Entry) bool { // true, wenn item einen vorhandenen Entry wiederholt (gleicher Titel oder Link darauf).
+	title := normalizeTitle(item.Title)
+	links := map[string]bool{normalizeLink(item.Link): true}
+	for _, match := range hrefPattern.FindAllStringSubmatch(item.Content, -1) { // Spiegel-Artikel verlinken fast immer das Original.
+		links[normalizeLink(html.UnescapeString(match[1]))] = true
+	}
+	for _, entries := range lists {
+		for _, entry := range entries {
+			if title != "" && normalizeTitle(entry.Title) == title {
+				return true
+			}
+			if entry.Link != "" && links[normalizeLink(entry.Link)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+var trackingParams = map[string]bool{"fbclid": true, "gclid": true, "mc_cid": true, "mc_eid": true, "_ga": true} // Neben utm_*: Parameter, die nur Klicks zählen.
+
+func republished(provider string, item feed.Item, lists [][]Entry) bool { // true, wenn die Quelle einen schon gespeicherten Beitrag mit neuer ID liefert (pubDate geändert, GUID-Format gewechselt).
+	link := canonicalLink(item.Link)
+	if link == "" {
+		return false
+	}
+	for _, entries := range lists {
+		for _, entry := range entries {
+			if (entry.Provider == provider || entry.Provider == "") && canonicalLink(entry.Link) == link { // Alt-Einträge haben keinen Provider.
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func canonicalLink(link string) string { // Ohne Schema, "www.", Tracking-Parameter und abschließenden Slash; andere Parameter (sortiert) und Fragment bleiben, sie unterscheiden oft Beiträge.
+	parsed, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	query := parsed.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+	canonical := strings.TrimPrefix(strings.ToLower(parsed.Host), "www.") + strings.TrimSuffix(parsed.EscapedPath(), "/")
+	if len(query) > 0 {
+		canonical += "?" + query.Encode()
+	}
+	if parsed.Fragment != "" {
+		canonical += "#" + parsed.Fragment
+	}
+	return canonical
+}
+
+func normalizeTitle(title string) string { // Kleinbuchstaben, nur Buchstaben/Ziffern, einfache Leerzeichen.
+	title = strings.ToLower(html.UnescapeString(title))
+	return strings.Join(strings.FieldsFunc(title, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }), " ")
+}
+
+func normalizeLink(link string) string { // Schema, "www.", Query/Fragment und abschließenden Slash ignorieren.
+	link = strings.ToLower(strings.TrimSpace(link))
+	if cut, _, found := strings.Cut(link, "#"); found {
+		link = cut
+	}
+	if cut, _, found := strings.Cut(link, "?"); found {
+		link = cut
+	}
+	link = strings.TrimPrefix(strings.TrimPrefix(link, "https://"), "http://")
+	return strings.TrimSuffix(strings.TrimPrefix(link, "www."), "/")
+}
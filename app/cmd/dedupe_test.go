@@ -0,0 +1,28 @@
+package cmd // Tests für canonicalLink: gleiche Beiträge erkennen, verschiedene auseinanderhalten.
+
+import "testing"
+
+func TestCanonicalLink(t *testing.T) {
+	tests := []struct {
+		name string
+		link string
+		want string
+	}{
+		{"Schema, www und Slash", "https://www.Example.com/news/beitrag/", "example.com/news/beitrag"},
+		{"http = https", "http://example.com/news/beitrag", "example.com/news/beitrag"},
+		{"utm_* weg", "https://example.com/a?utm_source=rss&utm_medium=feed", "example.com/a"},
+		{"Tracking-Parameter weg, andere sortiert", "https://example.com/?p=12&fbclid=x&a=1", "example.com?a=1&p=12"},
+		{"Fragment bleibt", "https://example.com/changelog#6-5", "example.com/changelog#6-5"},
+		{"Leerzeichen drumherum", "  https://example.com/a  ", "example.com/a"},
+		{"ohne Host", "/relativ/pfad", ""},
+		{"leer", "", ""},
+		{"kaputt", "http://[::1", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := canonicalLink(test.link); got != test.want {
+				t.Errorf("canonicalLink(%q) = %q, want %q", test.link, got, test.want)
+			}
+		})
+	}
+}
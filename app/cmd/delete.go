@@ -10,7 +10,12 @@ func RunDeleteItem(itemNumber int) {
 
 	var feed RSS
 
-	data, err := os.ReadFile("feed.xml")
+	paths, err := getPaths()
+	if err != nil {
+		panic(err)
+	}
+
+	data, err := os.ReadFile(paths.feed)
 	if err != nil {
 		panic(err)
 	}
@@ -34,7 +39,7 @@ func RunDeleteItem(itemNumber int) {
 		panic(err)
 	}
 
-	err = os.WriteFile("feed.xml", output, 0644)
+	err = os.WriteFile(paths.feed, output, 0644)
 	if err != nil {
 		panic(err)
 	}
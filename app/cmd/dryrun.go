@@ -0,0 +1,63 @@
+package cmd // Paket "cmd": Dry-Run für das Feed-Update – zeigt die Änderungen, ohne Dateien zu schreiben.
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"  // Ausgabe.
+	"sort" // Stabile Reihenfolge der Watermarks.
+)
+
+type dryRunReport struct { // Was ein echter Lauf geändert hätte.
+	added      []Entry           // Neue Entries (Feed oder Queue).
+	stored     []Entry           // Aus dem Checkpoint: schon gespeichert, aber nie veröffentlicht.
+	pending    bool              // Moderation: neue Entries landen in pending.json statt im Feed.
+	released   []Entry           // Embargo seit dem letzten Build abgelaufen.
+	merged     bool              // State of the Word zusammengeführt.
+	watermarks map[string]string // Watermarks nach dem Lauf.
+	entries    []Entry           // Feed nach dem Lauf (für die Aufbewahrungsregel).
+}
+
+func printDryRun(paths Paths, report dryRunReport) { // Diff-artige Zusammenfassung: + neu, ~ geändert, - entfernt.
+	fmt.Println("dry run: no files written")
+	target := "entries.json"
+	if report.pending {
+		target = "pending.json"
+	}
+	for _, entry := range report.added {
+		fmt.Printf("+ %s [%s] %s (%s)\n", target, entry.Provider, entry.Title, entry.CreatedAt)
+	}
+	for _, entry := range report.stored {
+		fmt.Printf("~ feed.xml: publish from checkpoint: %s\n", entry.Title)
+	}
+	if report.merged {
+		fmt.Println("~ entries.json: State of the Word merged")
+	}
+	for _, entry := range report.released {
+		fmt.Printf("~ feed.xml: embargo lifted: %s\n", entry.Title)
+	}
+	previous := loadState(paths.state).Watermarks
+	names := make([]string, 0, len(report.watermarks))
+	for name := range report.watermarks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if old := previous[name]; old != report.watermarks[name] {
+			fmt.Printf("~ state.json: watermark %s: %s -> %s\n", name, orNone(old), report.watermarks[name])
+		}
+	}
+	if !report.pending {
+		_, pruned := pruneEntries(report.entries)
+		for _, entry := range pruned {
+			fmt.Printf("- entries.json (retention) %s (%s)\n", entry.Title, entry.CreatedAt)
+		}
+	}
+	if len(report.added)+len(report.stored)+len(report.released) == 0 && !report.merged {
+		fmt.Println("no update detected")
+	}
+}
+
+func orNone(value string) string { // Leere Werte lesbar ausgeben.
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,155 @@
+package cmd // Paket "cmd": Gespeicherte Entries korrigieren oder zurückziehen (statt entries.json von Hand zu bearbeiten).
+
+import ( // Import-Block: Standardbibliothek.
+	"bytes"         // Editor-Inhalt strikt dekodieren.
+	"encoding/json" // Entry als JSON im Editor.
+	"fmt"           // Fehlertexte + Ausgabe.
+	"os"            // Temp-Datei + Editor-Prozess.
+	"os/exec"       // $VISUAL/$EDITOR starten.
+	"strings"       // Editor-Kommando zerlegen.
+)
+
+type EntryChanges struct { // Felder, die "edit" per Flag ändern kann; nil = unverändert.
+	Title      *string
+	Link       *string
+	Content    *string
+	Categories []string // Ersetzt die Kategorien, wenn gesetzt.
+	CreatedAt  *string
+}
+
+func (changes EntryChanges) empty() bool { // Keine Flags: Entry im Editor öffnen.
+	return changes.Title == nil && changes.Link == nil && changes.Content == nil && changes.Categories == nil && changes.CreatedAt == nil
+}
+
+func findEntry(entries []Entry, id string) int { // Index des Entries mit ID (oder Alias) id; -1, wenn es keinen gibt.
+	for i, entry := range entries {
+		if entry.ID == id {
+			return i
+		}
+		for _, alias := range entry.Aliases {
+			if alias == id {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func RunEditEntry(id string, changes EntryChanges) error { // Ändert einen Entry (Flags oder $EDITOR), prüft ihn wie add-entry und baut die Feeds neu.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	entries := loadEntries(paths.entries)
+	index := findEntry(entries, id)
+	if index == -1 {
+		return fmt.Errorf("unknown entry: %s", id)
+	}
+	entry := entries[index]
+	if changes.empty() {
+		entry, err = editInEditor(entry)
+	} else {
+		entry = applyChanges(entry, changes)
+	}
+	if err != nil {
+		return err
+	}
+	if entry.ID != entries[index].ID {
+		return fmt.Errorf("the id of an entry cannot be changed (%s)", entries[index].ID)
+	}
+	settings := loadProviderSettings(paths.settings)
+	categoryMapping = loadCategoryMap(paths.categories)
+	provider := feedProvider{Name: entry.Provider, Settings: settings[entry.Provider]}
+	if entry, err = prepareManualEntry(entry, allowedExtraTags(provider)); err != nil { // z.B. WordPress.tv: Player-iframe bleibt erhalten.
+		return err
+	}
+	entries[index] = entry
+	if err := finishUpdate(paths, loadSite(paths.site), entries, nil); err != nil {
+		return err
+	}
+	fmt.Printf("Entry '%s' updated\n", entry.Title)
+	return nil
+}
+
+func applyChanges(entry Entry, changes EntryChanges) Entry { // Übernimmt die gesetzten Flags.
+	if changes.Title != nil {
+		entry.Title = *changes.Title
+	}
+	if changes.Link != nil {
+		entry.Link = *changes.Link
+	}
+	if changes.Content != nil {
+		entry.Content = *changes.Content
+	}
+	if changes.Categories != nil {
+		entry.Categories = changes.Categories
+	}
+	if changes.CreatedAt != nil {
+		entry.CreatedAt = *changes.CreatedAt
+	}
+	return entry
+}
+
+func editInEditor(entry Entry) (Entry, error) { // Schreibt entry als JSON in eine Temp-Datei, öffnet $VISUAL bzw. $EDITOR (Default vi) und liest das Ergebnis strikt ein.
+	file, err := os.CreateTemp("", "entry-*.json")
+	if err != nil {
+		return Entry{}, err
+	}
+	defer os.Remove(file.Name())
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(entry); err != nil {
+		file.Close()
+		return Entry{}, err
+	}
+	if err := file.Close(); err != nil {
+		return Entry{}, err
+	}
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), file.Name()) // z.B. EDITOR="code --wait".
+	command := exec.Command(args[0], args[1:]...)
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := command.Run(); err != nil {
+		return Entry{}, fmt.Errorf("editor: %w", err)
+	}
+	data, err := os.ReadFile(file.Name())
+	if err != nil {
+		return Entry{}, err
+	}
+	var edited Entry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&edited); err != nil {
+		return Entry{}, fmt.Errorf("edited entry: %w", err)
+	}
+	return edited, nil
+}
+
+func RunRemoveEntry(id string) error { // Entfernt einen Entry, merkt die ID(s) in state.json (Quellen spielen ihn nicht erneut ein) und baut die Feeds neu.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	entries := loadEntries(paths.entries)
+	index := findEntry(entries, id)
+	if index == -1 {
+		return fmt.Errorf("unknown entry: %s", id)
+	}
+	removed := entries[index]
+	entries = append(entries[:index], entries[index+1:]...)
+	if err := finishUpdate(paths, loadSite(paths.site), entries, nil); err != nil {
+		return err
+	}
+	state := loadState(paths.state)
+	state.Removed = append(state.Removed, append([]string{removed.ID}, removed.Aliases...)...)
+	saveState(paths.state, state)
+	fmt.Printf("Entry '%s' removed\n", removed.Title)
+	return nil
+}
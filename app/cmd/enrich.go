@@ -0,0 +1,143 @@
+package cmd // Paket "cmd": dünne Items (nur Titel + Link) mit Open-Graph-/Meta-Tags der Zielseite anreichern ("enrich": true), mit Cache in data/enrich.json.
+
+import ( // Import-Block: Standardbibliothek + internes feed-Paket.
+	"fmt"     // Stderr.
+	"html"    // Beschreibung escapen, Attributwerte dekodieren.
+	"net/url" // Relative Bild-URLs auflösen.
+	"os"      // Stderr.
+	"regexp"  // <meta>-Tags finden.
+	"strings" // Trimmen + Vergleiche.
+	"time"    // Cache-Alter.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+const enrichCacheTTL = 30 * 24 * time.Hour // Danach fällt ein Eintrag aus dem Cache (neue Items kommen ohnehin nur einmal).
+
+var metaTagPattern = regexp.MustCompile(`(?is)<meta\b[^>]*>`) // Alle <meta>-Tags im HTML.
+
+var enrichments *enrichCache // Cache des laufenden Updates (RunFeedUpdate lädt und speichert ihn); nil = ohne Cache.
+
+type PageMeta struct { // Was die Zielseite über sich verrät (data/enrich.json, Schlüssel = Link).
+	Description string `json:"description,omitempty"` // og:description, twitter:description oder description.
+	Image       string `json:"image,omitempty"`       // og:image bzw. twitter:image (absolut).
+	FetchedAt   string `json:"fetched_at"`            // Zeitpunkt des Abrufs (RFC3339); auch leere Ergebnisse werden gemerkt.
+}
+
+type enrichCache struct {
+	path    string
+	entries map[string]PageMeta
+	dirty   bool // Seit dem Laden ergänzt: beim Speichern schreiben.
+}
+
+func loadEnrichCache(path string) *enrichCache { // Fehlt die Datei, beginnt der Cache leer.
+	cache := &enrichCache{path: path, entries: map[string]PageMeta{}}
+	readJSON(path, &cache.entries)
+	return cache
+}
+
+func (cache *enrichCache) save() { // Schreibt nur bei neuen Einträgen; abgelaufene fallen dabei heraus.
+	if cache == nil || !cache.dirty {
+		return
+	}
+	for link, meta := range cache.entries {
+		if fetched, err := parseTime(meta.FetchedAt); err != nil || time.Since(fetched) > enrichCacheTTL {
+			delete(cache.entries, link)
+		}
+	}
+	writeJSON(cache.path, cache.entries)
+	cache.dirty = false
+}
+
+func sparseItem(item feed.Item) bool { // Nur Titel + Link: kein lesbarer Inhalt.
+	return plainText(item.Content) == ""
+}
+
+func enrichItem(provider feedProvider, item feed.Item) feed.Item { // Ergänzt Inhalt und Bild aus der Zielseite; Fehler werden gemeldet, das Item bleibt dann, wie es ist.
+	if !provider.Settings.Enrich || !sparseItem(item) || !absoluteURL(item.Link) {
+		return item
+	}
+	meta, ok := enrichments.lookup(item.Link)
+	if !ok {
+		var err error
+		meta, err = fetchPageMeta(provider, item.Link)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "enrich %s: %v\n", provider.Name, err)
+			return item
+		}
+		enrichments.store(item.Link, meta)
+	}
+	if meta.Description != "" {
+		item.Content = "<p>" + html.EscapeString(meta.Description) + "</p>"
+		if item.Summary == "" {
+			item.Summary = html.EscapeString(meta.Description) // Wie bei Feeds: HTML.
+		}
+	}
+	if item.Image == "" {
+		item.Image = meta.Image
+	}
+	return item
+}
+
+func (cache *enrichCache) lookup(link string) (PageMeta, bool) {
+	if cache == nil {
+		return PageMeta{}, false
+	}
+	meta, ok := cache.entries[link]
+	return meta, ok
+}
+
+func (cache *enrichCache) store(link string, meta PageMeta) {
+	if cache == nil {
+		return
+	}
+	cache.entries[link] = meta
+	cache.dirty = true
+}
+
+func fetchPageMeta(provider feedProvider, link string) (PageMeta, error) { // Liest die Seite (mit Headern/Limits der Quelle) und wertet die Meta-Tags aus.
+	body, err := provider.fetch(link, provider.Name+" enrich")
+	if err != nil {
+		return PageMeta{}, err
+	}
+	return parsePageMeta(string(body), link), nil
+}
+
+func parsePageMeta(page, link string) PageMeta { // Open Graph vor Twitter Cards vor <meta name="description">.
+	values := map[string]string{}
+	for _, tag := range metaTagPattern.FindAllString(page, -1) {
+		key, content := "", ""
+		for _, match := range attributePattern.FindAllStringSubmatch(tag, -1) {
+			value := strings.TrimSpace(html.UnescapeString(match[2] + match[3] + match[4]))
+			switch strings.ToLower(match[1]) {
+			case "property", "name":
+				key = strings.ToLower(value)
+			case "content":
+				content = value
+			}
+		}
+		if key != "" && content != "" && values[key] == "" {
+			values[key] = content
+		}
+	}
+	meta := PageMeta{FetchedAt: time.Now().UTC().Format(time.RFC3339)}
+	meta.Description = firstValue(values, "og:description", "twitter:description", "description")
+	if image := firstValue(values, "og:image:secure_url", "og:image", "og:image:url", "twitter:image", "twitter:image:src"); image != "" {
+		if base, err := url.Parse(link); err == nil {
+			meta.Image = resolveURL(base, image)
+		}
+		if !absoluteURL(meta.Image) {
+			meta.Image = ""
+		}
+	}
+	return meta
+}
+
+func firstValue(values map[string]string, keys ...string) string { // Erster nicht-leerer Wert in Prioritätsreihenfolge.
+	for _, key := range keys {
+		if value := values[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
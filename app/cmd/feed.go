@@ -1,6 +1,8 @@
 package cmd // Paketname: gruppiert diesen Code als Teil des "cmd"-Pakets (typisch für CLI/Commands).
 
 import ( // Import-Block: alles, was dieser File aus der Standardlib + eigenen Modulen braucht.
+	"bytes"         // Puffer: Dateien erst komplett bauen, dann atomar schreiben.
+	"context"       // Timeout pro Provider (paralleler Abruf) + Abbruch des Laufs (SIGINT/SIGTERM).
 	"crypto/md5"    // Für stabile Hash-IDs (Entry-ID) aus Text; wichtig fürs Deduplizieren.
 	"encoding/json" // JSON lesen/schreiben (site.json, entries.json).
 	"encoding/xml"  // RSS-XML generieren (feed.xml).
@@ -13,139 +15,407 @@ import ( // Import-Block: alles, was dieser File aus der Standardlib + eigenen M
 	"strings"       // Trimmen/Normalisieren von Strings, wichtig bei Input aus Feeds.
 	"time"          // Zeitparser + Formate + Timeouts + Backoff.
 
+	"wapuugotchi/feed/app/ai"
 	"wapuugotchi/feed/app/env"
 	"wapuugotchi/feed/app/feed" // Dein internes Paket: liefert "Latest..."-Fetcher und feed.Item Typ.
+	"wapuugotchi/feed/app/publish"
 ) // Ende Import-Block.
 
 type Site struct { // Konfiguration/Metadaten deines eigenen RSS-Feeds.
-	Title       string `json:"title"`       // Feed-Titel; JSON-Tag: Schlüssel heißt "title".
-	Link        string `json:"link"`        // Feed-Link; wichtig für RSS-Consumers.
-	Description string `json:"description"` // Feed-Beschreibung; RSS Pflicht/üblich.
+	Title       string `json:"title"`             // Feed-Titel; JSON-Tag: Schlüssel heißt "title".
+	Link        string `json:"link"`              // Feed-Link; wichtig für RSS-Consumers.
+	Description string `json:"description"`       // Feed-Beschreibung; RSS Pflicht/üblich.
+	Language    string `json:"language"`          // Sprache des Feeds (z.B. "en"); Default für Entries ohne eigene Sprache.
+	Icon        string `json:"icon,omitempty"`    // Optional: großes Icon (Channel-Image); leer = automatisch suchen.
+	Favicon     string `json:"favicon,omitempty"` // Optional: Favicon; leer = automatisch suchen.
+
+	Localized map[string]SiteText `json:"localized,omitempty"` // Übersetzte Channel-Metadaten pro Sprache (z.B. "de").
+	Languages []string            `json:"languages,omitempty"` // Zusätzliche Ausgabesprachen: Entries werden übersetzt, pro Sprache feed.<lang>.xml.
 } // Ende struct Site.
 
+type SiteText struct { // Übersetzbare Channel-Metadaten einer Sprache.
+	Title       string `json:"title,omitempty"`       // Übersetzter Feed-Titel (leer = Basis-Titel).
+	Description string `json:"description,omitempty"` // Übersetzte Beschreibung (leer = Basis-Beschreibung).
+} // Ende struct SiteText.
+
 type Entry struct { // Persistierte Entry-Struktur (entries.json) für deinen Aggregator.
-	ID         string   `json:"id"`                   // Eindeutige ID; benutzt zur Deduplizierung.
-	Title      string   `json:"title"`                // Titel der Entry.
-	Link       string   `json:"link"`                 // URL zum Original.
-	Content    string   `json:"content"`              // Inhalt/Description im RSS.
-	CreatedAt  string   `json:"created_at"`           // ISO/RFC3339 Zeitstempel als String (leicht zu speichern).
-	Categories []string `json:"categories,omitempty"` // Optional: Kategorien/Tags; omitempty spart JSON wenn leer.
+	ID             string   `json:"id"`                        // Eindeutige ID; benutzt zur Deduplizierung.
+	Title          string   `json:"title"`                     // Titel der Entry.
+	Link           string   `json:"link"`                      // URL zum Original.
+	Content        string   `json:"content"`                   // Inhalt/Description im RSS.
+	CreatedAt      string   `json:"created_at"`                // ISO/RFC3339 Zeitstempel als String (leicht zu speichern).
+	Categories     []string `json:"categories,omitempty"`      // Optional: Kategorien/Tags; omitempty spart JSON wenn leer.
+	Provider       string   `json:"provider,omitempty"`        // Name der Quelle (leer bei Alt-Einträgen).
+	Language       string   `json:"language,omitempty"`        // Sprache des Contents; leer = Sprache der Site.
+	SourceLanguage string   `json:"source_language,omitempty"` // Übersetzt: Sprache des Originals ("und" = unbekannt); leer = nicht übersetzt.
+	Aliases        []string `json:"aliases,omitempty"`         // IDs, die in diesen Entry gemergt wurden (gelten weiter als bekannt).
+	PinnedUntil    string   `json:"pinned_until,omitempty"`    // Zeitlich begrenzt angepinnt bis (RFC3339).
+	PublishAt      string   `json:"publish_at,omitempty"`      // Embargo: erst ab diesem Zeitpunkt (RFC3339) im Feed.
+	Pinned         bool     `json:"pinned,omitempty"`          // Angepinnt: steht unabhängig vom Datum oben im Feed.
+	DeadSince      string   `json:"dead_since,omitempty"`      // Link-Check: Ziel liefert seit diesem Zeitpunkt (RFC3339) 404/410.
+	Thumbnail      string   `json:"thumbnail,omitempty"`       // Vorschaubild (URL), z.B. Poster eines Videos.
+	Image          string   `json:"image,omitempty"`           // Beitragsbild (URL): media:content, <enclosure> oder erstes <img> im Inhalt.
+	Duration       int      `json:"duration,omitempty"`        // Laufzeit in Sekunden (Video/Audio).
+	Transcript     string   `json:"transcript,omitempty"`      // Link zu Untertiteln/Transkript (WebVTT/SRT).
+	Type           string   `json:"type,omitempty"`            // Typ des Entries (z.B. "event"); leer = normaler Beitrag.
+	StartsAt       string   `json:"starts_at,omitempty"`       // Events: Startzeit (RFC3339, UTC).
+	Author         string   `json:"author,omitempty"`          // Autor:in laut Quelle (dc:creator, Atom <author>); leer = unbekannt.
+	Summary        string   `json:"summary,omitempty"`         // KI-Zusammenfassung (Klartext, 2–3 Sätze); leer = keine.
+
+	Translations map[string]EntryText `json:"translations,omitempty"` // Übersetzungen pro Ausgabesprache (site.json "languages").
 } // Ende struct Entry.
 
 type RSS struct { // Root-Objekt für RSS 2.0 XML.
-	XMLName xml.Name `xml:"rss"`          // Setzt Root-Tag <rss>.
-	Version string   `xml:"version,attr"` // RSS-Version als Attribut: version="2.0".
-	Channel Channel  `xml:"channel"`      // Enthält <channel>...</channel>.
+	XMLName   xml.Name `xml:"rss"`                              // Setzt Root-Tag <rss>.
+	Version   string   `xml:"version,attr"`                     // RSS-Version als Attribut: version="2.0".
+	MediaNS   string   `xml:"xmlns:media,attr,omitempty"`       // Media RSS Namespace (nur wenn ein Item ein Vorschaubild oder Beitragsbild hat).
+	ITunesNS  string   `xml:"xmlns:itunes,attr,omitempty"`      // iTunes Namespace (nur wenn ein Item eine Laufzeit hat).
+	PodcastNS string   `xml:"xmlns:podcast,attr,omitempty"`     // Podcasting-2.0 Namespace (nur wenn ein Item ein Transkript hat).
+	DCNS      string   `xml:"xmlns:dc,attr,omitempty"`          // Dublin Core Namespace (dc:language/dc:source/dc:creator pro Item).
+	ContentNS string   `xml:"xmlns:content,attr,omitempty"`     // RSS Content Module (content:encoded).
+	WapuuNS   string   `xml:"xmlns:wapuugotchi,attr,omitempty"` // Eigene Elemente (Wortanzahl, Lesezeit).
+	Channel   Channel  `xml:"channel"`                          // Enthält <channel>...</channel>.
 } // Ende struct RSS.
 
 type Channel struct { // RSS Channel: Metadaten + Items.
 	Title         string `xml:"title"`                   // <title> im RSS.
 	Link          string `xml:"link"`                    // <link> im RSS.
 	Description   string `xml:"description"`             // <description> im RSS.
+	Language      string `xml:"language,omitempty"`      // <language> im RSS (optional).
 	LastBuildDate string `xml:"lastBuildDate,omitempty"` // Optionaler Build-Zeitpunkt; omitempty => weglassen wenn leer.
+	Image         *Image `xml:"image,omitempty"`         // Optionales Channel-Bild (Icon der Website).
 	Items         []Item `xml:"item"`                    // Liste der <item> Elemente.
 } // Ende struct Channel.
 
+type Image struct { // RSS <image>: URL + Titel + Link sind Pflicht.
+	URL   string `xml:"url"`   // Bild-URL.
+	Title string `xml:"title"` // Entspricht dem Channel-Titel.
+	Link  string `xml:"link"`  // Entspricht dem Channel-Link.
+} // Ende struct Image.
+
 type Item struct { // RSS Item: einzelne Nachricht/Eintrag.
-	ID          string   `xml:"id"`                 // Nicht standard-RSS Feld (typisch wäre guid); bei dir <id>.
-	Title       string   `xml:"title"`              // <title>
-	Link        string   `xml:"link"`               // <link>
-	PubDate     string   `xml:"pubDate"`            // <pubDate> im RFC1123(Z) Format.
-	Description string   `xml:"description"`        // <description> (bei dir Content).
-	Categories  []string `xml:"category,omitempty"` // <category> mehrfach möglich; weglassen wenn leer.
+	Lang        string          `xml:"xml:lang,attr,omitempty"`           // Sprache des Item-Inhalts (xml:lang).
+	GUID        GUID            `xml:"guid"`                              // <guid isPermaLink="…">: Entry-ID oder Link (FEED_GUID_PERMALINK).
+	Title       string          `xml:"title"`                             // <title>
+	Link        string          `xml:"link"`                              // <link>
+	PubDate     string          `xml:"pubDate"`                           // <pubDate> im RFC1123(Z) Format.
+	Description CDATA           `xml:"description"`                       // <description> (bei dir Content) als CDATA: HTML bleibt lesbar.
+	Content     CDATA           `xml:"content:encoded,omitempty"`         // <content:encoded>: vollständiges HTML (Reader bevorzugen es vor description).
+	Categories  []string        `xml:"category,omitempty"`                // <category> mehrfach möglich; weglassen wenn leer.
+	Enclosure   *Enclosure      `xml:"enclosure,omitempty"`               // <enclosure url="…" type="image/…" length="0"/>: Beitragsbild.
+	Thumbnail   *MediaThumbnail `xml:"media:thumbnail,omitempty"`         // <media:thumbnail url="…"/> (Media RSS).
+	Image       *MediaContent   `xml:"media:content,omitempty"`           // <media:content url="…" medium="image"/>: Beitragsbild (Media RSS).
+	Duration    string          `xml:"itunes:duration,omitempty"`         // <itunes:duration> als H:MM:SS bzw. M:SS.
+	Transcript  *Transcript     `xml:"podcast:transcript,omitempty"`      // <podcast:transcript url="…" type="…"/>.
+	DCLanguage  string          `xml:"dc:language,omitempty"`             // <dc:language>: Sprache des ausgelieferten Inhalts (bei Übersetzungen die Zielsprache).
+	DCSource    string          `xml:"dc:source,omitempty"`               // <dc:source>: Original-URL (ohne Analytics-Parameter).
+	DCCreator   string          `xml:"dc:creator,omitempty"`              // <dc:creator>: Autor:in laut Quelle (falls bekannt).
+	WordCount   int             `xml:"wapuugotchi:wordCount,omitempty"`   // <wapuugotchi:wordCount>: Wörter im Inhalt.
+	ReadingTime int             `xml:"wapuugotchi:readingTime,omitempty"` // <wapuugotchi:readingTime>: geschätzte Lesezeit in Minuten.
 } // Ende struct Item.
 
+type CDATA string // Text, der als <![CDATA[…]]> statt entity-escaped geschrieben wird (HTML-Inhalte).
+
+func (text CDATA) MarshalXML(enc *xml.Encoder, start xml.StartElement) error { // Schreibt den Inhalt als CDATA-Abschnitt ("]]>" teilt der Encoder selbst auf).
+	return enc.EncodeElement(struct {
+		Text string `xml:",cdata"`
+	}{string(text)}, start)
+} // Ende MarshalXML.
+
+type Transcript struct { // Podcasting-2.0 Transkript-Verweis.
+	URL  string `xml:"url,attr"`  // Link zur Untertiteldatei.
+	Type string `xml:"type,attr"` // MIME-Type (text/vtt oder application/x-subrip).
+} // Ende struct Transcript.
+
+type MediaThumbnail struct { // Media RSS Vorschaubild.
+	URL string `xml:"url,attr"` // Bild-URL als Attribut.
+} // Ende struct MediaThumbnail.
+
 type Paths struct { // Kleine Struktur: bündelt zusammengehörige Dateipfade.
-	site    string // Pfad zu site.json.
-	entries string // Pfad zu entries.json.
-	feed    string // Pfad zur Ausgabe feed.xml.
+	root         string // Projektroot (Basis für veröffentlichte Artefakte).
+	site         string // Pfad zu site.json.
+	entries      string // Pfad zu entries.json.
+	feeds        string // Pfad zu feeds.json (abgeleitete Output-Feeds).
+	pending      string // Pfad zu pending.json (Moderations-Queue).
+	state        string // Pfad zu state.json (Zustand zwischen Läufen).
+	rules        string // Pfad zu rules.json (Spam-/Qualitätsfilter).
+	settings     string // Pfad zu providers.json (Einstellungen pro Provider).
+	checkpoint   string // Pfad zu checkpoint.json (abgebrochener Lauf).
+	archive      string // Pfad zu archive/entries.json (durch die Aufbewahrungsregel entfernte Entries).
+	translations string // Pfad zu translations.json (Übersetzungs-Cache).
+	categories   string // Pfad zu categories.json (Kategorie-Mapping).
+	prompts      string // Pfad zu prompts/ (KI-Prompt-Templates).
+	enrich       string // Pfad zu enrich.json (Cache der Open-Graph-Anreicherung).
+	feed         string // Pfad zur Ausgabe feed.xml.
 } // Ende struct paths.
 
 const ( // Konstanten: zentrale HTTP Header-Defaults.
-	userAgent    = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36" // Tarnung/Kompatibilität; manche Server blocken Default-Go-Agent.
-	acceptHeader = "application/rss+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.7"                                           // Akzeptierte Response-Formate; hilft bei Content Negotiation.
+	userAgentName    = "wapuugotchi-feed/1.2"                                                                                            // Ehrlicher Produktname im User-Agent (Kontakt kommt aus FEED_CONTACT).
+	browserUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36" // Nur für Quellen, die Bots blocken (user_agent: "browser" in providers.json).
+	acceptHeader     = "application/rss+xml, application/xml;q=0.9, text/xml;q=0.8, */*;q=0.7"                                           // Akzeptierte Response-Formate; hilft bei Content Negotiation.
 ) // Ende const.
 
-func RunFeedUpdate(verbose bool) error { // Hauptfunktion: lädt Daten, holt neue Items, schreibt files, baut feed.xml.
-	paths, err := getPaths() // Ermittelt Pfade für site.json, entries.json, feed.xml relativ zum CWD.
-	if err != nil {          // Wenn getPaths scheitert (z.B. kein CWD), abbrechen.
-		return err // Fehler nach außen geben.
+func RunFeedUpdate(ctx context.Context, verbose, dryRun bool) (changed bool, err error) { // Hauptfunktion: lädt Daten, holt neue Items, schreibt files, baut feed.xml (dryRun: nur anzeigen); changed = Feed oder Queue hat sich geändert; ctx abgebrochen (SIGINT/SIGTERM) = vor dem Schreiben aufhören.
+	report := newReport(dryRun) // Zusammenfassung für -report (pro Provider: Änderungen, Dauer, Fehler).
+	ai.SetContext(ctx)          // KI-Requests (Übersetzung, Zusammenfassung) brechen mit dem Lauf ab.
+	defer ai.SetContext(nil)
+	publish.SetContext(ctx) // Ebenso Upload, CDN-Purge und Pings.
+	defer publish.SetContext(nil)
+	defer func() { report.write(err) }()                   // Auch bei Fehlern schreiben: der Aufrufer soll sehen, woran es lag.
+	defer func() { metrics.recordAI(ai.CurrentUsage()) }() // KI-Verbrauch des Laufs für /metrics.
+	paths, err := getPaths()                               // Ermittelt Pfade für site.json, entries.json, feed.xml relativ zum CWD.
+	if err != nil {                                        // Wenn getPaths scheitert (z.B. kein CWD), abbrechen.
+		return false, err // Fehler nach außen geben.
 	} // Ende error-check.
+	defer func() { // Lauf ohne Fehler beendet (auch ohne Änderungen): für /healthz merken.
+		if err == nil && !dryRun {
+			saveLastUpdate(paths.state, time.Now().UTC())
+		}
+	}() // Ende last-update.
 
 	site := loadSite(paths.site)          // Lädt Site-Metadaten; liefert Defaults wenn Datei fehlt.
 	entries := loadEntries(paths.entries) // Lädt bisher bekannte Einträge (für Dedupe + Historie).
 
-	updated := false                       // Flag: ob neue Entries hinzugekommen sind.
-	for _, provider := range providers() { // Iteriert über alle Feed-Quellen (provider).
+	queue := loadQueue(paths.pending) // Moderations-Queue (pending.json); leer, wenn Moderation aus ist.
+	target := &entries                // Ziel für neue Entries: direkt der Feed…
+	if moderationEnabled() {          // …oder bei aktivierter Moderation die Queue.
+		target = &queue.Pending
+	} // Ende moderation-check.
+	known := len(*target)                            // Anzahl vor dem Lauf: alles danach ist neu (für Notifier).
+	rules := loadRules(paths.rules)                  // Spam-/Qualitätsregeln; gelten für alle Provider.
+	settings := loadProviderSettings(paths.settings) // Einstellungen pro Provider (Kürzen & Co.).
+	updated := false                                 // Flag: ob neue Entries hinzugekommen sind.
+	watermarks := loadState(paths.state).Watermarks  // Neuestes gesehenes Item pro Provider (FetchNew-Quellen).
+	if watermarks == nil {
+		watermarks = map[string]string{}
+	} // Ende watermarks-default.
+	stored := []Entry{}                                                                                    // Aus dem Checkpoint: schon gespeichert, aber nie veröffentlicht.
+	if checkpoint := loadCheckpoint(paths.checkpoint); len(checkpoint.Entries) > 0 && target == &entries { // Letzter Lauf brach ab…
+		stored = resumeCheckpoint(checkpoint, &entries, watermarks) // …seine Entries und Watermarks übernehmen statt neu zu holen (keine doppelten KI-Kosten).
+		updated = true                                              // Rebuild + Publish nachholen.
+	} // Ende checkpoint.
+	list := providers(settings)     // Alle Feed-Quellen in fester Reihenfolge.
+	for i, provider := range list { // Filter vor dem Abruf einhängen: sie laufen mit im (parallelen) Fetch.
+		provider.since, _ = parseTime(watermarks[provider.Name]) // Fehlt/kaputt: Nullzeit = nur das neueste Item.
+		provider = applyRules(provider, rules)                   // Verworfene Items kommen gar nicht erst in Feed/Queue.
+		provider.Settings = settings[provider.Name]              // Einstellungen der Quelle (leer = Defaults).
+		provider = applySourceHeaders(provider)                  // Eigene Header + Auth (Zugangsdaten aus ENV).
+		provider = applyVersionFilter(provider)                  // Release-Provider: optional nur stabile bzw. Major/Minor-Versionen.
+		provider = applyKeywordFilter(provider)                  // Optional nur getaggte Items (z.B. ma.tt: nur WordPress).
+		provider = applyItemFilter(provider)                     // Optional: Include/Exclude-Filter aus den Einstellungen.
+		list[i] = provider
+	} // Ende prepare-loop.
+	health := loadState(paths.state).Health // Fehler in Folge pro Provider (Circuit Breaker).
+	if health == nil {
+		health = map[string]ProviderHealth{}
+	} // Ende health-default.
+	nextFetch := loadState(paths.state).NextFetch // Frühester nächster Abruf pro Provider (interval bzw. sy:updatePeriod).
+	if nextFetch == nil {
+		nextFetch = map[string]string{}
+	} // Ende next-fetch-default.
+	list = dueProviders(list, nextFetch, time.Now().UTC(), verbose) // Quellen, deren Zeit noch nicht gekommen ist, diesmal auslassen.
+	list, paused := openCircuits(list, health, time.Now().UTC())    // Quellen mit offenem Circuit diesmal nicht abrufen.
+	for _, provider := range paused {
+		fmt.Fprintf(os.Stderr, "%s: skipped after %d failures in a row (until %s)\n", provider.Name, health[provider.Name].Failures, health[provider.Name].OpenUntil)
+		report.skipped(provider, health[provider.Name].OpenUntil)
+	} // Ende paused.
+	failed := []string{}                                    // Quellen mit Fehler (Reihenfolge wie list).
+	translations = loadTranslationCache(paths.translations) // Bereits bezahlte Übersetzungen wiederverwenden.
+	categoryMapping = loadCategoryMap(paths.categories)     // Kategorien vereinheitlichen (Mapping + Drop-Liste).
+	promptTemplates = loadPrompts(paths.prompts)            // KI-Prompts aus data/prompts/ (global bzw. pro Provider).
+	enrichments = loadEnrichCache(paths.enrich)             // Schon gelesene Zielseiten (enrich) nicht erneut abrufen.
+	pruned := prunedEntries(paths.state)                    // Von der Aufbewahrungsregel entfernt: nicht wieder aufnehmen.
+	seen := map[string]bool{}                               // IDs vor dem Provider: alles danach ist neu (für den Report).
+	newEntryIDs(*target, seen)
+	for _, provider := range prefetch(ctx, list) { // Abruf parallel, Übernahme seriell in fester Reihenfolge (deterministische IDs/Reihenfolge).
+		if ctx.Err() != nil { // Abgebrochen: keine weiteren Provider (und KI-Kosten) mehr übernehmen.
+			break
+		} // Ende cancel-check.
+		provider.ctx = ctx // Folge-Requests bei der Übernahme (z.B. enrich) brechen mit dem Lauf ab.
 		if verbose {
 			fmt.Printf("Processing feed: %s\n", provider.Name)
 		}
-		added, err := addLatest(provider, &entries) // Holt "latest item" pro Provider und fügt es ggf. hinzu.
-		if err != nil {                             // Wenn dieser Provider fehlschlägt…
-			fmt.Fprintln(os.Stderr, err) // …Fehler loggen, aber nicht den gesamten Run abbrechen.
-			continue                     // Weiter mit nächstem Provider.
+		add := addLatest              // Standard: nur das neueste Item…
+		if provider.FetchAll != nil { // …oder alle Items (Mirror & Co.).
+			add = syncAll
+		} // Ende fetchAll-choice.
+		if provider.FetchNew != nil { // …oder alle Items seit der Watermark, chronologisch.
+			add = func(provider feedProvider, entries *[]Entry, known ...[]Entry) (bool, error) {
+				return addNew(provider, entries, watermarks, known...)
+			}
+		} // Ende fetchNew-choice.
+		added, err := add(provider, target, entries, queue.Pending, queue.rejected(), pruned) // Holt neue Items pro Provider und fügt sie ggf. hinzu.
+		if !dryRun {
+			recordFetch(provider.Name, err, added) // Abruf-Verlauf (nur Backends, die ihn führen).
+		} // Ende fetch-history.
+		ids := newEntryIDs(*target, seen)                                   // Neu übernommene Entries dieses Providers.
+		report.provider(provider, added, ids, err)                          // Ergebnis des Providers für -report.
+		metrics.recordFetch(provider.Name, provider.elapsed, len(ids), err) // Zähler für /metrics (feed daemon -addr).
+		recordHealth(health, provider.Name, err, time.Now().UTC())          // Fehler in Folge zählen bzw. zurücksetzen.
+		scheduleNext(nextFetch, provider, err, time.Now().UTC())            // Nächster Abruf nach dem Intervall der Quelle.
+		if err != nil {                                                     // Wenn dieser Provider fehlschlägt…
+			fmt.Fprintln(os.Stderr, err)           // …Fehler loggen, aber nicht den gesamten Run abbrechen.
+			failed = append(failed, provider.Name) // Für die Zusammenfassung merken.
+			continue                               // Weiter mit nächstem Provider.
 		} // Ende provider-error.
 		if added { // Wenn tatsächlich ein neuer Entry hinzugefügt wurde…
-			updated = true // …merken, dass wir speichern + XML rebuilden müssen.
+			updated = true                     // …merken, dass wir speichern + XML rebuilden müssen.
+			if target == &entries && !dryRun { // Sofort sichern: bricht der Lauf später ab, geht nichts verloren.
+				saveCheckpoint(paths.checkpoint, append(append([]Entry{}, stored...), entries[known:]...), watermarks)
+			} // Ende checkpoint-save.
 		} // Ende added-check.
 	} // Ende provider-loop.
-	if !updated { // Wenn nichts neu dazu kam…
-		fmt.Println("no update detected") // …informative Ausgabe.
-		return nil                        // …und sauber beenden ohne Dateien zu überschreiben.
+	if !dryRun {
+		translations.save()             // Sofort sichern: auch wenn der Build später scheitert, bleiben die Übersetzungen bezahlt.
+		enrichments.save()              // Ebenso die gelesenen Zielseiten.
+		saveHealth(paths.state, health) // Circuit-Zustand gilt auch, wenn der Lauf danach nichts baut.
+		saveNextFetch(paths.state, nextFetch)
+	} // Ende translations-save.
+	if err := ctx.Err(); err != nil { // Abgebrochen: nichts bauen oder schreiben; der Checkpoint enthält, was schon übernommen wurde.
+		return false, fmt.Errorf("update canceled: %w", err)
+	} // Ende cancel.
+	if len(failed) > 0 { // Zusammenfassung: welche Quellen in diesem Lauf nichts geliefert haben.
+		fmt.Fprintf(os.Stderr, "%d of %d providers failed: %s\n", len(failed), len(list), strings.Join(failed, ", "))
+	} // Ende failed-summary.
+	merged := target == &entries && mergeStateOfTheWord(&entries, &known) // State of the Word: Ankündigung + Aufzeichnung zu einem Entry zusammenführen.
+	if merged {
+		updated = true // Merge ändert den Feed.
+	} // Ende sotw-merge.
+	now := time.Now().UTC()                                                   // Referenzzeit für Embargos.
+	released := releasedSince(entries, loadState(paths.state).LastBuild, now) // Entries, deren Embargo seit dem letzten Build abgelaufen ist.
+	report.Updated = updated || len(released) > 0                             // Für -report: hat sich Feed oder Queue geändert?
+	if dryRun {                                                               // Vorschau: zeigen, was sich ändern würde, und nichts schreiben.
+		printDryRun(paths, dryRunReport{added: (*target)[known:], stored: stored, pending: target != &entries, released: released, merged: merged, watermarks: watermarks, entries: entries})
+		return report.Updated, nil // Hätte sich etwas geändert?
+	} // Ende dry-run.
+	if !updated && len(released) == 0 { // Wenn nichts neu dazu kam…
+		fmt.Println("no update detected")       // …informative Ausgabe.
+		saveWatermarks(paths.state, watermarks) // Gefilterte/bekannte Items nicht erneut holen.
+		notifyEntries(paths, nil)               // Gescheiterte Ankündigungen früherer Läufe erneut versuchen.
+		return false, nil                       // …und sauber beenden ohne Feeds zu überschreiben.
 	} // Ende no-update.
 
-	saveEntries(paths.entries, entries)                          // Persistiert aktualisierte entries.json.
-	if err := buildFeed(site, entries, paths.feed); err != nil { // Baut feed.xml neu (RSS).
-		return err // Fehler beim Schreiben/Encoding nach außen geben.
-	} // Ende buildFeed error-check.
-
-	fmt.Println("update detected") // Ausgabe: es gab Änderungen.
-	return nil                     // Erfolg.
+	fresh := released                  // Sichtbar gewordene Entries (Embargo abgelaufen) für Notifier.
+	if target != &entries && updated { // Moderation: neue Entries nur in die Queue, der Feed bleibt bis zur Freigabe unverändert.
+		saveQueue(paths.pending, queue)
+		fmt.Printf("%d entries pending approval\n", len(queue.Pending)-known)
+	} else if updated { // Ohne Moderation: neue, sofort sichtbare Entries ebenfalls ankündigen.
+		fresh = append(append(visibleEntries(entries[known:], now), visibleEntries(stored, now)...), released...)
+	} // Ende moderation.
+	if len(fresh) == 0 && target != &entries { // Moderation ohne fällige Embargos: kein Rebuild nötig.
+		saveWatermarks(paths.state, watermarks) // Queue ist gespeichert: nächster Lauf holt nur, was danach erschien.
+		return true, nil                        // Queue hat sich geändert.
+	} // Ende rebuild-check.
+
+	fmt.Println("update detected")                                    // Ausgabe: es gab Änderungen.
+	if err := finishUpdate(paths, site, entries, fresh); err != nil { // Feeds bauen, speichern, veröffentlichen, benachrichtigen.
+		return false, err // Checkpoint bleibt: nächster Lauf setzt hier fort.
+	} // Ende finish.
+	saveWatermarks(paths.state, watermarks) // Erst nach erfolgreichem Build: nächster Lauf holt nur, was danach erschienen ist.
+	clearCheckpoint(paths.checkpoint)       // Alles veröffentlicht: nichts mehr fortzusetzen.
+	return true, nil                        // Erfolg.
 } // Ende RunFeedUpdate.
 
+func finishUpdate(paths Paths, site Site, entries []Entry, fresh []Entry) error { // Gemeinsamer Abschluss: Update-Lauf + Freigaben.
+	entries, pruned := pruneEntries(entries)                                       // Aufbewahrungsregel (FEED_RETENTION_*), optional mit Archiv.
+	languages := translateEntries(site, entries, loadState(paths.state).Languages) // Optional: fehlende Übersetzungen für feed.<lang>.xml ergänzen.
+	if err := buildOutputs(paths, site, entries); err != nil {                     // Zuerst feed.xml + abgeleitete Feeds (RSS + JSON Feed)…
+		return err // …scheitert das, bleiben entries.json und state.json beim alten Stand.
+	} // Ende buildFeed error-check.
+	saveEntries(paths.entries, entries)                     // Dann entries.json…
+	state := loadState(paths.state)                         // …und zuletzt state.json (andere Felder bleiben erhalten).
+	state.Languages = languages                             // Stand der Übersetzungen pro Sprache.
+	recordPruned(paths, &state, pruned)                     // Entfernte IDs merken (+ Archiv).
+	state.LastBuild = time.Now().UTC().Format(time.RFC3339) // Build-Zeitpunkt merken (Basis für Embargo-Erkennung).
+	saveState(paths.state, state)                           // Persistiert state.json.
+	if err := publishArtifacts(paths); err != nil {         // Optional: Artefakte zum konfigurierten Hosting-Ziel hochladen.
+		return err // Upload-Fehler nach außen geben.
+	} // Ende publish.
+	pingServices(site)          // Aggregatoren/Crawler über das Update informieren (best-effort).
+	notifyEntries(paths, fresh) // Neue Entries an Mastodon & Co. verteilen (best-effort, ohne Doppelposts).
+	return nil                  // Erfolg.
+} // Ende finishUpdate.
+
 type feedProvider struct { // Abstraktion einer Quelle: Name + Fetch-Funktion.
-	Name  string                                                                  // Name wird u.a. in ID-Hash einbezogen (stabil pro Quelle).
-	Fetch func(fetch func(url, source string) ([]byte, error)) (feed.Item, error) // Fetcher nimmt eine fetch-Funktion (Dependency Injection) und liefert ein feed.Item.
+	Name     string                                                                                     // Name wird u.a. in ID-Hash einbezogen (stabil pro Quelle).
+	Fetch    func(fetch func(url, source string) ([]byte, error)) (feed.Item, error)                    // Fetcher nimmt eine fetch-Funktion (Dependency Injection) und liefert ein feed.Item.
+	FetchAll func(fetch func(url, source string) ([]byte, error)) ([]feed.Item, error)                  // Alternative: liefert alle Items (z.B. Mirror-Modus).
+	FetchNew func(fetch func(url, source string) ([]byte, error), since time.Time) ([]feed.Item, error) // Alternative: alle Items seit der Watermark (state.json); ohne Watermark nur das neueste.
+	Mirror   bool                                                                                       // Mirror: upstream entfernte Items werden auch lokal entfernt.
+	Settings ProviderSettings                                                                           // Einstellungen aus data/providers.json.
+	Headers  map[string]string                                                                          // Zusätzliche HTTP-Header für alle Requests dieser Quelle (z.B. Authorization).
+	Dedupe   bool                                                                                       // Items verwerfen, die einen vorhandenen Entry nur wiederholen (gleicher Titel/Link).
+	ctx      context.Context                                                                            // Laufzeit-Kontext des Abrufs (Timeout pro Provider, Abbruch des Laufs); nil = ohne Frist.
+	since    time.Time                                                                                  // Watermark für FetchNew: neuestes bisher gesehenes Item (leer = erster Lauf).
+	elapsed  time.Duration                                                                              // Dauer des Abrufs (prefetch), für den Report.
+
+	updatePeriod time.Duration // sy:updatePeriod des abgerufenen Feeds (prefetch); bestimmt next_fetch_at, wenn kein interval gesetzt ist.
 } // Ende struct feedProvider.
 
-func providers() []feedProvider { // Liefert die Liste der Quellen, die abgefragt werden sollen.
-	return []feedProvider{ // Slice-Literal: Reihenfolge ist die Abfrage-Reihenfolge.
-		{Name: "wordpress-releases", Fetch: feed.LatestReleases},    // Quelle 1: WordPress Releases.
-// 		{Name: "wordpress-tv", Fetch: feed.LatestWordPressTV},       // Quelle 2: WordPress TV.
-// 		{Name: "wordpress-com", Fetch: feed.LatestWordPressComBlog}, // Quelle 3: WordPress.com Blog.
+func providers(settings map[string]ProviderSettings) []feedProvider { // Liefert die Liste der Quellen, die abgefragt werden sollen.
+	list := []feedProvider{ // Slice-Literal: Reihenfolge ist die Abfrage-Reihenfolge.
+		{Name: "wordpress-releases", FetchNew: feed.LatestReleases},    // Quelle 1: WordPress Releases.
+		{Name: "wapuugotchi-plugin", Fetch: feed.LatestPluginVersion(feed.DefaultPlugin)}, // Neue Versionen des WapuuGotchi-Plugins (Changelog).
+// 		{Name: "wordpress-tv", FetchNew: feed.LatestWordPressTV},       // Quelle 2: WordPress TV.
+// 		{Name: "wordpress-com", FetchNew: feed.LatestWordPressComBlog}, // Quelle 3: WordPress.com Blog.
 	} // Ende Slice.
+	list = append(list, optionalProviders(settings)...)   // Optionale eingebaute Quellen ("enabled": true in providers.json).
+	list = append(list, configuredProviders(settings)...) // Zusätzliche Quellen aus providers.json (z.B. WordPress REST API).
+	if mirror, ok := mirrorProvider(); ok {               // Optional: Mirror-Quelle (FEED_MIRROR_URL).
+		list = append(list, mirror)
+	} // Ende mirror.
+	return activeProviders(list, settings) // Alle aktiven Quellen ("disabled": true und Dubletten fallen raus).
 } // Ende providers.
 
-func getPaths() (Paths, error) { // Ermittelt, wo Dateien liegen sollen (relativ zum Working Directory).
+func getPaths() (Paths, error) { // Ermittelt, wo Dateien liegen sollen (relativ zum Working Directory, überschreibbar per Flag/ENV).
 	root, err := os.Getwd() // Holt das aktuelle Arbeitsverzeichnis.
 	if err != nil {         // Falls das nicht geht (selten, aber möglich)…
 		return Paths{}, err // …leere paths + Fehler zurück.
 	} // Ende error-check.
-	dataDir := filepath.Join(root, "data") // Baut data/ Pfad OS-sicher zusammen.
-	return Paths{                          // Gibt alle Pfade zurück.
-		site:    filepath.Join(dataDir, "site.json"),    // data/site.json
-		entries: filepath.Join(dataDir, "entries.json"), // data/entries.json
-		feed:    filepath.Join(root, "feed.xml"),        // feed.xml im Projektroot.
+	dataDir := dataDirectory(root)             // data/ (oder -data-dir / FEED_DATA_DIR).
+	root, feedPath := outputPaths(root)        // Ausgabe: Projektroot (oder -output / FEED_OUTPUT).
+	if err := openStore(dataDir); err != nil { // Speicher-Backend für data/ (FEED_STORE, Default: Dateisystem).
+		return Paths{}, err
+	} // Ende store.
+	return Paths{ // Gibt alle Pfade zurück.
+		root:         root,                                              // Projektroot bzw. Ausgabeverzeichnis (Basis für veröffentlichte Artefakte).
+		site:         filepath.Join(dataDir, "site.json"),               // data/site.json
+		entries:      filepath.Join(dataDir, "entries.json"),            // data/entries.json
+		feeds:        filepath.Join(dataDir, "feeds.json"),              // data/feeds.json
+		pending:      filepath.Join(dataDir, "pending.json"),            // data/pending.json
+		state:        filepath.Join(dataDir, "state.json"),              // data/state.json
+		rules:        filepath.Join(dataDir, "rules.json"),              // data/rules.json
+		settings:     filepath.Join(dataDir, "providers.json"),          // data/providers.json
+		checkpoint:   filepath.Join(dataDir, "checkpoint.json"),         // data/checkpoint.json
+		archive:      filepath.Join(dataDir, "archive", "entries.json"), // data/archive/entries.json
+		translations: filepath.Join(dataDir, "translations.json"),       // data/translations.json
+		categories:   filepath.Join(dataDir, "categories.json"),         // data/categories.json
+		prompts:      filepath.Join(dataDir, "prompts"),                 // data/prompts/
+		enrich:       filepath.Join(dataDir, "enrich.json"),             // data/enrich.json
+		feed:         feedPath,                                          // feed.xml im Projektroot (bzw. Ausgabeverzeichnis).
 	}, nil // Kein Fehler.
 } // Ende getPaths.
 
 func loadSite(path string) Site { // Lädt Site-Infos mit sinnvollem Default.
 	site := Site{Title: "Wapuugotchi RSS"} // Default-Wert; wichtig falls site.json fehlt/leer ist.
-	if fillSiteFromEnv(&site) {
-		return site
+	if !fillSiteFromEnv(&site) {
+		readJSON(path, &site) // Versucht zu überschreiben; bei Fehlern macht readJSON einfach nichts.
+	}
+	if language := env.ReadEnv("FEED_LANGUAGE"); language != "" { // ENV hat Vorrang vor site.json.
+		site.Language = language
+	}
+	if languages := env.ReadEnv("FEED_LANGUAGES"); languages != "" { // Kommagetrennt, z.B. "de,fr".
+		site.Languages = strings.Split(languages, ",")
 	}
-	readJSON(path, &site) // Versucht zu überschreiben; bei Fehlern macht readJSON einfach nichts.
-	return site           // Gibt Site zurück (Default oder geladen).
+	return site // Gibt Site zurück (Default oder geladen).
 } // Ende loadSite.
 
-func loadEntries(path string) []Entry { // Lädt gespeicherte Entries.
-	entries := []Entry{}     // Default: leerer Slice (kein nil).
-	readJSON(path, &entries) // Bei fehlender Datei bleibt es leer.
-	return entries           // Return.
+func loadEntries(path string) []Entry { // Lädt gespeicherte Entries (entries.json + entries-JJJJ.json).
+	return loadYearEntries(path) // Jahresdateien werden transparent zusammengeführt.
 } // Ende loadEntries.
 
-func saveEntries(path string, entries []Entry) { // Speichert Entries nach JSON.
-	writeJSON(path, entries) // Zentralisierte JSON-Ausgabe + Fehlerbehandlung (exit).
+func saveEntries(path string, entries []Entry) { // Speichert Entries nach JSON, aufgeteilt nach Jahr.
+	saveYearEntries(path, entries) // Kleine Dateien pro Jahr bleiben in Pull Requests reviewbar.
 } // Ende saveEntries.
 
 func fillSiteFromEnv(site *Site) bool { // Lädt alle env variablen
@@ -162,86 +432,155 @@ func fillSiteFromEnv(site *Site) bool { // Lädt alle env variablen
 
 } // Ende fillSiteFromEnv.
 
-func addLatest(provider feedProvider, entries *[]Entry) (bool, error) { // Holt neuesten Item eines Providers und fügt ihn ggf. hinzu.
-	item, err := provider.Fetch(fetchFeed) // Provider-Fetcher aufrufen; bekommt fetchFeed als HTTP-Funktion.
-	if err != nil {                        // Wenn Fetch scheitert…
+func addLatest(provider feedProvider, entries *[]Entry, known ...[]Entry) (bool, error) { // Holt neuesten Item eines Providers und fügt ihn ggf. hinzu.
+	item, err := provider.Fetch(provider.fetch) // Provider-Fetcher aufrufen; bekommt fetchFeed (+ Provider-Header) als HTTP-Funktion.
+	if err != nil {                             // Wenn Fetch scheitert…
 		return false, err // …nichts hinzugefügt + Fehler.
 	} // Ende error-check.
+	return addItem(provider, item, entries, known...), nil // Einzelnes Item prüfen + ggf. übernehmen.
+} // Ende addLatest.
+
+func addItem(provider feedProvider, item feed.Item, entries *[]Entry, known ...[]Entry) bool { // Übernimmt ein Item als Entry, falls es neu ist (true = hinzugefügt).
 	if strings.TrimSpace(item.Title) == "" { // Wenn Item ohne Titel kommt…
-		return false, nil // …ignorieren: vermutlich ungültig/leer.
+		return false // …ignorieren: vermutlich ungültig/leer.
 	} // Ende title-check.
 
-	item.Categories = cleanCategories(item.Categories) // Kategorien trimmen + leere entfernen.
-	id := pickEntryID(provider.Name, item)             // Stabile ID aus Provider + PubDate/Link generieren.
-	if idExists(*entries, id) {                        // Prüfen, ob diese ID schon vorhanden ist.
-		return false, nil // Wenn ja: kein Update.
+	item.Title = cleanTitle(item.Title, provider.Settings.TitleSuffixes) // Entities, Whitespace, NFC, Site-Suffixe.
+	item.Categories = cleanCategories(item.Categories)                   // Kategorien trimmen + leere entfernen.
+	id := pickEntryID(provider.Name, item)                               // Stabile ID aus Provider + PubDate/Link generieren.
+	if idExists(*entries, id) || idKnown(known, id) {                    // Prüfen, ob diese ID schon vorhanden ist (auch Feed/Queue/abgelehnt).
+		return false // Wenn ja: kein Update.
 	} // Ende exists-check.
+	if republished(provider.Name, item, append([][]Entry{*entries}, known...)) { // Gleicher Beitrag (normalisierter Link), nur mit neuer ID…
+		return false // …nicht doppelt aufnehmen.
+	} // Ende link-check.
+	if provider.Dedupe && mirrorsEntry(item, append([][]Entry{*entries}, known...)) { // Quelle wiederholt nur eine bekannte Ankündigung…
+		return false // …dann nicht doppelt aufnehmen.
+	} // Ende dedupe-check.
+
+	if provider.FetchAll != nil { // FetchAll-Quellen (Mirror & Co.)…
+		item = mirrorItem(provider, item) // …optional per FEED_MIRROR_PROMPT umschreiben (KI nur für wirklich neue Items).
+	} // Ende mirror-check.
+	item = enrichItem(provider, item)               // Optional: dünne Items aus Open Graph der Zielseite ergänzen.
+	item = translateItem(provider, item)            // Optional übersetzen (erst hier: nur neue Items kosten KI).
+	entry := newEntry(provider, item, id)           // Entry bauen (bereinigt, ggf. gekürzt).
+	entry.Summary = summarizeEntry(provider, entry) // Optional: KI-Zusammenfassung für <description>.
+	*entries = append(*entries, entry)              // Neuen Entry an den Slice anhängen (über Pointer mutieren).
+	return true                                     // Es wurde etwas hinzugefügt.
+} // Ende addItem.
+
+func newEntry(provider feedProvider, item feed.Item, id string) Entry { // Mappt ein feed.Item auf einen persistierbaren Entry.
+	content := sanitizeHTML(item.Content, allowedExtraTags(provider))                                          // Allowlist: keine Skripte, Event-Handler, Tracking-Pixel.
+	content = resolveURLs(content, item.Link)                                                                  // Relative href/src gegen den Link des Items auflösen (sonst brechen Bilder im Reader).
+	content = truncateHTML(content, provider.Settings.MaxContentLength, item.Link, provider.Settings.ReadMore) // Optional kürzen (max_content_length).
+	return Entry{
+		ID:             id,                                 // Setzt ID.
+		Title:          item.Title,                         // Titel übernehmen.
+		Link:           item.Link,                          // Link übernehmen.
+		Content:        attribute(provider, item, content), // Content übernehmen (ggf. mit Quellenzeile).
+		CreatedAt:      pickEntryTime(item),                // Zeitpunkt normalisieren/parsen; fallback: now.
+		Categories:     item.Categories,                    // Kategorien übernehmen (bereinigt).
+		Provider:       provider.Name,                      // Quelle merken (Notifier, Filter).
+		PublishAt:      pickPublishAt(item),                // Embargo der Quelle übernehmen (normalisiert), falls vorhanden.
+		Thumbnail:      item.Thumbnail,                     // Vorschaubild übernehmen (falls der Provider eins kennt).
+		Image:          entryImage(item, content),          // Beitragsbild der Quelle, sonst das erste Bild im Inhalt.
+		Duration:       item.Duration,                      // Laufzeit übernehmen (falls bekannt).
+		Transcript:     item.Transcript,                    // Transkript-Link übernehmen (falls vorhanden).
+		Type:           item.Type,                          // Typ übernehmen (z.B. "event").
+		StartsAt:       item.StartsAt,                      // Event-Start übernehmen.
+		Language:       item.Language,                      // Sprache übernehmen (leer = Sprache der Site).
+		SourceLanguage: item.SourceLanguage,                // Originalsprache, falls übersetzt.
+		Author:         item.Author,                        // Autor:in übernehmen (falls bekannt).
+	} // Ende Entry.
+} // Ende newEntry.
+
+func fetchFeed(url, source string) ([]byte, error) { // HTTP Fetch helper mit Retry auf 429/5xx.
+	policy := defaultFetchPolicy                                                   // Standard-Retries…
+	policy.maxBody = maxBodySize()                                                 // …mit dem Größenlimit aus FEED_MAX_BODY_SIZE.
+	return fetchWithHeaders(context.Background(), url, source, nil, false, policy) // Ohne zusätzliche Header, mit Zertifikatsprüfung, ohne Frist.
+} // Ende fetchFeed.
 
-	*entries = append(*entries, Entry{ // Neuen Entry an den Slice anhängen (über Pointer mutieren).
-		ID:         id,                  // Setzt ID.
-		Title:      item.Title,          // Titel übernehmen.
-		Link:       item.Link,           // Link übernehmen.
-		Content:    item.Content,        // Content übernehmen.
-		CreatedAt:  pickEntryTime(item), // Zeitpunkt normalisieren/parsen; fallback: now.
-		Categories: item.Categories,     // Kategorien übernehmen (bereinigt).
-	}) // Ende append.
-	return true, nil // Es wurde etwas hinzugefügt.
-} // Ende addLatest.
-
-func fetchFeed(url, source string) ([]byte, error) { // HTTP Fetch helper mit Retry auf 429.
-	client := &http.Client{Timeout: 15 * time.Second} // Client mit Timeout; schützt vor Hängern.
+func (provider feedProvider) fetch(url, source string) ([]byte, error) { // fetchFeed mit den Headern des Providers (z.B. API-Token).
+	ctx := provider.ctx // Timeout aus prefetch (falls gesetzt).
+	if ctx == nil {
+		ctx = context.Background()
+	} // Ende ctx-default.
+	return fetchWithHeaders(ctx, url, source, provider.requestHeaders(), provider.Settings.InsecureSkipVerify, provider.fetchPolicy()) // Header, Timeout und Retries pro Provider.
+} // Ende fetch.
+
+func fetchWithHeaders(ctx context.Context, url, source string, headers map[string]string, insecure bool, policy fetchPolicy) ([]byte, error) { // fetchFeed mit zusätzlichen/überschriebenen Headern und eigener Retry-Strategie.
+	shared, err := httpClient(insecure) // Client mit TLS-Einstellungen.
+	if err != nil {                     // Ungültige TLS-Konfiguration…
+		return nil, err // …betrifft jeden Request: direkt melden.
+	} // Ende error-check.
+	client := *shared               // Kopie: Transport wird geteilt, der Timeout gilt nur für diesen Provider.
+	client.Timeout = policy.timeout // Frist pro Request.
 
-	var body []byte                            // Hier landet der Response-Body.
-	for attempt := 0; attempt < 2; attempt++ { // Max 2 Versuche: 1 normal + 1 Retry bei 429.
-		req, err := http.NewRequest(http.MethodGet, url, nil) // Request bauen.
-		if err != nil {                                       // Wenn URL kaputt o.ä.
+	var body []byte                                          // Hier landet der Response-Body.
+	for attempt := 0; attempt < policy.attempts; attempt++ { // Versuche laut Policy (Default: 1 normal + 1 Retry).
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil) // Request bauen (bricht mit dem Provider-Timeout ab).
+		if err != nil {                                                       // Wenn URL kaputt o.ä.
 			return nil, err // Direkt zurück.
 		} // Ende error-check.
-		req.Header.Set("User-Agent", userAgent) // Setzt User-Agent.
-		req.Header.Set("Accept", acceptHeader)  // Setzt Accept Header.
+		req.Header.Set("User-Agent", userAgent())         // Setzt User-Agent (Provider können ihn über headers überschreiben).
+		req.Header.Set("Accept", acceptHeader)            // Setzt Accept Header.
+		req.Header.Set("Accept-Encoding", acceptEncoding) // Komprimierung selbst entpacken (mit Größenlimit, siehe body.go).
+		for key, value := range headers {                 // Provider-spezifische Header (Auth, API-Version) überschreiben Defaults.
+			req.Header.Set(key, value) // Header setzen.
+		} // Ende headers.
 
 		resp, err := client.Do(req) // Request ausführen.
 		if err != nil {             // Netzwerkfehler, DNS, Timeout, etc.
 			return nil, err // Zurückgeben.
 		} // Ende error-check.
 
-		if resp.StatusCode == http.StatusTooManyRequests && attempt == 0 { // Wenn 429 und wir sind beim ersten Versuch…
+		if retryableStatus(resp.StatusCode) && attempt+1 < policy.attempts { // Wenn 429/5xx und noch Versuche übrig…
 			_, _ = io.Copy(io.Discard, resp.Body) // Body leeren, damit Keep-Alive sauber ist (best practice).
 			resp.Body.Close()                     // Body schließen (wichtig: Ressourcen frei).
-			time.Sleep(2 * time.Second)           // Kurzer Backoff bevor Retry.
-			continue                              // Nächster Versuch.
-		} // Ende 429-Handling.
+			select {                              // Backoff (bzw. Retry-After) bevor Retry…
+			case <-time.After(policy.wait(attempt, resp)):
+			case <-ctx.Done(): // …außer die Frist ist schon abgelaufen.
+				return nil, ctx.Err()
+			} // Ende backoff.
+			continue // Nächster Versuch.
+		} // Ende retry-Handling.
 
 		if resp.StatusCode < 200 || resp.StatusCode >= 300 { // Alles außerhalb 2xx als Fehler behandeln.
 			resp.Body.Close()                                                // Body schließen, sonst Leak.
 			return nil, fmt.Errorf("%s api status: %s", source, resp.Status) // Fehler mit Quelle + Status.
 		} // Ende status-check.
 
-		body, err = io.ReadAll(resp.Body) // Body vollständig lesen.
-		resp.Body.Close()                 // Immer schließen, auch bei Erfolg.
-		if err != nil {                   // Falls ReadAll fehlschlägt…
-			return nil, err // …Fehler zurück.
+		body, err = readBody(resp, policy.maxBody) // Body entpacken und lesen (höchstens maxBody Bytes).
+		resp.Body.Close()                          // Immer schließen, auch bei Erfolg.
+		if err != nil {                            // Falls Lesen/Entpacken fehlschlägt oder der Body zu groß ist…
+			return nil, fmt.Errorf("%s: %w", source, err) // …Fehler mit Quelle zurück.
 		} // Ende read error-check.
 		break // Erfolgreich gelesen: Retry-Schleife verlassen.
 	} // Ende retry-loop.
 
 	return body, nil // Gibt Response-Bytes zurück.
-} // Ende fetchFeed.
+} // Ende fetchWithHeaders.
 
-func cleanCategories(values []string) []string { // Entfernt Whitespace + leere Kategorien.
+func cleanCategories(values []string) []string { // Normalisiert Kategorien (Kleinbuchstaben, categories.json) und entfernt leere/doppelte.
 	result := make([]string, 0, len(values)) // Prealloc: spart Reallocs, max so groß wie input.
+	seen := map[string]bool{}                // Schon übernommene Kategorien (nach Normalisierung).
 	for _, value := range values {           // Über alle Kategorien iterieren.
-		value = strings.TrimSpace(value) // Whitespace entfernen.
-		if value == "" {                 // Leere Einträge rausfiltern.
+		value = categoryMapping.normalize(value) // Whitespace, Kleinschreibung, Mapping, Drop-Liste.
+		if value == "" || seen[value] {          // Leere/verworfene Einträge und Dubletten rausfiltern.
 			continue // Skip.
 		} // Ende empty-check.
+		seen[value] = true             // Merken für die Dubletten-Prüfung.
 		result = append(result, value) // Saubere Kategorie hinzufügen.
 	} // Ende loop.
 	return result // Ergebnis zurück.
 } // Ende cleanCategories.
 
 func buildFeed(site Site, entries []Entry, outputPath string) error { // Baut feed.xml aus Site + Entries.
-	sort.Slice(entries, func(i, j int) bool { // Sortiert Entries absteigend nach CreatedAt-String.
+	now := time.Now().UTC()                   // Referenzzeit für zeitlich begrenztes Anpinnen.
+	sort.Slice(entries, func(i, j int) bool { // Sortiert Entries: angepinnte zuerst, dann absteigend nach CreatedAt-String.
+		if isPinned(entries[i], now) != isPinned(entries[j], now) { // Pinned schlägt Datum.
+			return isPinned(entries[i], now) // Angepinnter Entry nach vorne.
+		} // Ende pinned-check.
 		return entries[i].CreatedAt > entries[j].CreatedAt // Stringvergleich funktioniert bei RFC3339 (lexikographisch = chronologisch).
 	}) // Ende sort.
 
@@ -249,27 +588,46 @@ func buildFeed(site Site, entries []Entry, outputPath string) error { // Baut fe
 		Title:       site.Title,       // Feed Titel.
 		Link:        site.Link,        // Feed Link.
 		Description: site.Description, // Feed Beschreibung.
+		Language:    site.Language,    // Feed Sprache.
 	} // Ende channel init.
+	if site.Icon != "" { // Icon als Channel-Bild (nur mit Link gültig).
+		channel.Image = &Image{URL: site.Icon, Title: site.Title, Link: site.Link}
+	} // Ende image.
 
-	if len(entries) > 0 { // Wenn mindestens ein Entry existiert…
-		last, err := parseTime(entries[0].CreatedAt) // Nimmt den neuesten (nach Sort) und parsed RFC3339.
-		if err == nil {                              // Wenn parse klappt…
+	if newest := newestCreatedAt(entries); newest != "" { // Wenn mindestens ein Entry existiert (angepinnte stehen evtl. vor dem neuesten)…
+		last, err := parseTime(newest) // Nimmt den neuesten und parsed RFC3339.
+		if err == nil {                // Wenn parse klappt…
 			channel.LastBuildDate = last.UTC().Format(time.RFC1123Z) // lastBuildDate in RSS-übliches Format.
 		} // Ende parse success.
 	} // Ende entries-check.
 
+	params := linkParams()          // Optionale Analytics-Parameter (FEED_LINK_PARAMS) für ausgehende Links.
 	for _, entry := range entries { // Alle Entries in RSS-Items umwandeln.
 		createdAt, err := parseTime(entry.CreatedAt) // CreatedAt parsen.
 		if err != nil {                              // Wenn kaputt…
 			continue // Entry überspringen (besser als kompletten Feed kaputt machen).
 		} // Ende parse error.
+		language := entryLanguage(site, entry)      // Sprache des Inhalts (eigene oder die der Site).
+		words := wordCount(entry.Content)           // Für Wortanzahl + Lesezeit.
 		channel.Items = append(channel.Items, Item{ // Item hinzufügen.
+			Lang:        language,                              // xml:lang.
+			DCLanguage:  language,                              // dc:language (für Reader, die xml:lang ignorieren).
+			DCSource:    entry.Link,                            // Original-URL.
 			Title:       entry.Title,                           // Titel.
-			Link:        entry.Link,                            // Link.
-			ID:          entry.ID,                              // ID (bei dir <id>).
+			Link:        decorateLink(entry.Link, params),      // Link (ggf. mit Analytics-Parametern).
+			GUID:        itemGUID(entry),                       // guid (Entry-ID bzw. Permalink).
 			PubDate:     createdAt.UTC().Format(time.RFC1123Z), // pubDate in RFC1123Z.
-			Description: entry.Content,                         // description = content.
+			Description: CDATA(itemDescription(entry)),         // description = Zusammenfassung, sonst content (als CDATA; bestehende Leser erwarten den Inhalt hier).
+			Content:     CDATA(entry.Content),                  // content:encoded = voller HTML-Inhalt.
+			DCCreator:   entry.Author,                          // Autor:in (optional).
 			Categories:  entry.Categories,                      // Kategorien.
+			Thumbnail:   mediaThumbnail(entry.Thumbnail),       // Vorschaubild (optional).
+			Enclosure:   imageEnclosure(entry.Image),           // Beitragsbild als Anhang (optional).
+			Image:       mediaImage(entry.Image),               // Beitragsbild als media:content (optional).
+			Duration:    formatDuration(entry.Duration),        // Laufzeit (optional).
+			Transcript:  transcriptLink(entry.Transcript),      // Transkript (optional).
+			WordCount:   words,                                 // Wortanzahl (0 = Element entfällt).
+			ReadingTime: readingTime(words),                    // Lesezeit in Minuten.
 		}) // Ende append.
 	} // Ende loop.
 
@@ -277,21 +635,74 @@ func buildFeed(site Site, entries []Entry, outputPath string) error { // Baut fe
 		Version: "2.0",   // RSS Version setzen.
 		Channel: channel, // Channel einhängen.
 	} // Ende rss init.
+	for _, item := range channel.Items { // Namespaces nur deklarieren, wenn sie auch benutzt werden.
+		if item.Thumbnail != nil || item.Image != nil { // Mindestens ein Vorschau- oder Beitragsbild…
+			rss.MediaNS = "http://search.yahoo.com/mrss/" // …dann xmlns:media setzen.
+		} // Ende thumbnail-check.
+		if item.Duration != "" { // Mindestens eine Laufzeit…
+			rss.ITunesNS = "http://www.itunes.com/dtds/podcast-1.0.dtd" // …dann xmlns:itunes setzen.
+		} // Ende duration-check.
+		if item.DCLanguage != "" || item.DCSource != "" || item.DCCreator != "" { // Mindestens ein Dublin-Core-Feld…
+			rss.DCNS = "http://purl.org/dc/elements/1.1/" // …dann xmlns:dc setzen.
+		} // Ende dc-check.
+		if item.Content != "" { // Mindestens ein Vollinhalt…
+			rss.ContentNS = "http://purl.org/rss/1.0/modules/content/" // …dann xmlns:content setzen.
+		} // Ende content-check.
+		if item.WordCount > 0 { // Mindestens ein Text mit Wörtern…
+			rss.WapuuNS = wapuugotchiNS // …dann xmlns:wapuugotchi setzen.
+		} // Ende wordcount-check.
+		if item.Transcript != nil { // Mindestens ein Transkript…
+			rss.PodcastNS = "https://podcastindex.org/namespace/1.0" // …dann xmlns:podcast setzen.
+		} // Ende transcript-check.
+	} // Ende namespace-loop.
+
+	var data bytes.Buffer                   // Erst komplett im Speicher bauen…
+	data.WriteString(xml.Header)            // XML Header (<?xml version="1.0"...>).
+	enc := xml.NewEncoder(&data)            // XML-Encoder in den Puffer.
+	enc.Indent("", outputIndent())          // Pretty Print (oder minifiziert mit FEED_MINIFY).
+	if err := enc.Encode(rss); err != nil { // RSS struct als XML kodieren.
+		return err // Fehler zurück; feed.xml bleibt unverändert.
+	} // Ende encode error-check.
+	return writeFileAtomic(outputPath, data.Bytes(), 0o644) // …dann atomar ersetzen: nie ein halbes feed.xml.
+} // Ende buildFeed.
 
-	file, err := os.Create(outputPath) // Zieldatei erstellen/überschreiben.
-	if err != nil {                    // Wenn das nicht geht (Permission, Pfad)…
-		return err // …Fehler zurück.
-	} // Ende error-check.
-	defer file.Close() // Sicherstellen, dass Datei am Ende geschlossen wird.
-
-	if _, err := file.WriteString(xml.Header); err != nil { // XML Header schreiben (<?xml version="1.0"...>).
-		return err // Fehler zurück.
-	} // Ende header write.
+func mediaThumbnail(url string) *MediaThumbnail { // nil bei leerer URL (Element entfällt dann).
+	if url == "" { // Kein Vorschaubild…
+		return nil // …kein Element.
+	} // Ende empty-check.
+	return &MediaThumbnail{URL: url} // Element mit URL.
+} // Ende mediaThumbnail.
 
-	enc := xml.NewEncoder(file) // XML-Encoder, der direkt in die Datei schreibt.
-	enc.Indent("", "  ")        // Pretty Print: Einrückung für Lesbarkeit.
-	return enc.Encode(rss)      // RSS struct als XML schreiben; gibt ggf. error zurück.
-} // Ende buildFeed.
+func transcriptLink(url string) *Transcript { // nil bei leerer URL; Type anhand der Endung.
+	if url == "" { // Kein Transkript…
+		return nil // …kein Element.
+	} // Ende empty-check.
+	kind := "text/vtt"                                                              // WebVTT ist der Normalfall.
+	if strings.HasSuffix(strings.ToLower(strings.SplitN(url, "?", 2)[0]), ".srt") { // SRT erkennen (Query ignorieren).
+		kind = "application/x-subrip" // SubRip.
+	} // Ende type-check.
+	return &Transcript{URL: url, Type: kind} // Element mit URL + Type.
+} // Ende transcriptLink.
+
+func formatDuration(seconds int) string { // 183 → "3:03", 3723 → "1:02:03"; 0 → "" (Element entfällt).
+	if seconds <= 0 { // Unbekannte Laufzeit…
+		return "" // …kein Element.
+	} // Ende empty-check.
+	if seconds >= 3600 { // Ab einer Stunde mit Stundenanteil.
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60) // H:MM:SS.
+	} // Ende hour-check.
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60) // M:SS.
+} // Ende formatDuration.
+
+func newestCreatedAt(entries []Entry) string { // Neuester CreatedAt-Wert (unabhängig von der Sortierung).
+	newest := ""                    // Leer, wenn es keine Entries gibt.
+	for _, entry := range entries { // Linear über alle Entries.
+		if entry.CreatedAt > newest { // RFC3339-Stringvergleich.
+			newest = entry.CreatedAt // Neuer Höchstwert.
+		} // Ende compare.
+	} // Ende loop.
+	return newest // Ergebnis zurück.
+} // Ende newestCreatedAt.
 
 func parseTime(value string) (time.Time, error) { // Erwartet RFC3339 timestamps (CreatedAt).
 	return time.Parse(time.RFC3339, strings.TrimSpace(value)) // Trimmt und parsed.
@@ -316,15 +727,38 @@ func pickEntryTime(item feed.Item) string { // Ermittelt CreatedAt aus PubDate,
 	return parsed.UTC().Format(time.RFC3339) // Normalisiert als RFC3339 String (UTC).
 } // Ende pickEntryTime.
 
+func pickPublishAt(item feed.Item) string { // Normalisiert ein provider-seitiges Embargo auf RFC3339 (leer = keins).
+	value := strings.TrimSpace(item.PublishAt) // Whitespace entfernen.
+	if value == "" {                           // Kein Embargo…
+		return "" // …sofort sichtbar.
+	} // Ende empty-check.
+	if parsed, err := parseTime(value); err == nil { // RFC3339 direkt akzeptieren.
+		return parsed.UTC().Format(time.RFC3339) // Normalisiert in UTC.
+	} // Ende RFC3339.
+	if parsed, err := parsePubDate(value); err == nil { // RSS-Datumsformate ebenfalls akzeptieren.
+		return parsed.UTC().Format(time.RFC3339) // Normalisiert in UTC.
+	} // Ende RFC1123.
+	return value // Unlesbar: unverändert speichern; isVisible hält den Entry dann zurück.
+} // Ende pickPublishAt.
+
 func idExists(entries []Entry, id string) bool { // Prüft, ob ID schon in entries.json existiert.
 	for _, entry := range entries { // Iteriert linear über alle Entries.
-		if entry.ID == id { // Match?
+		if entry.ID == id || containsFold(entry.Aliases, id) { // Match (auch IDs, die in diesen Entry gemergt wurden)?
 			return true // Existiert schon.
 		} // Ende match-check.
 	} // Ende loop.
 	return false // Nicht gefunden.
 } // Ende idExists.
 
+func idKnown(lists [][]Entry, id string) bool { // Prüft mehrere Entry-Listen (Feed, Queue, abgelehnte IDs).
+	for _, entries := range lists { // Jede Liste einzeln prüfen.
+		if idExists(entries, id) { // Match?
+			return true // Bereits bekannt.
+		} // Ende match-check.
+	} // Ende loop.
+	return false // Nirgends gefunden.
+} // Ende idKnown.
+
 func parsePubDate(value string) (time.Time, error) { // Parst PubDate aus RSS/HTTP-Feeds.
 	value = strings.TrimSpace(value) // Whitespace entfernen.
 	if value == "" {                 // Wenn leer…
@@ -346,26 +780,24 @@ func hashString(value string) string { // Macht aus beliebigem Text einen stabil
 } // Ende hashString.
 
 func readJSON(path string, target any) { // Liest JSON-Datei in target, aber "silent fail".
-	data, err := os.ReadFile(path) // Datei lesen.
-	if err != nil {                // Wenn Datei fehlt/kein Zugriff…
+	data, err := readData(path) // Datei lesen (über das Speicher-Backend, falls unter data/).
+	if err != nil {             // Wenn Datei fehlt/kein Zugriff…
 		return // …einfach nichts tun (Defaults bleiben).
 	} // Ende error-check.
 	_ = json.Unmarshal(data, target) // JSON parsen; Fehler wird ignoriert (bewusst: robust, aber still).
 } // Ende readJSON.
 
-func writeJSON(path string, value any) { // Schreibt JSON-Datei, bei Fehlern hartes Exit.
-	file, err := os.Create(path) // Datei erstellen/überschreiben.
-	if err != nil {              // Wenn das fehlschlägt…
-		fmt.Fprintln(os.Stderr, err) // …Fehler ausgeben.
-		os.Exit(1)                   // …Programm beenden (weil Persistenz kritisch ist).
-	} // Ende error-check.
-	defer file.Close() // Datei sicher schließen.
-
-	enc := json.NewEncoder(file)              // JSON Encoder auf Datei.
+func writeJSON(path string, value any) { // Schreibt JSON-Datei atomar, bei Fehlern hartes Exit.
+	var data bytes.Buffer                     // Erst im Speicher kodieren, dann in einem Schritt ersetzen.
+	enc := json.NewEncoder(&data)             // JSON Encoder in den Puffer.
 	enc.SetIndent("", "  ")                   // Pretty JSON für bessere Diffbarkeit/Lesbarkeit.
 	enc.SetEscapeHTML(false)                  // Verhindert z.B. "<" zu "\u003c" (hilfreich für Content/Links).
 	if err := enc.Encode(value); err != nil { // JSON schreiben.
 		fmt.Fprintln(os.Stderr, err) // Fehler ausgeben.
 		os.Exit(1)                   // Harte Beendigung (konsistenter State ist wichtig).
 	} // Ende encode error-check.
+	if err := writeData(path, data.Bytes()); err != nil { // Backend bzw. Temp-Datei + Rename: ein Abbruch hinterlässt nie eine halbe Datei.
+		fmt.Fprintln(os.Stderr, err) // …Fehler ausgeben.
+		os.Exit(1)                   // …Programm beenden (weil Persistenz kritisch ist).
+	} // Ende write error-check.
 } // Ende writeJSON.
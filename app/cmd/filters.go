@@ -0,0 +1,77 @@
+package cmd // Paket "cmd": Include/Exclude-Filter pro Provider ("filter" in providers.json bzw. sources.json).
+
+import ( // Import-Block: Standardbibliothek + internes Feed-Paket.
+	"fmt"          // Log-Ausgabe.
+	"os"           // Stderr für Konfigurationsfehler.
+	"regexp"       // Titel-Muster.
+	"strings"      // Kategorien trimmen.
+	"unicode/utf8" // Mindestlänge in Zeichen statt Bytes.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+type ItemFilter struct { // Nur Items, die alle gesetzten Bedingungen erfüllen, kommen in den Feed; Regex-Syntax wie Go (case-insensitive mit "(?i)").
+	Include    string   `json:"include,omitempty"`    // Regex: der Titel muss passen (z.B. "(?i)release").
+	Exclude    string   `json:"exclude,omitempty"`    // Regex: passende Titel werden verworfen (z.B. "(?i)beta|rc").
+	Categories []string `json:"categories,omitempty"` // Alle diese Kategorien müssen vorhanden sein (case-insensitive); "eine von" ist "keywords".
+	MinLength  int      `json:"min_length,omitempty"` // Mindestlänge des Textinhalts (ohne HTML) in Zeichen.
+}
+
+func applyItemFilter(provider feedProvider) feedProvider { // Hängt den Filter aus den Einstellungen vor die Fetcher; ungültige Muster werden gemeldet und der Filter ignoriert.
+	filter := provider.Settings.Filter
+	if filter == nil {
+		return provider
+	}
+	include, err := compileFilter(filter.Include)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "provider %s: filter include: %v\n", provider.Name, err)
+		return provider
+	}
+	exclude, err := compileFilter(filter.Exclude)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "provider %s: filter exclude: %v\n", provider.Name, err)
+		return provider
+	}
+	return withItemFilter(provider, func(item feed.Item) bool {
+		reason := ""
+		switch {
+		case include != nil && !include.MatchString(item.Title):
+			reason = "include"
+		case exclude != nil && exclude.MatchString(item.Title):
+			reason = "exclude"
+		case !hasCategories(item.Categories, filter.Categories):
+			reason = "categories"
+		case filter.MinLength > 0 && utf8.RuneCountInString(plainText(item.Content)) < filter.MinLength:
+			reason = "min_length"
+		default:
+			return false
+		}
+		if provider.FetchAll == nil { // Bei FetchAll-Quellen wäre das Log pro Lauf nur Rauschen.
+			fmt.Printf("skipped %s: %q (filter %s)\n", provider.Name, item.Title, reason)
+		}
+		return true
+	})
+}
+
+func compileFilter(pattern string) (*regexp.Regexp, error) { // nil ohne Muster.
+	if strings.TrimSpace(pattern) == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+func hasCategories(categories, required []string) bool { // Jede geforderte Kategorie ist vorhanden; beide Seiten laufen durch data/categories.json (wie später cleanCategories).
+	have := map[string]bool{}
+	for _, category := range categories {
+		have[categoryMapping.normalize(category)] = true
+	}
+	for _, category := range required {
+		if strings.TrimSpace(category) == "" {
+			continue
+		}
+		if key := categoryMapping.normalize(category); key == "" || !have[key] { // Gedroppte Kategorie kann nie vorhanden sein.
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package cmd // Tests für hasCategories: Filter-Kategorien werden wie die Entry-Kategorien abgebildet.
+
+import "testing"
+
+func TestHasCategories(t *testing.T) {
+	saved := categoryMapping
+	defer func() { categoryMapping = saved }()
+	categoryMapping = newCategoryMap(CategoryConfig{Map: map[string]string{"Releases": "release", "Veröffentlichungen": "release"}, Drop: []string{"Featured"}})
+
+	tests := []struct {
+		name       string
+		categories []string
+		required   []string
+		want       bool
+	}{
+		{"ohne Filter", []string{"News"}, nil, true},
+		{"Groß-/Kleinschreibung", []string{"News"}, []string{"news"}, true},
+		{"Upstream-Name gegen Ziel", []string{"Veröffentlichungen"}, []string{"release"}, true},
+		{"Upstream-Name gegen Upstream-Name", []string{"Veröffentlichungen"}, []string{"Releases"}, true},
+		{"Entities und Whitespace", []string{"News &amp;  Events"}, []string{"news & events"}, true},
+		{"fehlt", []string{"News"}, []string{"News", "Release"}, false},
+		{"gedroppt", []string{"Featured"}, []string{"Featured"}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := hasCategories(test.categories, test.required); got != test.want {
+				t.Errorf("hasCategories(%q, %q) = %v, want %v", test.categories, test.required, got, test.want)
+			}
+		})
+	}
+}
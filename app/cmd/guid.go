@@ -0,0 +1,18 @@
+package cmd // Paket "cmd": <guid> der RSS-Items – Entry-ID (isPermaLink="false", Default) oder der Link selbst (FEED_GUID_PERMALINK=true).
+
+import ( // Import-Block: Env-Helper.
+	"wapuugotchi/feed/app/env"
+)
+
+type GUID struct { // RSS <guid>; isPermaLink wird immer geschrieben (ohne Attribut gilt laut Spezifikation "true").
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+func itemGUID(entry Entry) GUID { // Stabile ID ist der Default: Links können sich ändern (Redirects, Tracking-Parameter), die ID nicht.
+	_ = env.LoadDotEnv()
+	if env.ReadEnv("FEED_GUID_PERMALINK") == "true" && absoluteURL(entry.Link) {
+		return GUID{IsPermaLink: "true", Value: entry.Link}
+	}
+	return GUID{IsPermaLink: "false", Value: entry.ID}
+}
@@ -0,0 +1,85 @@
+package cmd // Paket "cmd": Header-Sidecars (_headers / .htaccess) für statische Hoster.
+
+import ( // Import-Block: Standardbibliothek + Publish-Paket (Content-Types).
+	"crypto/md5"    // ETag aus dem Dateiinhalt.
+	"fmt"           // Zeilen formatieren.
+	"os"            // Dateien lesen/schreiben.
+	"path/filepath" // Pfade relativ zum Projektroot.
+	"strconv"       // max-age parsen.
+	"strings"       // Builder/Lowercase.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/publish"
+)
+
+const defaultCacheMaxAge = 300 // Sekunden: kurz genug, damit neue Items zügig ankommen.
+
+func headerSidecars() []string { // FEED_HEADERS: "netlify" (_headers), "htaccess" (.htaccess) oder beides kommagetrennt.
+	_ = env.LoadDotEnv()
+	return splitComma(strings.ToLower(env.ReadEnv("FEED_HEADERS")))
+}
+
+func sidecarName(kind string) string { // Dateiname je Sidecar-Typ ("" = unbekannt).
+	switch kind {
+	case "netlify", "cloudflare", "_headers": // Netlify und Cloudflare Pages lesen dieselbe _headers Syntax.
+		return "_headers"
+	case "htaccess", "apache", ".htaccess":
+		return ".htaccess"
+	}
+	return ""
+}
+
+func cacheMaxAge() int { // FEED_CACHE_MAX_AGE in Sekunden (Default 300).
+	if value, err := strconv.Atoi(env.ReadEnv("FEED_CACHE_MAX_AGE")); err == nil && value >= 0 {
+		return value
+	}
+	return defaultCacheMaxAge
+}
+
+func writeHeaderSidecars(paths Paths) error { // Schreibt die konfigurierten Sidecars für alle generierten Dateien.
+	kinds := headerSidecars()
+	if len(kinds) == 0 {
+		return nil
+	}
+	files := outputFiles(paths)
+	for _, kind := range kinds {
+		name := sidecarName(kind)
+		if name == "" {
+			return fmt.Errorf("unknown FEED_HEADERS value: %s", kind)
+		}
+		var b strings.Builder
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(paths.root, file)
+			if err != nil {
+				return err
+			}
+			rel = filepath.ToSlash(rel)
+			etag := fmt.Sprintf(`"%x"`, md5.Sum(data)) // Starker ETag aus dem Inhalt: ändert sich nur bei echten Änderungen.
+			cache := fmt.Sprintf("public, max-age=%d", cacheMaxAge())
+			if name == "_headers" {
+				fmt.Fprintf(&b, "/%s\n  Content-Type: %s\n  Cache-Control: %s\n  ETag: %s\n\n", rel, publish.ContentType(rel), cache, etag)
+				continue
+			}
+			fmt.Fprintf(&b, "<Files \"%s\">\n  ForceType %s\n  <IfModule mod_headers.c>\n    Header set Cache-Control \"%s\"\n    Header set ETag \"%s\"\n  </IfModule>\n</Files>\n\n",
+				filepath.Base(rel), strings.Split(publish.ContentType(rel), ";")[0], cache, strings.ReplaceAll(etag, `"`, `\"`))
+		}
+		if err := writeFileAtomic(filepath.Join(paths.root, name), []byte(strings.TrimRight(b.String(), "\n")+"\n"), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sidecarFiles(paths Paths) []string { // Pfade aller konfigurierten Sidecars (für Publish).
+	files := []string{}
+	for _, kind := range headerSidecars() {
+		if name := sidecarName(kind); name != "" {
+			files = append(files, filepath.Join(paths.root, name))
+		}
+	}
+	return files
+}
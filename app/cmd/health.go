@@ -0,0 +1,85 @@
+package cmd // Paket "cmd": Zustand pro Provider (Fehler in Folge) und Circuit Breaker – eine wackelige Quelle kostet nicht jeden Lauf Retries.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"     // Stderr.
+	"os"      // Stderr.
+	"strconv" // FEED_BREAKER_THRESHOLD parsen.
+	"time"    // Cool-down.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultBreakerThreshold = 3            // Fehler in Folge, nach denen eine Quelle pausiert.
+const defaultBreakerCooldown = 6 * time.Hour // So lange wird sie dann übersprungen.
+
+type ProviderHealth struct { // Zustand einer Quelle in state.json ("health"); fehlt sie, lief der letzte Abruf fehlerfrei.
+	Failures    int    `json:"failures"`             // Fehlgeschlagene Abrufe in Folge.
+	LastError   string `json:"last_error,omitempty"` // Letzter Fehler.
+	LastFailure string `json:"last_failure"`         // Zeitpunkt des letzten Fehlers (RFC3339).
+	OpenUntil   string `json:"open_until,omitempty"` // Circuit offen: bis dahin wird die Quelle übersprungen (RFC3339).
+}
+
+func breakerThreshold() int { // FEED_BREAKER_THRESHOLD, Default 3; 0 = Circuit Breaker aus.
+	_ = env.LoadDotEnv()
+	value := env.ReadEnv("FEED_BREAKER_THRESHOLD")
+	if value == "" {
+		return defaultBreakerThreshold
+	}
+	threshold, err := strconv.Atoi(value)
+	if err != nil || threshold < 0 {
+		fmt.Fprintf(os.Stderr, "invalid FEED_BREAKER_THRESHOLD %q, using %d\n", value, defaultBreakerThreshold)
+		return defaultBreakerThreshold
+	}
+	return threshold
+}
+
+func breakerCooldown() time.Duration { // FEED_BREAKER_COOLDOWN als Go-Dauer (z.B. "2h"), Default 6h.
+	_ = env.LoadDotEnv()
+	value := env.ReadEnv("FEED_BREAKER_COOLDOWN")
+	if value == "" {
+		return defaultBreakerCooldown
+	}
+	cooldown, err := time.ParseDuration(value)
+	if err != nil || cooldown <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid FEED_BREAKER_COOLDOWN %q, using %s\n", value, defaultBreakerCooldown)
+		return defaultBreakerCooldown
+	}
+	return cooldown
+}
+
+func openCircuits(list []feedProvider, health map[string]ProviderHealth, now time.Time) (active []feedProvider, skipped []feedProvider) { // Teilt die Provider: abrufen bzw. überspringen (Circuit offen); nach dem Cool-down gibt es einen Probeversuch.
+	for _, provider := range list {
+		if until, err := parseTime(health[provider.Name].OpenUntil); err == nil && now.Before(until) {
+			skipped = append(skipped, provider)
+			continue
+		}
+		active = append(active, provider)
+	}
+	return active, skipped
+}
+
+func recordHealth(health map[string]ProviderHealth, name string, err error, now time.Time) { // Erfolg setzt die Quelle zurück; der N-te Fehler in Folge öffnet den Circuit (auch der Probeversuch danach).
+	if err == nil {
+		delete(health, name)
+		return
+	}
+	state := health[name]
+	state.Failures++
+	state.LastError = err.Error()
+	state.LastFailure = now.Format(time.RFC3339)
+	state.OpenUntil = ""
+	if threshold := breakerThreshold(); threshold > 0 && state.Failures >= threshold {
+		state.OpenUntil = now.Add(breakerCooldown()).Format(time.RFC3339)
+		fmt.Fprintf(os.Stderr, "%s: %d failures in a row, skipping until %s\n", name, state.Failures, state.OpenUntil)
+	}
+	health[name] = state
+}
+
+func saveHealth(path string, health map[string]ProviderHealth) { // Schreibt den Zustand in state.json (andere Felder bleiben erhalten).
+	state := loadState(path)
+	if len(health) == 0 && len(state.Health) == 0 {
+		return
+	}
+	state.Health = health
+	saveState(path, state)
+}
@@ -0,0 +1,81 @@
+package cmd // Tests für den Circuit Breaker: Fehler zählen, Circuit öffnen, nach dem Cool-down Probeversuch.
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRecordHealth(t *testing.T) {
+	t.Setenv("FEED_BREAKER_THRESHOLD", "3")
+	t.Setenv("FEED_BREAKER_COOLDOWN", "2h")
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	boom := errors.New("boom")
+	tests := []struct {
+		name         string
+		before       ProviderHealth
+		err          error
+		wantFailures int    // 0 = Eintrag entfernt.
+		wantOpen     string // OpenUntil.
+	}{
+		{"Erfolg setzt zurück", ProviderHealth{Failures: 5, OpenUntil: "2024-05-01T11:00:00Z"}, nil, 0, ""},
+		{"erster Fehler", ProviderHealth{}, boom, 1, ""},
+		{"unter der Schwelle", ProviderHealth{Failures: 1}, boom, 2, ""},
+		{"Schwelle öffnet", ProviderHealth{Failures: 2}, boom, 3, "2024-05-01T14:00:00Z"},
+		{"Probeversuch scheitert: wieder offen", ProviderHealth{Failures: 3, OpenUntil: "2024-05-01T11:00:00Z"}, boom, 4, "2024-05-01T14:00:00Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			health := map[string]ProviderHealth{"p": test.before}
+			recordHealth(health, "p", test.err, now)
+			state, ok := health["p"]
+			if test.wantFailures == 0 {
+				if ok {
+					t.Errorf("health = %+v, want removed", state)
+				}
+				return
+			}
+			if state.Failures != test.wantFailures || state.OpenUntil != test.wantOpen {
+				t.Errorf("health = %+v, want failures %d, open until %q", state, test.wantFailures, test.wantOpen)
+			}
+			if state.LastError != "boom" || state.LastFailure != "2024-05-01T12:00:00Z" {
+				t.Errorf("health = %+v, want last error/failure recorded", state)
+			}
+		})
+	}
+}
+
+func TestRecordHealthDisabled(t *testing.T) { // Schwelle 0 = Circuit Breaker aus.
+	t.Setenv("FEED_BREAKER_THRESHOLD", "0")
+	health := map[string]ProviderHealth{"p": {Failures: 10}}
+	recordHealth(health, "p", errors.New("boom"), time.Now())
+	if health["p"].OpenUntil != "" {
+		t.Errorf("circuit opened with threshold 0: %+v", health["p"])
+	}
+}
+
+func TestOpenCircuits(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	list := []feedProvider{{Name: "ok"}, {Name: "offen"}, {Name: "abgelaufen"}, {Name: "kaputt"}}
+	health := map[string]ProviderHealth{
+		"ok":         {Failures: 1},
+		"offen":      {Failures: 3, OpenUntil: "2024-05-01T13:00:00Z"},
+		"abgelaufen": {Failures: 3, OpenUntil: "2024-05-01T12:00:00Z"}, // Cool-down vorbei: Probeversuch.
+		"kaputt":     {Failures: 3, OpenUntil: "morgen"},
+	}
+	active, skipped := openCircuits(list, health, now)
+	names := func(list []feedProvider) []string {
+		result := []string{}
+		for _, provider := range list {
+			result = append(result, provider.Name)
+		}
+		return result
+	}
+	if got, want := names(active), []string{"ok", "abgelaufen", "kaputt"}; !slices.Equal(got, want) {
+		t.Errorf("active = %v, want %v", got, want)
+	}
+	if got, want := names(skipped), []string{"offen"}; !slices.Equal(got, want) {
+		t.Errorf("skipped = %v, want %v", got, want)
+	}
+}
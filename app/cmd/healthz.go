@@ -0,0 +1,78 @@
+package cmd // Paket "cmd": /healthz für Container-Probes (feed serve, feed daemon -addr) – unhealthy bei veraltetem Update oder wenn alle Quellen scheitern.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"encoding/json" // Antwort.
+	"fmt"           // Warnungen + Gründe.
+	"net/http"      // Handler.
+	"os"            // Stderr.
+	"time"          // Alter des letzten Updates.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultHealthMaxAge = 3 * time.Hour // Älter darf das letzte erfolgreiche Update nicht sein (stündlicher Lauf + Puffer).
+
+type healthStatus struct { // Antwort von /healthz.
+	Status     string   `json:"status"`                // "ok" oder "unhealthy".
+	LastUpdate string   `json:"last_update,omitempty"` // Letzter erfolgreicher Update-Lauf (RFC3339).
+	Failing    []string `json:"failing,omitempty"`     // Quellen, deren letzter Abruf scheiterte.
+	Reasons    []string `json:"reasons,omitempty"`     // Warum unhealthy.
+}
+
+func healthMaxAge() time.Duration { // FEED_HEALTH_MAX_AGE als Go-Dauer (z.B. "90m"), Default 3h; 0 = Alter nicht prüfen.
+	_ = env.LoadDotEnv()
+	value := env.ReadEnv("FEED_HEALTH_MAX_AGE")
+	if value == "" {
+		return defaultHealthMaxAge
+	}
+	maxAge, err := time.ParseDuration(value)
+	if err != nil || maxAge < 0 {
+		fmt.Fprintf(os.Stderr, "invalid FEED_HEALTH_MAX_AGE %q, using %s\n", value, defaultHealthMaxAge)
+		return defaultHealthMaxAge
+	}
+	return maxAge
+}
+
+func saveLastUpdate(path string, now time.Time) { // Merkt sich einen erfolgreich beendeten Lauf in state.json (andere Felder bleiben erhalten).
+	state := loadState(path)
+	state.LastUpdate = now.Format(time.RFC3339)
+	saveState(path, state)
+}
+
+func checkHealth(paths Paths, now time.Time) healthStatus { // Liest state.json: Alter des letzten Updates (ersatzweise des letzten Builds) und Fehler pro Quelle.
+	state := loadState(paths.state)
+	status := healthStatus{Status: "ok", LastUpdate: state.LastUpdate}
+	if status.LastUpdate == "" {
+		status.LastUpdate = state.LastBuild // Ältere state.json ohne last_update.
+	}
+	if last, err := parseTime(status.LastUpdate); err != nil {
+		status.Reasons = append(status.Reasons, "no successful update yet")
+	} else if maxAge := healthMaxAge(); maxAge > 0 && now.Sub(last) > maxAge {
+		status.Reasons = append(status.Reasons, fmt.Sprintf("last successful update is older than %s", maxAge))
+	}
+	list := providers(loadProviderSettings(paths.settings))
+	for _, provider := range list {
+		if state.Health[provider.Name].Failures > 0 {
+			status.Failing = append(status.Failing, provider.Name)
+		}
+	}
+	if len(list) > 0 && len(status.Failing) == len(list) {
+		status.Reasons = append(status.Reasons, "all providers are failing")
+	}
+	if len(status.Reasons) > 0 {
+		status.Status = "unhealthy"
+	}
+	return status
+}
+
+func serveHealth(paths Paths) http.HandlerFunc { // 200 = ok, 503 = unhealthy; der Body nennt die Gründe.
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := checkHealth(paths, time.Now().UTC())
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		if status.Status != "ok" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(status)
+	}
+}
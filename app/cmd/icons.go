@@ -0,0 +1,101 @@
+package cmd // Paket "cmd": Favicon/Apple-Touch-Icon der Website finden und in state.json cachen.
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"     // Log-Ausgabe.
+	"html"    // Entities in href auflösen.
+	"net/url" // Relative Icon-URLs auflösen.
+	"os"      // Stderr.
+	"regexp"  // <link>-Tags finden.
+	"strings" // rel-Werte prüfen.
+	"time"    // Cache-Alter.
+)
+
+const iconCacheTTL = 7 * 24 * time.Hour // Icons ändern sich selten: einmal pro Woche neu prüfen reicht.
+
+var linkTagPattern = regexp.MustCompile(`(?is)<link\b[^>]*>`)                                     // Alle <link>-Tags im HTML.
+var attrPattern = regexp.MustCompile(`(?is)\b(rel|href)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s>]+))`) // rel/href mit beliebigen Quotes.
+
+type IconCache struct { // Ergebnis der letzten Icon-Suche (state.json).
+	Link      string `json:"link"`              // Website, für die gesucht wurde (ändert sie sich, wird neu gesucht).
+	Icon      string `json:"icon,omitempty"`    // Großes Icon (apple-touch-icon), Fallback favicon.
+	Favicon   string `json:"favicon,omitempty"` // Kleines Icon (rel=icon), Fallback /favicon.ico.
+	CheckedAt string `json:"checked_at"`        // Zeitpunkt der Suche (RFC3339).
+}
+
+func withIcons(paths Paths, site Site) Site { // Ergänzt fehlende Icons der Site aus Cache bzw. frischer Suche.
+	if site.Link == "" || (site.Icon != "" && site.Favicon != "") { // Explizit konfigurierte Icons gewinnen.
+		return site
+	}
+	state := loadState(paths.state)
+	cache := state.Icons
+	fresh := false
+	if cache != nil && cache.Link == site.Link {
+		if checked, err := parseTime(cache.CheckedAt); err == nil && time.Since(checked) < iconCacheTTL {
+			fresh = true
+		}
+	}
+	if !fresh {
+		icon, favicon, err := discoverIcons(site.Link)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "icon discovery: %v\n", err)
+		}
+		if err == nil || cache == nil || cache.Link != site.Link { // Bei Fehlern den alten Cache behalten.
+			cache = &IconCache{Link: site.Link, Icon: icon, Favicon: favicon, CheckedAt: time.Now().UTC().Format(time.RFC3339)}
+			state.Icons = cache
+			saveState(paths.state, state)
+		}
+	}
+	if site.Icon == "" {
+		site.Icon = cache.Icon
+	}
+	if site.Favicon == "" {
+		site.Favicon = cache.Favicon
+	}
+	return site
+}
+
+func discoverIcons(link string) (string, string, error) { // Liest die Startseite und sucht apple-touch-icon + icon.
+	base, err := url.Parse(link)
+	if err != nil {
+		return "", "", err
+	}
+	fallback := base.ResolveReference(&url.URL{Path: "/favicon.ico"}).String() // Klassischer Ort, wenn das HTML nichts verrät.
+	body, err := fetchFeed(link, "icon")
+	if err != nil {
+		return fallback, fallback, err
+	}
+	icon, favicon := "", ""
+	for _, tag := range linkTagPattern.FindAllString(string(body), -1) {
+		rel, href := "", ""
+		for _, match := range attrPattern.FindAllStringSubmatch(tag, -1) {
+			value := html.UnescapeString(match[2] + match[3] + match[4])
+			if strings.EqualFold(match[1], "rel") {
+				rel = strings.ToLower(value)
+			} else {
+				href = value
+			}
+		}
+		if href == "" {
+			continue
+		}
+		ref, err := url.Parse(strings.TrimSpace(href))
+		if err != nil {
+			continue
+		}
+		resolved := base.ResolveReference(ref).String()
+		fields := strings.Fields(rel)
+		switch {
+		case icon == "" && (containsFold(fields, "apple-touch-icon") || containsFold(fields, "apple-touch-icon-precomposed")):
+			icon = resolved
+		case favicon == "" && containsFold(fields, "icon"):
+			favicon = resolved
+		}
+	}
+	if favicon == "" {
+		favicon = fallback
+	}
+	if icon == "" {
+		icon = favicon
+	}
+	return icon, favicon, nil
+}
@@ -0,0 +1,56 @@
+package cmd // Paket "cmd": Beitragsbilder – aus dem Item übernehmen und als <enclosure>/<media:content> ausgeben (Thumbnails in der WapuuGotchi-UI).
+
+import ( // Import-Block: Standardbibliothek + Feed-Paket.
+	"mime"    // MIME-Type anhand der Endung.
+	"path"    // Endung aus dem URL-Pfad.
+	"strings" // Präfix/Query prüfen.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+type Enclosure struct { // RSS-Anhang; length ist Pflicht, 0 = unbekannt.
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int    `xml:"length,attr"`
+}
+
+type MediaContent struct { // Media RSS <media:content> für ein Bild.
+	URL    string `xml:"url,attr"`
+	Medium string `xml:"medium,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+}
+
+func entryImage(item feed.Item, content string) string { // Bild der Quelle, sonst das erste <img> im bereinigten Inhalt (ohne Tracking-Pixel); nur absolute URLs.
+	image := item.Image
+	if image == "" {
+		image = feed.ContentImage(content)
+	}
+	if !absoluteURL(image) {
+		return ""
+	}
+	return image
+}
+
+func imageType(url string) string { // image/png, image/webp, …; unbekannte Endungen gelten als JPEG (häufigster Fall bei WordPress).
+	if index := strings.IndexAny(url, "?#"); index != -1 {
+		url = url[:index]
+	}
+	if kind := mime.TypeByExtension(strings.ToLower(path.Ext(url))); strings.HasPrefix(kind, "image/") {
+		return kind
+	}
+	return "image/jpeg"
+}
+
+func imageEnclosure(url string) *Enclosure { // nil bei leerer URL (Element entfällt dann).
+	if url == "" {
+		return nil
+	}
+	return &Enclosure{URL: url, Type: imageType(url)}
+}
+
+func mediaImage(url string) *MediaContent { // nil bei leerer URL (Element entfällt dann).
+	if url == "" {
+		return nil
+	}
+	return &MediaContent{URL: url, Medium: "image", Type: imageType(url)}
+}
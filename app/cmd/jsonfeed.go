@@ -0,0 +1,102 @@
+package cmd // Paket "cmd": JSON Feed 1.1 (feed.json) neben jedem RSS-Feed.
+
+import ( // Import-Block: Standardbibliothek + Publish-Paket (öffentliche URL).
+	"bytes"         // Puffer für den Encoder.
+	"encoding/json" // JSON-Ausgabe.
+	"path/filepath" // Dateiendung tauschen.
+	"strings"       // URL-Ersetzung.
+	"time"          // Datumsformat.
+
+	"wapuugotchi/feed/app/publish"
+)
+
+type JSONFeed struct { // Root-Objekt nach https://jsonfeed.org/version/1.1.
+	Version     string         `json:"version"`                 // Immer "https://jsonfeed.org/version/1.1".
+	Title       string         `json:"title"`                   // Feed-Titel.
+	HomePageURL string         `json:"home_page_url,omitempty"` // Website (site.Link).
+	FeedURL     string         `json:"feed_url,omitempty"`      // Öffentliche URL dieses Feeds (falls bekannt).
+	Description string         `json:"description,omitempty"`   // Feed-Beschreibung.
+	Icon        string         `json:"icon,omitempty"`          // Großes Icon (z.B. apple-touch-icon).
+	Favicon     string         `json:"favicon,omitempty"`       // Kleines Icon (favicon).
+	Language    string         `json:"language,omitempty"`      // Sprache des Feeds.
+	Items       []JSONFeedItem `json:"items"`                   // Items (nie null).
+}
+
+type JSONFeedItem struct { // Ein Item im JSON Feed.
+	ID            string             `json:"id"`                       // Stabile Entry-ID.
+	URL           string             `json:"url,omitempty"`            // Link zum Original.
+	Title         string             `json:"title,omitempty"`          // Titel.
+	ContentHTML   string             `json:"content_html,omitempty"`   // HTML-Inhalt.
+	Summary       string             `json:"summary,omitempty"`        // KI-Zusammenfassung (Klartext).
+	DatePublished string             `json:"date_published,omitempty"` // RFC3339.
+	Image         string             `json:"image,omitempty"`          // Vorschaubild (URL).
+	Tags          []string           `json:"tags,omitempty"`           // Kategorien.
+	Language      string             `json:"language,omitempty"`       // Eigene Sprache des Entries (falls abweichend).
+	Extension     *JSONFeedExtension `json:"_wapuugotchi,omitempty"`   // Eigene Felder (JSON-Feed-Erweiterungen beginnen mit "_").
+}
+
+type JSONFeedExtension struct { // Zusatzfelder für das WapuuGotchi-Plugin.
+	Duration       int    `json:"duration,omitempty"`        // Laufzeit in Sekunden (Video/Audio).
+	Transcript     string `json:"transcript,omitempty"`      // Link zu Untertiteln/Transkript.
+	Type           string `json:"type,omitempty"`            // Typ des Entries (z.B. "event").
+	StartsAt       string `json:"starts_at,omitempty"`       // Events: Startzeit (RFC3339).
+	SourceLanguage string `json:"source_language,omitempty"` // Übersetzt aus dieser Sprache ("und" = unbekannt).
+	WordCount      int    `json:"word_count,omitempty"`      // Wörter im Inhalt.
+	ReadingTime    int    `json:"reading_time,omitempty"`    // Geschätzte Lesezeit in Minuten.
+}
+
+func jsonFeedPath(path string) string { // feed.xml → feed.json, feed.videos.de.xml → feed.videos.de.json.
+	return strings.TrimSuffix(path, filepath.Ext(path)) + ".json"
+}
+
+func buildJSONFeed(site Site, entries []Entry, outputPath string) error { // Schreibt die Entries (bereits sortiert) als JSON Feed.
+	out := JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       site.Title,
+		HomePageURL: site.Link,
+		Description: site.Description,
+		Icon:        site.Icon,
+		Favicon:     site.Favicon,
+		Language:    site.Language,
+		Items:       []JSONFeedItem{},
+	}
+	if feedURL := publish.FeedURL(); feedURL != "" && strings.HasSuffix(feedURL, "/feed.xml") { // Gleicher Ort wie feed.xml, nur mit dem eigenen Dateinamen.
+		out.FeedURL = strings.TrimSuffix(feedURL, "feed.xml") + filepath.Base(outputPath)
+	}
+	params := linkParams()
+	for _, entry := range entries {
+		createdAt, err := parseTime(entry.CreatedAt)
+		if err != nil { // Wie im RSS: kaputte Zeitstempel überspringen.
+			continue
+		}
+		item := JSONFeedItem{
+			ID:            entry.ID,
+			URL:           decorateLink(entry.Link, params),
+			Title:         entry.Title,
+			ContentHTML:   entry.Content,
+			Summary:       entry.Summary,
+			DatePublished: createdAt.UTC().Format(time.RFC3339),
+			Tags:          entry.Categories,
+			Image:         entry.Image,
+		}
+		if item.Image == "" { // Videos: Poster statt Beitragsbild.
+			item.Image = entry.Thumbnail
+		}
+		words := wordCount(entry.Content)
+		if extension := (JSONFeedExtension{Duration: entry.Duration, Transcript: entry.Transcript, Type: entry.Type, StartsAt: entry.StartsAt, SourceLanguage: entry.SourceLanguage, WordCount: words, ReadingTime: readingTime(words)}); extension != (JSONFeedExtension{}) {
+			item.Extension = &extension
+		}
+		if entry.Language != "" && entry.Language != site.Language {
+			item.Language = entry.Language
+		}
+		out.Items = append(out.Items, item)
+	}
+	var data bytes.Buffer
+	enc := json.NewEncoder(&data)
+	enc.SetEscapeHTML(false)          // content_html lesbar halten (kein \u003c).
+	enc.SetIndent("", outputIndent()) // Leer bei FEED_MINIFY: kompakt.
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	return writeFileAtomic(outputPath, data.Bytes(), 0o644)
+}
@@ -0,0 +1,30 @@
+package cmd // Paket "cmd": Keyword-Filter pro Provider (nur Items mit passendem Schlagwort).
+
+import ( // Import-Block: Standardbibliothek + internes Feed-Paket.
+	"fmt" // Log-Ausgabe.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+var defaultKeywords = map[string][]string{ // Provider, die ohne Filter zu viel Fremdes liefern würden.
+	"ma-tt": {"WordPress"},
+}
+
+func applyKeywordFilter(provider feedProvider) feedProvider { // Verwirft Items ohne eines der Schlagwörter aus keywords (bzw. dem Default des Providers).
+	keywords := provider.Settings.Keywords
+	if len(keywords) == 0 {
+		keywords = defaultKeywords[provider.Name]
+	}
+	if len(keywords) == 0 {
+		return provider
+	}
+	return withItemFilter(provider, func(item feed.Item) bool {
+		if anyContainsFold(item.Categories, keywords) {
+			return false
+		}
+		if provider.FetchAll == nil { // Bei FetchAll-Quellen wäre das Log pro Lauf nur Rauschen.
+			fmt.Printf("skipped %s: %q (keyword filter)\n", provider.Name, item.Title)
+		}
+		return true
+	})
+}
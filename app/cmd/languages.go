@@ -0,0 +1,137 @@
+package cmd // Paket "cmd": mehrsprachige Ausgabe – jeder Entry wird in die Sprachen aus site.json "languages" (bzw. FEED_LANGUAGES) übersetzt, pro Sprache entsteht feed.<lang>.xml.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"           // Fehlerausgabe.
+	"os"            // Stderr.
+	"path/filepath" // Ausgabepfade.
+	"sort"          // Neueste Entries zuerst übersetzen.
+	"strconv"       // FEED_LANGUAGES_BATCH.
+	"strings"       // Sprachlisten + Vergleiche.
+	"time"          // Zeitpunkt im Sprach-State.
+
+	"wapuugotchi/feed/app/ai"
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultLanguageBatch = 25 // Höchstens so viele Übersetzungen pro Sprache und Lauf (der Rest folgt in späteren Läufen).
+
+type EntryText struct { // Übersetzter Titel + Inhalt eines Entries in einer Sprache.
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+type LanguageState struct { // Zustand pro Zielsprache (state.json "languages"): ein Fehler in "fr" hält "de" nicht auf.
+	LastRun string `json:"last_run,omitempty"` // Letzter Übersetzungsversuch (RFC3339).
+	Pending int    `json:"pending,omitempty"`  // Entries, die noch auf eine Übersetzung warten.
+	Error   string `json:"error,omitempty"`    // Letzter Fehler; leer = letzter Lauf ohne Fehler.
+}
+
+func feedLanguages(site Site) []string { // Zielsprachen ohne Dubletten und ohne die Sprache der Site (die hat feed.xml).
+	languages := []string{}
+	for _, language := range site.Languages {
+		language = strings.TrimSpace(language)
+		if language == "" || strings.EqualFold(language, site.Language) || containsFold(languages, language) {
+			continue
+		}
+		languages = append(languages, language)
+	}
+	return languages
+}
+
+func languageFeedPath(path, language string) string { // feed.xml → feed.de.xml.
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + strings.ToLower(language) + ext
+}
+
+func translateEntries(site Site, entries []Entry, states map[string]LanguageState) map[string]LanguageState { // Ergänzt fehlende Übersetzungen (neueste zuerst); pro Sprache bricht der erste Fehler nur diese Sprache ab.
+	languages := feedLanguages(site)
+	if len(languages) == 0 {
+		return states
+	}
+	translator, err := newTranslator()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "translate: %v\n", err)
+		return states
+	}
+	defer translations.save() // Auch teilweise Erfolge nicht noch einmal bezahlen.
+	batch := defaultLanguageBatch
+	if value, err := strconv.Atoi(env.ReadEnv("FEED_LANGUAGES_BATCH")); err == nil && value > 0 {
+		batch = value
+	}
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return entries[order[a]].CreatedAt > entries[order[b]].CreatedAt })
+	if states == nil {
+		states = map[string]LanguageState{}
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	for _, language := range languages {
+		status := LanguageState{LastRun: now}
+		done := 0
+		for _, i := range order {
+			entry := &entries[i]
+			if _, ok := entry.Translations[language]; ok || strings.EqualFold(entryLanguage(site, *entry), language) {
+				continue
+			}
+			if status.Error != "" || done == batch { // Sprache für diesen Lauf beendet: Rest bleibt offen.
+				status.Pending++
+				continue
+			}
+			text, err := translateEntry(translator, *entry, language)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "translate %s (%s): %v\n", entry.ID, language, err)
+				status.Error = err.Error()
+				status.Pending++
+				continue
+			}
+			if text.Content == entry.Content && text.Title == strings.TrimSpace(entry.Title) { // Passthrough (TRANSLATE_PROVIDER=none): nichts zu speichern.
+				status.Pending++
+				continue
+			}
+			if entry.Translations == nil {
+				entry.Translations = map[string]EntryText{}
+			}
+			entry.Translations[language] = text
+			done++
+		}
+		states[language] = status
+	}
+	return states
+}
+
+func translateEntry(translator ai.Translator, entry Entry, language string) (EntryText, error) { // Titel + Inhalt einer Sprache.
+	content, err := translator.Translate(entry.Content, language)
+	if err != nil {
+		return EntryText{}, err
+	}
+	title, err := translator.Translate(entry.Title, language)
+	if err != nil {
+		return EntryText{}, err
+	}
+	return EntryText{Title: strings.TrimSpace(title), Content: content}, nil
+}
+
+func localizedEntries(site Site, entries []Entry, language string) []Entry { // Entries für feed.<lang>.xml: übersetzt, wo möglich, sonst im Original.
+	result := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if text, ok := entry.Translations[language]; ok {
+			entry.SourceLanguage = entryLanguage(site, entry)
+			entry.Language = language
+			entry.Content = text.Content
+			entry.Summary = "" // Die Zusammenfassung ist in der Originalsprache.
+			if text.Title != "" {
+				entry.Title = text.Title
+			}
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+func languageSite(site Site, language string) Site { // Channel-Metadaten einer Zielsprache (site.json "localized").
+	site = site.localize(language)
+	site.Language = language
+	return site
+}
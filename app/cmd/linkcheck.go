@@ -0,0 +1,92 @@
+package cmd // Paket "cmd": Link-Check – prüft, ob die Ziele der Entries noch erreichbar sind.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"      // Ausgabe.
+	"io"       // Body verwerfen.
+	"net/http" // HEAD/GET Requests (Redirects folgt der Client automatisch).
+	"os"       // Stderr.
+	"strconv"  // FEED_LINK_EXPIRE_DAYS.
+	"time"     // Timeout + Ablaufzeit.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultLinkExpireDays = 14 // So lange darf ein toter Link im Feed bleiben, bevor der Entry entfernt wird.
+
+func linkExpiry() time.Duration { // FEED_LINK_EXPIRE_DAYS (Default 14, 0 = nur markieren, nie entfernen).
+	_ = env.LoadDotEnv()
+	if days, err := strconv.Atoi(env.ReadEnv("FEED_LINK_EXPIRE_DAYS")); err == nil && days >= 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return defaultLinkExpireDays * 24 * time.Hour
+}
+
+func RunCheckLinks() error { // Prüft alle Links, markiert tote und entfernt Entries, deren Link zu lange tot ist.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	entries := loadEntries(paths.entries)
+	client, err := httpClient(false)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	expiry := linkExpiry()
+	changed := false
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.Link == "" || entry.Pinned { // Ohne Link nichts zu prüfen; angepinnte Entries verwaltet die Redaktion selbst.
+			kept = append(kept, entry)
+			continue
+		}
+		status, err := linkStatus(client, entry.Link)
+		switch {
+		case err != nil: // Netzwerkfehler/Timeouts gelten als vorübergehend: Zustand nicht ändern.
+			fmt.Fprintf(os.Stderr, "link check %s: %v\n", entry.Link, err)
+		case status == http.StatusNotFound || status == http.StatusGone:
+			if entry.DeadSince == "" {
+				entry.DeadSince = now.Format(time.RFC3339)
+				changed = true
+				fmt.Printf("dead link (%d): %s\n", status, entry.Link)
+			}
+			if since, err := parseTime(entry.DeadSince); err == nil && expiry > 0 && now.Sub(since) >= expiry {
+				fmt.Printf("expired entry '%s' (dead since %s)\n", entry.Title, entry.DeadSince)
+				changed = true
+				continue
+			}
+		case entry.DeadSince != "" && status < 400: // Wieder erreichbar: Markierung aufheben.
+			entry.DeadSince = ""
+			changed = true
+		}
+		kept = append(kept, entry)
+	}
+	if !changed {
+		fmt.Println("all links ok")
+		return nil
+	}
+	return finishUpdate(paths, loadSite(paths.site), kept, nil)
+}
+
+func linkStatus(client *http.Client, link string) (int, error) { // Statuscode nach Redirects; HEAD zuerst, GET als Fallback.
+	status, err := requestStatus(client, http.MethodHead, link)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusForbidden || status == http.StatusNotImplemented) {
+		return requestStatus(client, http.MethodGet, link) // Manche Server unterstützen HEAD nicht sauber.
+	}
+	return status, err
+}
+
+func requestStatus(client *http.Client, method, link string) (int, error) { // Einzelner Request; Body wird verworfen.
+	req, err := http.NewRequest(method, link, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", userAgent())
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,35 @@
+package cmd // Paket "cmd": Link-Decorator für ausgehende Links (Analytics-Parameter).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"net/url" // Query-Parameter parsen/anhängen.
+
+	"wapuugotchi/feed/app/env"
+)
+
+func linkParams() url.Values { // FEED_LINK_PARAMS, z.B. "utm_source=wapuugotchi&utm_medium=feed" (leer = aus).
+	_ = env.LoadDotEnv()
+	values, err := url.ParseQuery(env.ReadEnv("FEED_LINK_PARAMS"))
+	if err != nil {
+		return nil // Kaputte Konfiguration: Links lieber unverändert lassen.
+	}
+	return values
+}
+
+func decorateLink(link string, params url.Values) string { // Hängt die Parameter an, ohne vorhandene Werte zu überschreiben.
+	if len(params) == 0 || link == "" {
+		return link
+	}
+	parsed, err := url.Parse(link)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") { // Nur echte Web-Links (kein mailto:, keine relativen Pfade).
+		return link
+	}
+	query := parsed.Query()
+	for key, values := range params {
+		if query.Has(key) { // Upstream hat den Parameter schon gesetzt: dessen Wert gewinnt.
+			continue
+		}
+		query[key] = values
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
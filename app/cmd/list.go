@@ -10,6 +10,9 @@ import (
 func RunListItems()  {
 	fmt.Printf("Wapuugotchi Feed Generator\n")
 	feedFile := "feed.xml"
+	if paths, err := getPaths(); err == nil {
+		feedFile = paths.feed
+	}
 	file, err := os.Open(feedFile)
 	if err != nil {
 		fmt.Printf("Error opening feed file: %v\n", err)
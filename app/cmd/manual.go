@@ -0,0 +1,110 @@
+package cmd // Paket "cmd": Handgeschriebene Entries (Ankündigungen) direkt in entries.json eintragen.
+
+import ( // Import-Block: Standardbibliothek.
+	"bytes"         // JSON-Datei strikt dekodieren.
+	"encoding/json" // --from-file.
+	"errors"        // Validierungsfehler sammeln.
+	"fmt"           // Fehlertexte + Ausgabe.
+	"os"            // Datei lesen.
+	"strings"       // Trimmen.
+	"time"          // created_at.
+)
+
+const manualProvider = "manual" // Provider-Name handgeschriebener Entries (geht in die ID ein, Filter/Notifier sehen ihn).
+
+func RunAddEntry(entry Entry, fromFile string) error { // Prüft entry (Flags haben Vorrang vor fromFile), hängt ihn an entries.json an und baut die Feeds neu.
+	if fromFile != "" {
+		loaded, err := readManualEntry(fromFile)
+		if err != nil {
+			return err
+		}
+		entry = mergeManualEntry(loaded, entry)
+	}
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	categoryMapping = loadCategoryMap(paths.categories) // Gleiche Kategorien wie bei Quellen.
+	entry, err = prepareManualEntry(entry, nil)
+	if err != nil {
+		return err
+	}
+	entries := loadEntries(paths.entries)
+	for _, existing := range entries {
+		for _, id := range append([]string{existing.ID}, existing.Aliases...) {
+			if id == entry.ID {
+				return fmt.Errorf("entry %s already exists: %s", entry.ID, existing.Title)
+			}
+		}
+	}
+	entries = append(entries, entry)
+	if err := finishUpdate(paths, loadSite(paths.site), entries, visibleEntries([]Entry{entry}, time.Now().UTC())); err != nil { // Wie ein neuer Entry aus einer Quelle: auch Notifier laufen (mit publish_at erst nach dem Embargo, über releasedSince).
+		return err
+	}
+	fmt.Printf("Entry '%s' added (%s)\n", entry.Title, entry.ID)
+	return nil
+}
+
+func readManualEntry(path string) (Entry, error) { // Ein Entry im Format von entries.json; unbekannte Felder sind ein Fehler (Tippfehler fallen auf).
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	var entry Entry
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&entry); err != nil {
+		return Entry{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return entry, nil
+}
+
+func mergeManualEntry(base, flags Entry) Entry { // Gesetzte Flags überschreiben die Werte aus der Datei.
+	if flags.Title != "" {
+		base.Title = flags.Title
+	}
+	if flags.Link != "" {
+		base.Link = flags.Link
+	}
+	if flags.Content != "" {
+		base.Content = flags.Content
+	}
+	if len(flags.Categories) > 0 {
+		base.Categories = flags.Categories
+	}
+	return base
+}
+
+func prepareManualEntry(entry Entry, extraTags []string) (Entry, error) { // Normalisiert und validiert; vergibt created_at, Provider und eine stabile ID.
+	entry.Title = cleanTitle(entry.Title, nil)
+	entry.Link = strings.TrimSpace(entry.Link)
+	entry.Categories = cleanCategories(entry.Categories)
+	entry.Content = sanitizeHTML(entry.Content, extraTags) // Gleiche Allowlist wie für Quellen.
+	entry.Content = resolveURLs(entry.Content, entry.Link) // Relative Bilder/Links gegen den Link auflösen.
+	problems := []error{}
+	if entry.Title == "" {
+		problems = append(problems, errors.New("missing title"))
+	}
+	if entry.Link != "" && !absoluteURL(entry.Link) {
+		problems = append(problems, fmt.Errorf("link must be an absolute http(s) URL: %q", entry.Link))
+	}
+	if entry.CreatedAt == "" {
+		entry.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	} else if _, err := parseTime(entry.CreatedAt); err != nil {
+		problems = append(problems, fmt.Errorf("invalid created_at %q (RFC3339 expected)", entry.CreatedAt))
+	}
+	if entry.Provider == "" {
+		entry.Provider = manualProvider
+	}
+	if entry.ID == "" { // Stabil: gleicher Link (bzw. Titel) ergibt dieselbe ID, ein doppeltes add-entry fällt also auf.
+		base := entry.Link
+		if base == "" {
+			base = entry.Title
+		}
+		entry.ID = hashString(entry.Provider + "|" + base)
+	}
+	if len(problems) > 0 {
+		return Entry{}, fmt.Errorf("invalid entry: %w", errors.Join(problems...))
+	}
+	return entry, nil
+}
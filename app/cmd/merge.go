@@ -0,0 +1,224 @@
+package cmd // Paket "cmd": Drei-Wege-Merge für entries.json/state.json (parallele CI-Läufe, Handarbeit).
+
+import ( // Import-Block: Standardbibliothek.
+	"bytes"         // JSON-Form erkennen + vergleichen.
+	"encoding/json" // Feldweise zerlegen.
+	"errors"        // Fehlende Basis erkennen.
+	"fmt"           // Fehler + Ausgabe.
+	"io/fs"         // fs.ErrNotExist.
+	"os"            // Dateien lesen.
+)
+
+type jsonFields map[string]json.RawMessage // Ein JSON-Objekt, Feld für Feld.
+
+func RunMerge(base, ours, theirs string) error { // Merged base/ours/theirs und schreibt das Ergebnis nach ours (Konvention von git-Merge-Treibern: %O %A %B).
+	baseData, err := os.ReadFile(base)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) { // Ohne Basis (beide Seiten neu) ist alles eine Ergänzung.
+		return err
+	}
+	oursData, err := os.ReadFile(ours)
+	if err != nil {
+		return err
+	}
+	theirsData, err := os.ReadFile(theirs)
+	if err != nil {
+		return err
+	}
+	if trimmed := bytes.TrimSpace(oursData); len(trimmed) > 0 && trimmed[0] == '[' { // Liste = entries.json.
+		merged, err := mergeEntryFiles(baseData, oursData, theirsData)
+		if err != nil {
+			return err
+		}
+		writeJSON(ours, merged)
+		fmt.Printf("merged %d entries into %s\n", len(merged), ours)
+		return nil
+	}
+	merged, err := mergeObjects(baseData, oursData, theirsData) // Objekt = state.json.
+	if err != nil {
+		return err
+	}
+	state := State{}
+	if err := remarshal(merged, &state); err != nil {
+		return err
+	}
+	writeJSON(ours, state)
+	fmt.Printf("merged state into %s\n", ours)
+	return nil
+}
+
+func mergeEntryFiles(baseData, oursData, theirsData []byte) ([]Entry, error) { // Vereinigung nach ID; geänderte Felder werden einzeln zusammengeführt.
+	base, err := entryFields(baseData)
+	if err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	ours, err := entryFields(oursData)
+	if err != nil {
+		return nil, fmt.Errorf("ours: %w", err)
+	}
+	theirs, err := entryFields(theirsData)
+	if err != nil {
+		return nil, fmt.Errorf("theirs: %w", err)
+	}
+	baseByID, theirsByID := indexFields(base), indexFields(theirs)
+	oursByID := indexFields(ours)
+	result := []Entry{}
+	add := func(fields jsonFields) error {
+		entry := Entry{}
+		if err := remarshal(fields, &entry); err != nil {
+			return err
+		}
+		result = append(result, entry)
+		return nil
+	}
+	for _, entry := range ours { // Reihenfolge von ours behalten…
+		id := fieldID(entry)
+		other, inTheirs := theirsByID[id]
+		original, inBase := baseByID[id]
+		if !inTheirs {
+			if inBase && sameFields(entry, original) { // Drüben gelöscht, hier unverändert → gelöscht.
+				continue
+			}
+			if err := add(entry); err != nil { // Neu oder hier geändert → behalten.
+				return nil, err
+			}
+			continue
+		}
+		if err := add(mergeFields(original, entry, other)); err != nil {
+			return nil, err
+		}
+	}
+	for _, entry := range theirs { // …und Ergänzungen von drüben anhängen.
+		id := fieldID(entry)
+		if _, inOurs := oursByID[id]; inOurs {
+			continue
+		}
+		if original, inBase := baseByID[id]; inBase && sameFields(entry, original) { // Hier gelöscht, drüben unverändert → gelöscht.
+			continue
+		}
+		if err := add(entry); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func mergeObjects(baseData, oursData, theirsData []byte) (jsonFields, error) { // Feldweiser Merge zweier JSON-Objekte.
+	base, ours, theirs := jsonFields{}, jsonFields{}, jsonFields{}
+	for _, part := range []struct {
+		data   []byte
+		target *jsonFields
+	}{{baseData, &base}, {oursData, &ours}, {theirsData, &theirs}} {
+		if len(bytes.TrimSpace(part.data)) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(part.data, part.target); err != nil {
+			return nil, err
+		}
+	}
+	return mergeFields(base, ours, theirs), nil
+}
+
+func mergeFields(base, ours, theirs jsonFields) jsonFields { // Pro Feld: nur eine Seite geändert → diese; beide → die neuere.
+	result := jsonFields{}
+	names := map[string]bool{}
+	for _, fields := range []jsonFields{base, ours, theirs} {
+		for name := range fields {
+			names[name] = true
+		}
+	}
+	for name := range names {
+		original, mine, other := base[name], ours[name], theirs[name]
+		value := other
+		switch {
+		case sameJSON(mine, other), sameJSON(other, original):
+			value = mine
+		case sameJSON(mine, original):
+			value = other
+		default: // Beide geändert: Objekte Schlüssel für Schlüssel, Zeitstempel → späterer gewinnt, sonst die eingehende Seite (theirs ist der neuere Stand).
+			value = mergeValues(original, mine, other)
+		}
+		if len(value) > 0 {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+func mergeValues(original, mine, other json.RawMessage) json.RawMessage { // Zwei Objekte (z.B. watermarks, health pro Provider) rekursiv zusammenführen, damit Schlüssel beider Seiten erhalten bleiben.
+	mineFields, otherFields := jsonFields{}, jsonFields{}
+	if json.Unmarshal(mine, &mineFields) != nil || json.Unmarshal(other, &otherFields) != nil || mineFields == nil || otherFields == nil {
+		return newerValue(mine, other)
+	}
+	originalFields := jsonFields{}
+	if json.Unmarshal(original, &originalFields) != nil || originalFields == nil { // Basis fehlt oder war kein Objekt.
+		originalFields = jsonFields{}
+	}
+	merged, err := json.Marshal(mergeFields(originalFields, mineFields, otherFields))
+	if err != nil {
+		return newerValue(mine, other)
+	}
+	return merged
+}
+
+func newerValue(mine, other json.RawMessage) json.RawMessage { // Vergleicht RFC3339-Strings; alles andere → other.
+	var mineText, otherText string
+	if json.Unmarshal(mine, &mineText) == nil && json.Unmarshal(other, &otherText) == nil {
+		mineTime, mineErr := parseTime(mineText)
+		otherTime, otherErr := parseTime(otherText)
+		if mineErr == nil && otherErr == nil && mineTime.After(otherTime) {
+			return mine
+		}
+	}
+	return other
+}
+
+func entryFields(data []byte) ([]jsonFields, error) { // entries.json als Liste von Feld-Maps (leere Datei = leere Liste).
+	list := []jsonFields{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return list, nil
+	}
+	err := json.Unmarshal(data, &list)
+	return list, err
+}
+
+func indexFields(list []jsonFields) map[string]jsonFields { // ID → Felder.
+	index := make(map[string]jsonFields, len(list))
+	for _, fields := range list {
+		index[fieldID(fields)] = fields
+	}
+	return index
+}
+
+func fieldID(fields jsonFields) string { // Wert des "id"-Felds.
+	var id string
+	_ = json.Unmarshal(fields["id"], &id)
+	return id
+}
+
+func sameFields(a, b jsonFields) bool { // Alle Felder gleich (unabhängig von Formatierung).
+	if len(a) != len(b) {
+		return false
+	}
+	for name, value := range a {
+		if !sameJSON(value, b[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameJSON(a, b json.RawMessage) bool { // Vergleich ohne Whitespace-Unterschiede.
+	var left, right bytes.Buffer
+	if json.Compact(&left, a) != nil || json.Compact(&right, b) != nil {
+		return bytes.Equal(bytes.TrimSpace(a), bytes.TrimSpace(b))
+	}
+	return bytes.Equal(left.Bytes(), right.Bytes())
+}
+
+func remarshal(fields jsonFields, target any) error { // Feld-Map zurück in die Struct (Struct-Reihenfolge der Felder im Output).
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
@@ -0,0 +1,87 @@
+package cmd // Tests für den Merge-Treiber: feldweise, verschachtelte Maps (state.json) und Entry-Listen (entries.json, entries-YYYY.json).
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestMergeFields(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		want               string
+	}{
+		{"nur ours geändert", `{"a":1,"b":1}`, `{"a":2,"b":1}`, `{"a":1,"b":1}`, `{"a":2,"b":1}`},
+		{"nur theirs geändert", `{"a":1}`, `{"a":1}`, `{"a":3}`, `{"a":3}`},
+		{"beide gleich geändert", `{"a":1}`, `{"a":2}`, `{"a":2}`, `{"a":2}`},
+		{"beide verschieden → theirs", `{"a":1}`, `{"a":2}`, `{"a":3}`, `{"a":3}`},
+		{"Zeitstempel → späterer", `{"t":"2024-01-01T00:00:00Z"}`, `{"t":"2024-03-01T00:00:00Z"}`, `{"t":"2024-02-01T00:00:00Z"}`, `{"t":"2024-03-01T00:00:00Z"}`},
+		{"Ergänzungen beider Seiten", `{}`, `{"a":1}`, `{"b":2}`, `{"a":1,"b":2}`},
+		{"hier gelöscht, drüben unverändert", `{"a":1,"b":2}`, `{"b":2}`, `{"a":1,"b":2}`, `{"b":2}`},
+		{
+			"verschachtelte Maps Schlüssel für Schlüssel",
+			`{"watermarks":{"x":"2024-01-01T00:00:00Z","y":"2024-01-01T00:00:00Z"}}`,
+			`{"watermarks":{"x":"2024-02-01T00:00:00Z","y":"2024-01-01T00:00:00Z"}}`,
+			`{"watermarks":{"x":"2024-01-01T00:00:00Z","y":"2024-02-01T00:00:00Z","z":"2024-02-01T00:00:00Z"}}`,
+			`{"watermarks":{"x":"2024-02-01T00:00:00Z","y":"2024-02-01T00:00:00Z","z":"2024-02-01T00:00:00Z"}}`,
+		},
+		{
+			"health zwei Ebenen tief",
+			`{"health":{"a":{"failures":1,"last_failure":"2024-01-01T00:00:00Z"}}}`,
+			`{"health":{"a":{"failures":2,"last_failure":"2024-01-02T00:00:00Z"}}}`,
+			`{"health":{"a":{"failures":1,"last_failure":"2024-01-01T00:00:00Z"},"b":{"failures":1,"last_failure":"2024-01-02T00:00:00Z"}}}`,
+			`{"health":{"a":{"failures":2,"last_failure":"2024-01-02T00:00:00Z"},"b":{"failures":1,"last_failure":"2024-01-02T00:00:00Z"}}}`,
+		},
+		{"Map auf einer Seite ohne Basis", `{}`, `{"next_fetch_at":{"a":"2024-01-01T00:00:00Z"}}`, `{"next_fetch_at":{"b":"2024-01-01T00:00:00Z"}}`, `{"next_fetch_at":{"a":"2024-01-01T00:00:00Z","b":"2024-01-01T00:00:00Z"}}`},
+		{"Objekt gegen Wert → theirs", `{"a":{"x":1}}`, `{"a":{"x":2}}`, `{"a":5}`, `{"a":5}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := mergeObjects([]byte(test.base), []byte(test.ours), []byte(test.theirs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := json.Marshal(merged)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !sameJSON(got, json.RawMessage(test.want)) {
+				t.Errorf("merge = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestMergeEntryFiles(t *testing.T) { // Gleiches Format für entries.json und die Jahresdateien (entries-YYYY.json).
+	tests := []struct {
+		name               string
+		base, ours, theirs string
+		want               []string // id:title in Ergebnis-Reihenfolge.
+	}{
+		{"beide ergänzen", `[{"id":"1","title":"a"}]`, `[{"id":"2","title":"b"},{"id":"1","title":"a"}]`, `[{"id":"3","title":"c"},{"id":"1","title":"a"}]`, []string{"2:b", "1:a", "3:c"}},
+		{"drüben gelöscht", `[{"id":"1","title":"a"},{"id":"2","title":"b"}]`, `[{"id":"1","title":"a"},{"id":"2","title":"b"}]`, `[{"id":"2","title":"b"}]`, []string{"2:b"}},
+		{"drüben gelöscht, hier geändert", `[{"id":"1","title":"a"}]`, `[{"id":"1","title":"neu"}]`, `[]`, []string{"1:neu"}},
+		{"Felder beider Seiten", `[{"id":"1","title":"a","link":"x"}]`, `[{"id":"1","title":"neu","link":"x"}]`, `[{"id":"1","title":"a","link":"y"}]`, []string{"1:neu"}},
+		{"ohne Basis (neue Jahresdatei)", ``, `[{"id":"1","title":"a"}]`, `[{"id":"2","title":"b"}]`, []string{"1:a", "2:b"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, err := mergeEntryFiles([]byte(test.base), []byte(test.ours), []byte(test.theirs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []string{}
+			for _, entry := range merged {
+				got = append(got, entry.ID+":"+entry.Title)
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("merge = %v, want %v", got, test.want)
+			}
+		})
+	}
+	merged, err := mergeEntryFiles([]byte(`[{"id":"1","title":"a","link":"x"}]`), []byte(`[{"id":"1","title":"neu","link":"x"}]`), []byte(`[{"id":"1","title":"a","link":"y"}]`))
+	if err != nil || len(merged) != 1 || merged[0].Link != "y" {
+		t.Errorf("field merge lost theirs' link: %+v, %v", merged, err)
+	}
+}
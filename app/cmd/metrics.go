@@ -0,0 +1,132 @@
+package cmd // Paket "cmd": Prometheus-Metriken unter /metrics (feed serve, feed daemon -addr) – Abrufe, Fehler, neue Entries, KI-Aufrufe, Abrufdauer.
+
+import ( // Import-Block: Standardbibliothek + KI-Paket.
+	"fmt"      // Textformat.
+	"net/http" // Handler.
+	"sort"     // Stabile Reihenfolge der Provider.
+	"strings"  // Builder + Label-Escaping.
+	"sync"     // Updates laufen neben dem Server.
+	"time"     // Dauern.
+
+	"wapuugotchi/feed/app/ai"
+)
+
+var latencyBuckets = []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120} // Abrufdauer in Sekunden (Default-Timeout pro Quelle: 2m).
+
+var metrics = &registry{providers: map[string]*providerMetrics{}} // Zähler dieses Prozesses (seit dem Start); feed daemon aktualisiert sie nach jedem Abruf.
+
+type registry struct {
+	sync.Mutex
+	providers map[string]*providerMetrics
+	aiCalls   int // KI-Aufrufe aller Läufe.
+	aiTokens  int
+	aiSkipped int // Wegen Budget übersprungen.
+}
+
+type providerMetrics struct {
+	fetches    int     // Abrufe insgesamt.
+	failures   int     // Davon fehlgeschlagen.
+	newEntries int     // Übernommene Entries.
+	buckets    []int   // Abrufe pro latencyBuckets-Grenze (nicht kumuliert).
+	sum        float64 // Summe der Abrufdauer in Sekunden.
+}
+
+func (r *registry) recordFetch(name string, took time.Duration, added int, err error) { // Ergebnis eines Provider-Abrufs.
+	r.Lock()
+	defer r.Unlock()
+	provider := r.providers[name]
+	if provider == nil {
+		provider = &providerMetrics{buckets: make([]int, len(latencyBuckets))}
+		r.providers[name] = provider
+	}
+	provider.fetches++
+	if err != nil {
+		provider.failures++
+	}
+	provider.newEntries += added
+	seconds := took.Seconds()
+	provider.sum += seconds
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			provider.buckets[i]++
+			break
+		}
+	}
+}
+
+func (r *registry) recordAI(usage ai.Usage) { // Verbrauch eines Laufs (ai.CurrentUsage) aufaddieren.
+	r.Lock()
+	defer r.Unlock()
+	r.aiCalls += usage.Calls
+	r.aiTokens += usage.Tokens
+	r.aiSkipped += usage.Skipped
+}
+
+func (r *registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) { // Prometheus-Textformat 0.0.4.
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	fmt.Fprint(w, r.render(loadMetricsState()))
+}
+
+func loadMetricsState() State { // Circuit-Zustand + letzter Build aus state.json: auch feed serve (ohne eigene Abrufe) meldet kaputte Quellen.
+	paths, err := getPaths()
+	if err != nil {
+		return State{}
+	}
+	return loadState(paths.state)
+}
+
+func (r *registry) render(state State) string {
+	r.Lock()
+	defer r.Unlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var out strings.Builder
+	counter := func(metric, help string, value func(*providerMetrics) int) {
+		fmt.Fprintf(&out, "# HELP %s %s\n# TYPE %s counter\n", metric, help, metric)
+		for _, name := range names {
+			fmt.Fprintf(&out, "%s{provider=\"%s\"} %d\n", metric, labelValue(name), value(r.providers[name]))
+		}
+	}
+	counter("feed_fetches_total", "Provider fetches since start.", func(p *providerMetrics) int { return p.fetches })
+	counter("feed_fetch_failures_total", "Failed provider fetches since start.", func(p *providerMetrics) int { return p.failures })
+	counter("feed_new_entries_total", "Entries added per provider since start.", func(p *providerMetrics) int { return p.newEntries })
+
+	out.WriteString("# HELP feed_fetch_duration_seconds Duration of provider fetches.\n# TYPE feed_fetch_duration_seconds histogram\n")
+	for _, name := range names {
+		provider, label := r.providers[name], labelValue(name)
+		cumulative := 0
+		for i, bound := range latencyBuckets {
+			cumulative += provider.buckets[i]
+			fmt.Fprintf(&out, "feed_fetch_duration_seconds_bucket{provider=\"%s\",le=\"%g\"} %d\n", label, bound, cumulative)
+		}
+		fmt.Fprintf(&out, "feed_fetch_duration_seconds_bucket{provider=\"%s\",le=\"+Inf\"} %d\n", label, provider.fetches)
+		fmt.Fprintf(&out, "feed_fetch_duration_seconds_sum{provider=\"%s\"} %g\n", label, provider.sum)
+		fmt.Fprintf(&out, "feed_fetch_duration_seconds_count{provider=\"%s\"} %d\n", label, provider.fetches)
+	}
+
+	fmt.Fprintf(&out, "# HELP feed_ai_calls_total AI requests since start.\n# TYPE feed_ai_calls_total counter\nfeed_ai_calls_total %d\n", r.aiCalls)
+	fmt.Fprintf(&out, "# HELP feed_ai_tokens_total AI tokens used since start.\n# TYPE feed_ai_tokens_total counter\nfeed_ai_tokens_total %d\n", r.aiTokens)
+	fmt.Fprintf(&out, "# HELP feed_ai_skipped_total AI requests skipped because the budget was exhausted.\n# TYPE feed_ai_skipped_total counter\nfeed_ai_skipped_total %d\n", r.aiSkipped)
+
+	failing := make([]string, 0, len(state.Health))
+	for name := range state.Health {
+		failing = append(failing, name)
+	}
+	sort.Strings(failing)
+	out.WriteString("# HELP feed_provider_consecutive_failures Failed fetches in a row (state.json).\n# TYPE feed_provider_consecutive_failures gauge\n")
+	for _, name := range failing {
+		fmt.Fprintf(&out, "feed_provider_consecutive_failures{provider=\"%s\"} %d\n", labelValue(name), state.Health[name].Failures)
+	}
+	if lastBuild, err := parseTime(state.LastBuild); err == nil {
+		fmt.Fprintf(&out, "# HELP feed_last_build_timestamp_seconds Time of the last feed build.\n# TYPE feed_last_build_timestamp_seconds gauge\nfeed_last_build_timestamp_seconds %d\n", lastBuild.Unix())
+	}
+	return out.String()
+}
+
+func labelValue(value string) string { // Escaping laut Textformat: Backslash, Anführungszeichen, Zeilenumbruch.
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
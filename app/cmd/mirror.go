@@ -0,0 +1,104 @@
+package cmd // Paket "cmd": Mirror-Modus – synchronisiert alle Items eines externen Feeds (FEED_MIRROR_URL).
+
+import ( // Import-Block: Standardbibliothek + interne Pakete.
+	"errors"  // Kein brauchbarer Prompt.
+	"strings" // Trimmen.
+
+	"wapuugotchi/feed/app/ai"
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/feed"
+)
+
+func mirrorProvider() (feedProvider, bool) { // Mirror-Quelle aus ENV; aktiv, sobald FEED_MIRROR_URL gesetzt ist.
+	_ = env.LoadDotEnv()
+	url := env.ReadEnv("FEED_MIRROR_URL")
+	if url == "" {
+		return feedProvider{}, false
+	}
+	name := env.ReadEnv("FEED_MIRROR_NAME") // Eigener Name, damit IDs/Filter stabil und sprechend sind.
+	if name == "" {
+		name = "mirror"
+	}
+	return feedProvider{Name: name, FetchAll: feed.MirrorFeed(url), Mirror: true}, true
+}
+
+func syncAll(provider feedProvider, entries *[]Entry, known ...[]Entry) (bool, error) { // Fügt alle neuen Items hinzu, aktualisiert Titel und entfernt verschwundene.
+	items, err := provider.FetchAll(provider.fetch)
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	upstream := map[string]bool{}
+	for _, item := range items {
+		if strings.TrimSpace(item.Title) == "" {
+			continue
+		}
+		cleaned := item // Nur für ID und Titelvergleich; addItem bereinigt selbst (Kategorie-Mapping nicht doppelt anwenden).
+		cleaned.Title = cleanTitle(item.Title, provider.Settings.TitleSuffixes)
+		id := pickEntryID(provider.Name, cleaned)
+		upstream[id] = true
+		if index := entryIndex(*entries, id); index != -1 { // Bekannt: nur den Titel nachziehen (Content bleibt, um KI-Kosten zu sparen).
+			if (*entries)[index].Title != cleaned.Title {
+				(*entries)[index].Title = cleaned.Title
+				changed = true
+			}
+			continue
+		}
+		if addItem(provider, item, entries, known...) { // Neu: dieselbe Pipeline wie addLatest/addNew (Republish-/Dedupe-Check, Enrich, Übersetzung, Summary).
+			changed = true
+		}
+	}
+	if provider.Mirror && len(items) > 0 { // Upstream entfernt → hier auch entfernen (leere Antwort gilt als Störung, nicht als Löschung).
+		kept := (*entries)[:0]
+		for _, entry := range *entries {
+			if entry.Provider == provider.Name && !upstream[entry.ID] {
+				changed = true
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		*entries = kept
+	}
+	return changed, nil
+}
+
+func entryIndex(entries []Entry, id string) int { // Position eines Entries per ID; -1 wenn unbekannt.
+	for i, entry := range entries {
+		if entry.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func mirrorItem(provider feedProvider, item feed.Item) feed.Item { // Optional: KI-Prompt (z.B. Übersetzung) für neue Items; data/prompts/mirror.tmpl hat Vorrang vor FEED_MIRROR_PROMPT.
+	prompt := env.ReadEnv("FEED_MIRROR_PROMPT")
+	_, hasTemplate := lookupPrompt("mirror", provider)
+	if (prompt == "" && !hasTemplate) || item.Content == "" {
+		return item
+	}
+	transformed, err := transformMirrorItem(provider, prompt, item)
+	if err != nil {
+		return item
+	}
+	item.Content = transformed
+	if language := env.ReadEnv("FEED_MIRROR_LANGUAGE"); language != "" { // Prompt übersetzt in diese Sprache.
+		item.SourceLanguage = item.Language
+		if item.SourceLanguage == "" {
+			item.SourceLanguage = "und" // BCP 47: Originalsprache unbekannt.
+		}
+		item.Language = language
+	}
+	return item
+}
+
+func transformMirrorItem(provider feedProvider, prompt string, item feed.Item) (string, error) { // Template aus data/prompts/ (mirror.tmpl), sonst FEED_MIRROR_PROMPT (Platzhalter %s).
+	data := promptData{Title: item.Title, Link: item.Link, Source: provider.Name, Language: item.Language, Categories: item.Categories, Text: item.Content}
+	if rendered, ok := providerPrompt("mirror", provider, data, ""); ok {
+		return ai.TransformText("", rendered)
+	}
+	if prompt == "" { // Kaputtes Template ohne Ersatz: Item unverändert übernehmen.
+		return "", errors.New("no usable mirror prompt")
+	}
+	return ai.TransformText(prompt, item.Content)
+}
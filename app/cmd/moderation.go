@@ -0,0 +1,107 @@
+package cmd // Paket "cmd": Moderations-Queue – neue Entries landen erst in pending.json und brauchen eine Freigabe.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"     // Ausgabe.
+	"strconv" // Nummern aus der -pending Liste.
+	"strings" // Normalisieren von ENV-Werten.
+	"time"    // Embargo der freigegebenen Entries.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type moderationQueue struct { // Inhalt von data/pending.json.
+	Pending  []Entry  `json:"pending"`            // Wartende Entries (noch nicht im Feed).
+	Rejected []string `json:"rejected,omitempty"` // IDs abgelehnter Entries: werden nicht erneut eingesammelt.
+}
+
+func moderationEnabled() bool { // FEED_MODERATION=true/1/yes aktiviert die Queue.
+	_ = env.LoadDotEnv()
+	switch strings.ToLower(env.ReadEnv("FEED_MODERATION")) {
+	case "1", "true", "yes", "on":
+		return true
+	}
+	return false
+}
+
+func loadQueue(path string) moderationQueue { // Lädt pending.json; fehlt die Datei, ist die Queue leer.
+	queue := moderationQueue{Pending: []Entry{}}
+	readJSON(path, &queue)
+	return queue
+}
+
+func saveQueue(path string, queue moderationQueue) { // Speichert pending.json.
+	writeJSON(path, queue)
+}
+
+func (queue moderationQueue) rejected() []Entry { // Abgelehnte IDs als Entry-Liste (für die Dedupe-Prüfung).
+	entries := make([]Entry, 0, len(queue.Rejected))
+	for _, id := range queue.Rejected {
+		entries = append(entries, Entry{ID: id})
+	}
+	return entries
+}
+
+func (queue moderationQueue) find(ref string) int { // Findet einen Entry per Nummer (aus -pending) oder ID; -1 wenn unbekannt.
+	if number, err := strconv.Atoi(ref); err == nil && number >= 1 && number <= len(queue.Pending) {
+		return number - 1
+	}
+	for i, entry := range queue.Pending {
+		if entry.ID == ref {
+			return i
+		}
+	}
+	return -1
+}
+
+func RunListPending() error { // Zeigt alle wartenden Entries mit Nummer.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	queue := loadQueue(paths.pending)
+	for i, entry := range queue.Pending {
+		fmt.Printf("%d) [%s] %s (%s)\n", i+1, entry.Provider, entry.Title, entry.ID)
+	}
+	fmt.Printf("Total pending: %d\n", len(queue.Pending))
+	return nil
+}
+
+func RunApprove(ref string) error { // Übernimmt einen wartenden Entry in den Feed und baut/veröffentlicht neu.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	queue := loadQueue(paths.pending)
+	index := queue.find(ref)
+	if index == -1 {
+		return fmt.Errorf("no pending entry: %s", ref)
+	}
+	entry := queue.Pending[index]
+	queue.Pending = append(queue.Pending[:index], queue.Pending[index+1:]...)
+
+	entries := append(loadEntries(paths.entries), entry)
+	if err := finishUpdate(paths, loadSite(paths.site), entries, visibleEntries([]Entry{entry}, time.Now().UTC())); err != nil { // Mit laufendem Embargo erst ankündigen, wenn es abläuft (releasedSince).
+		return err
+	}
+	saveQueue(paths.pending, queue) // Erst nach erfolgreichem Build aus der Queue entfernen.
+	fmt.Printf("Entry '%s' approved\n", entry.Title)
+	return nil
+}
+
+func RunReject(ref string) error { // Verwirft einen wartenden Entry und merkt sich seine ID.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	queue := loadQueue(paths.pending)
+	index := queue.find(ref)
+	if index == -1 {
+		return fmt.Errorf("no pending entry: %s", ref)
+	}
+	entry := queue.Pending[index]
+	queue.Pending = append(queue.Pending[:index], queue.Pending[index+1:]...)
+	queue.Rejected = append(queue.Rejected, entry.ID)
+	saveQueue(paths.pending, queue)
+	fmt.Printf("Entry '%s' rejected\n", entry.Title)
+	return nil
+}
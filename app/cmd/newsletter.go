@@ -0,0 +1,180 @@
+package cmd // Paket "cmd": Newsletter-Befehl (HTML-Mail + Text-Alternative aus neuen Entries).
+
+import ( // Import-Block: Standardbibliothek + Mail-Paket.
+	"fmt"           // Statusausgabe + Betreff.
+	"html"          // Entities im Text-Teil dekodieren.
+	"html/template" // Responsives HTML-Template.
+	"os"            // Dateien schreiben.
+	"path/filepath" // Ausgabepfade.
+	"regexp"        // Tags für den Text-Teil entfernen.
+	"sort"          // Neueste zuerst.
+	"strings"       // Text-Building.
+	"time"          // Default-Zeitraum beim ersten Versand.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/mail"
+)
+
+type newsletterState struct { // Persistierter Versandstand (data/newsletter.json).
+	Sent       []string `json:"sent,omitempty"`         // IDs bereits verschickter Entries (wie Notified in state.json).
+	LastSentAt string   `json:"last_sent_at,omitempty"` // Untergrenze: alter Watermark bzw. Beginn des ersten Versands; wirkt, bis er aus dem Lookback fällt.
+}
+
+const newsletterLookback = 30 // Tage: so weit zurück kommen Entries für den Newsletter in Frage (z.B. nachträglich freigegebene).
+const maxNewsletterIDs = 1000 // So viele zuletzt verschickte IDs merken.
+
+var tagPattern = regexp.MustCompile(`(?s)<[^>]*>`) // Entfernt HTML-Tags für die Text-Alternative.
+
+var newsletterTemplate = template.Must(template.New("newsletter").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+  body { margin: 0; padding: 0; background: #f4f4f4; font-family: Georgia, "Times New Roman", serif; line-height: 1.6; color: #1e1e1e; }
+  .wrapper { width: 100%; background: #f4f4f4; padding: 24px 0; }
+  .container { max-width: 600px; margin: 0 auto; background: #ffffff; padding: 24px; }
+  h1 { font-size: 24px; margin: 0 0 16px; }
+  h2 { font-size: 20px; margin: 24px 0 8px; }
+  h2 a { color: #1e1e1e; }
+  img, iframe { max-width: 100%; height: auto; }
+  .footer { font-size: 12px; color: #757575; margin-top: 32px; }
+  @media (max-width: 620px) { .container { padding: 16px; } h1 { font-size: 20px; } }
+</style>
+</head>
+<body>
+<div class="wrapper"><div class="container">
+<h1>{{.Title}}</h1>
+{{range .Entries}}<h2><a href="{{.Link}}">{{.Title}}</a></h2>
+{{.Content}}
+{{end}}<p class="footer"><a href="{{.Link}}">{{.Link}}</a></p>
+</div></div>
+</body>
+</html>
+`))
+
+type newsletterEntry struct { // Entry in der Form, die das HTML-Template erwartet.
+	Title   string
+	Link    string
+	Content template.HTML // Content ist bereits HTML (aus dem Feed) und wird nicht erneut escaped.
+}
+
+func RunNewsletter() error { // Rendert newsletter.html/.txt und verschickt sie, falls Versand konfiguriert ist.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	site := loadSite(paths.site)
+	statePath := filepath.Join(filepath.Dir(paths.entries), "newsletter.json")
+	state := newsletterState{}
+	readJSON(statePath, &state)
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -newsletterLookback).Format(time.RFC3339)
+	if state.LastSentAt > since { // Alles bis zur Untergrenze gilt als verschickt (bzw. war vor dem ersten Versand).
+		since = state.LastSentAt
+	}
+	if len(state.Sent) == 0 && state.LastSentAt == "" { // Erster Versand: nur die letzte Woche, nicht das ganze Archiv.
+		since = now.AddDate(0, 0, -7).Format(time.RFC3339)
+	}
+	sent := map[string]bool{}
+	for _, id := range state.Sent {
+		sent[id] = true
+	}
+
+	entries := []Entry{}
+	for _, entry := range visibleEntries(loadEntries(paths.entries), now) { // Embargo: erst nach publish_at verschicken.
+		if !sent[entry.ID] && entry.CreatedAt > since { // IDs statt Watermark: auch Entries mit älterem CreatedAt (Moderation, Embargo) kommen mit; RFC3339-Strings sind lexikographisch = chronologisch sortierbar.
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 {
+		fmt.Println("no new entries since last newsletter")
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt > entries[j].CreatedAt })
+
+	htmlBody, textBody, err := renderNewsletter(site, entries)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(paths.root, "newsletter.html"), []byte(htmlBody), 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(paths.root, "newsletter.txt"), []byte(textBody), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("newsletter rendered: %d entries\n", len(entries))
+
+	recipients := splitComma(env.ReadEnv("NEWSLETTER_TO"))
+	if len(recipients) == 0 || !mail.Configured() { // Versand ist optional: ohne Empfänger/Transport nur rendern.
+		return nil
+	}
+	err = mail.Send(mail.Message{
+		From:    env.ReadEnv("NEWSLETTER_FROM"),
+		To:      recipients,
+		Subject: fmt.Sprintf("%s: %s", site.Title, entries[0].Title),
+		HTML:    htmlBody,
+		Text:    textBody,
+	})
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries { // Erst nach erfolgreichem Versand vermerken.
+		state.Sent = append(state.Sent, entry.ID)
+	}
+	if state.LastSentAt == "" { // Erster Versand: ältere Entries sollen auch später nicht nachrutschen.
+		state.LastSentAt = since
+	}
+	if len(state.Sent) > maxNewsletterIDs {
+		state.Sent = state.Sent[len(state.Sent)-maxNewsletterIDs:]
+	}
+	writeJSON(statePath, state)
+	fmt.Println("newsletter sent")
+	return nil
+}
+
+func renderNewsletter(site Site, entries []Entry) (string, string, error) { // Liefert HTML- und Text-Version.
+	data := struct {
+		Title   string
+		Link    string
+		Entries []newsletterEntry
+	}{Title: site.Title, Link: site.Link}
+	var text strings.Builder
+	text.WriteString(site.Title + "\n\n")
+	for _, entry := range entries {
+		data.Entries = append(data.Entries, newsletterEntry{Title: entry.Title, Link: entry.Link, Content: template.HTML(entry.Content)})
+		text.WriteString(entry.Title + "\n")
+		if plain := plainText(entry.Content); plain != "" {
+			text.WriteString(plain + "\n")
+		}
+		text.WriteString(entry.Link + "\n\n")
+	}
+	var out strings.Builder
+	if err := newsletterTemplate.Execute(&out, data); err != nil {
+		return "", "", err
+	}
+	return out.String(), strings.TrimSpace(text.String()) + "\n", nil
+}
+
+func plainText(content string) string { // HTML → lesbarer Text (Absätze als Zeilenumbrüche).
+	content = strings.NewReplacer("</p>", "\n", "<br>", "\n", "<br/>", "\n", "<br />", "\n", "</li>", "\n").Replace(content)
+	content = html.UnescapeString(tagPattern.ReplaceAllString(content, ""))
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func splitComma(value string) []string { // Kommagetrennte Liste → getrimmte, nicht-leere Werte.
+	result := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
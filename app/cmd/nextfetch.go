@@ -0,0 +1,71 @@
+package cmd // Paket "cmd": Abruf-Rhythmus pro Provider – nicht jede Quelle muss bei jedem Lauf geprüft werden (state.json "next_fetch_at").
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"          // Ausgabe.
+	"math/rand/v2" // Jitter.
+	"time"         // Intervalle.
+)
+
+const maxFetchTolerance = 5 * time.Minute // Höchstens so viel früher gilt eine Quelle schon als dran (Cron/Actions starten selten pünktlich).
+
+var pollInterval, pollJitter time.Duration // feed daemon: Intervall für Quellen ohne eigenes interval bzw. sy:updatePeriod und zufälliger Aufschlag; 0 = bei jedem Lauf abrufen.
+
+func dueProviders(list []feedProvider, next map[string]string, now time.Time, verbose bool) []feedProvider { // Nur Quellen ohne next_fetch_at bzw. mit erreichter Zeit; Einträge entfernter Quellen fallen weg.
+	known := map[string]bool{}
+	due := []feedProvider{}
+	for _, provider := range list {
+		known[provider.Name] = true
+		if at, err := parseTime(next[provider.Name]); err == nil && now.Before(at) {
+			if verbose {
+				fmt.Printf("%s: next fetch at %s\n", provider.Name, next[provider.Name])
+			}
+			continue
+		}
+		due = append(due, provider)
+	}
+	for name := range next {
+		if !known[name] {
+			delete(next, name)
+		}
+	}
+	return due
+}
+
+func fetchInterval(provider feedProvider) time.Duration { // interval aus den Einstellungen, sonst sy:updatePeriod des Feeds (nie kürzer als -interval), sonst -interval.
+	if provider.Settings.Interval != "" {
+		return parsePolicyDuration(provider.Name, "interval", provider.Settings.Interval, pollInterval)
+	}
+	return max(provider.updatePeriod, pollInterval)
+}
+
+func scheduleNext(next map[string]string, provider feedProvider, err error, now time.Time) { // Plant den nächsten Abruf nach einem erfolgreichen; nach Fehlern ist die Quelle beim nächsten Lauf wieder dran (Circuit Breaker begrenzt das).
+	interval := fetchInterval(provider)
+	if err != nil || interval <= 0 {
+		delete(next, provider.Name)
+		return
+	}
+	interval -= min(interval/10, maxFetchTolerance)
+	if pollJitter > 0 {
+		interval += rand.N(pollJitter)
+	}
+	next[provider.Name] = now.Add(interval).UTC().Format(time.RFC3339)
+}
+
+func saveNextFetch(path string, next map[string]string) { // Schreibt die Zeitpunkte in state.json (andere Felder bleiben erhalten); wirkt nur, wenn state.json den Lauf überlebt (feed daemon, festes data/, in Actions der Commit von data/ auch bei Exit-Code 3).
+	state := loadState(path)
+	if len(next) == 0 && len(state.NextFetch) == 0 {
+		return
+	}
+	state.NextFetch = next
+	saveState(path, state)
+}
+
+func nextWake(next map[string]string, now time.Time, limit time.Duration) time.Time { // feed daemon: sobald die erste Quelle dran ist, spätestens nach limit.
+	wake := now.Add(limit)
+	for _, value := range next {
+		if at, err := parseTime(value); err == nil && at.Before(wake) {
+			wake = at
+		}
+	}
+	return wake
+}
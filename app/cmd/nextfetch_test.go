@@ -0,0 +1,81 @@
+package cmd // Tests für next_fetch_at: fällige Quellen auswählen und den nächsten Abruf planen.
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDueProviders(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	list := []feedProvider{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}}
+	next := map[string]string{
+		"a":        "2024-05-01T13:00:00Z", // Noch nicht dran.
+		"b":        "2024-05-01T12:00:00Z", // Genau jetzt.
+		"c":        "kaputt",               // Unlesbar: abrufen.
+		"entfernt": "2024-05-02T00:00:00Z", // Quelle gibt es nicht mehr.
+	}
+	due := dueProviders(list, next, now, false)
+	names := []string{}
+	for _, provider := range due {
+		names = append(names, provider.Name)
+	}
+	if want := []string{"b", "c", "d"}; !slices.Equal(names, want) {
+		t.Errorf("due = %v, want %v", names, want)
+	}
+	if _, ok := next["entfernt"]; ok {
+		t.Error("next_fetch_at of a removed provider was kept")
+	}
+	if _, ok := next["a"]; !ok {
+		t.Error("next_fetch_at of a waiting provider was dropped")
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	defer func() { pollInterval, pollJitter = 0, 0 }()
+	tests := []struct {
+		name     string
+		provider feedProvider
+		poll     time.Duration
+		err      error
+		want     string // "" = kein Eintrag.
+	}{
+		{"ohne Intervall: jeder Lauf", feedProvider{Name: "p"}, 0, nil, ""},
+		{"Fehler: nächster Lauf", feedProvider{Name: "p", Settings: ProviderSettings{Interval: "6h"}}, 0, errors.New("boom"), ""},
+		{"interval aus den Einstellungen, 5m Toleranz", feedProvider{Name: "p", Settings: ProviderSettings{Interval: "6h"}}, 0, nil, "2024-05-01T17:55:00Z"},
+		{"kurzes Intervall: 10% Toleranz", feedProvider{Name: "p", Settings: ProviderSettings{Interval: "10m"}}, 0, nil, "2024-05-01T12:09:00Z"},
+		{"sy:updatePeriod", feedProvider{Name: "p", updatePeriod: 2 * time.Hour}, 0, nil, "2024-05-01T13:55:00Z"},
+		{"sy:updatePeriod nie kürzer als -interval", feedProvider{Name: "p", updatePeriod: time.Hour}, 3 * time.Hour, nil, "2024-05-01T14:55:00Z"},
+		{"nur -interval", feedProvider{Name: "p"}, 30 * time.Minute, nil, "2024-05-01T12:27:00Z"},
+		{"kaputtes interval: -interval", feedProvider{Name: "p", Settings: ProviderSettings{Interval: "bald"}}, 30 * time.Minute, nil, "2024-05-01T12:27:00Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pollInterval, pollJitter = test.poll, 0
+			next := map[string]string{"p": "2024-01-01T00:00:00Z"}
+			scheduleNext(next, test.provider, test.err, now)
+			if got := next["p"]; got != test.want {
+				t.Errorf("next_fetch_at = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScheduleNextJitter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pollInterval, pollJitter = time.Hour, 10*time.Minute
+	defer func() { pollInterval, pollJitter = 0, 0 }()
+	for i := 0; i < 50; i++ {
+		next := map[string]string{}
+		scheduleNext(next, feedProvider{Name: "p"}, nil, now)
+		at, err := parseTime(next["p"])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if offset := at.Sub(now); offset < 55*time.Minute || offset >= 65*time.Minute {
+			t.Fatalf("next fetch %s after now, want between 55m and 65m", offset)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package cmd // Paket "cmd": Anbindung der Notifier an den Update-Lauf.
+
+import ( // Import-Block: Standardbibliothek + Notify-Paket.
+	"fmt"  // Fehlerausgabe.
+	"os"   // Stderr.
+	"time" // Embargo der Wiederholungen.
+
+	"wapuugotchi/feed/app/notify"
+)
+
+const maxNotifiedIDs = 1000 // Pro Kanal so viele zuletzt angekündigte IDs merken.
+const maxNotifyAttempts = 5 // So oft wird eine gescheiterte Ankündigung versucht, dann aufgegeben (z.B. Kanal lehnt den Text dauerhaft ab).
+
+func notifyEntries(paths Paths, entries []Entry) { // Verteilt neue Entries an alle aktiven Kanäle (jeden Entry nur einmal pro Kanal); Fehler werden geloggt und im nächsten Lauf wiederholt.
+	state := loadState(paths.state)
+	if len(state.Unnotified) > 0 { // Gescheiterte Ankündigungen früherer Läufe mitnehmen (nur noch vorhandene, sichtbare Entries).
+		queued := map[string]bool{}
+		for _, entry := range entries {
+			queued[entry.ID] = true
+		}
+		for _, entry := range visibleEntries(loadEntries(paths.entries), time.Now().UTC()) {
+			if _, retry := state.Unnotified[entry.ID]; retry && !queued[entry.ID] {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	notices := make([]notify.Notice, 0, len(entries))
+	for _, entry := range entries {
+		image := entry.Image
+		if image == "" {
+			image = entry.Thumbnail // Z.B. Poster eines Videos.
+		}
+		notices = append(notices, notify.Notice{
+			ID:         entry.ID,
+			Title:      entry.Title,
+			Link:       entry.Link,
+			Content:    entry.Content,
+			Image:      image,
+			Categories: entry.Categories,
+			Provider:   entry.Provider,
+		})
+	}
+	if len(notices) == 0 && len(state.Unnotified) == 0 {
+		return
+	}
+	sent := notify.Sent(state.Notified)
+	if sent == nil {
+		sent = notify.Sent{}
+	}
+	failed, errs := notify.Dispatch(notices, sent)
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	for channel, ids := range sent {
+		if len(ids) > maxNotifiedIDs {
+			sent[channel] = ids[len(ids)-maxNotifiedIDs:]
+		}
+	}
+	unnotified := map[string]int{} // Nur, was in diesem Lauf wieder scheiterte; erfolgreiche und verschwundene IDs fallen heraus.
+	for _, id := range failed {
+		if attempts := state.Unnotified[id] + 1; attempts < maxNotifyAttempts {
+			unnotified[id] = attempts
+		} else {
+			fmt.Fprintf(os.Stderr, "notify %s: giving up after %d attempts\n", id, attempts)
+		}
+	}
+	state.Notified = sent
+	state.Unnotified = unnotified
+	saveState(paths.state, state)
+}
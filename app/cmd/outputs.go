@@ -0,0 +1,193 @@
+package cmd // Paket "cmd": zusätzliche, gefilterte Output-Feeds (data/feeds.json).
+
+import ( // Import-Block: Standardbibliothek.
+	"os"            // Ausgabeverzeichnis anlegen.
+	"path/filepath" // Output-Pfade relativ zum Projektroot.
+	"sort"          // Neueste zuerst vor dem Kürzen auf max_items.
+	"strings"       // Case-insensitive Vergleiche.
+	"time"          // Referenzzeit für Embargos.
+)
+
+type FeedConfig struct { // Ein abgeleiteter Feed: eigene Filter + eigener Ausgabepfad.
+	Name        string   `json:"name"`                  // Name für Logs (z.B. "videos-de").
+	Output      string   `json:"output"`                // Pfad relativ zum Projektroot, z.B. "feed.videos.de.xml".
+	Title       string   `json:"title,omitempty"`       // Optional: eigener Channel-Titel.
+	Description string   `json:"description,omitempty"` // Optional: eigene Channel-Beschreibung.
+	Providers   []string `json:"providers,omitempty"`   // Nur Entries dieser Quellen (leer = alle).
+	Categories  []string `json:"categories,omitempty"`  // Nur Entries mit mindestens einer dieser Kategorien (leer = alle).
+	MaxItems    int      `json:"max_items,omitempty"`   // Maximal so viele (neueste) Entries (0 = unbegrenzt).
+	Language    string   `json:"language,omitempty"`    // Nur Entries dieser Sprache (leer = alle).
+}
+
+func loadFeedConfigs(path string) []FeedConfig { // Lädt data/feeds.json; fehlt die Datei, gibt es nur den Haupt-Feed.
+	configs := []FeedConfig{}
+	readJSON(path, &configs)
+	return configs
+}
+
+func buildOutputs(paths Paths, site Site, entries []Entry) error { // Baut feed.xml und alle konfigurierten, gefilterten Feeds.
+	entries = visibleEntries(entries, time.Now().UTC()) // Embargo: noch nicht fällige Entries tauchen in keinem Output auf.
+	site = withIcons(paths, site)                       // Channel-Icons (konfiguriert oder automatisch gefunden).
+	settings := loadProviderSettings(paths.settings)    // Locale-gefilterte Provider erscheinen nur in Feeds ihrer Sprache.
+	if err := writeFeed(site, localeOnly(entries, settings), paths.feed); err != nil {
+		return err
+	}
+	for _, language := range feedLanguages(site) { // feed.de.xml, feed.fr.xml, …: übersetzt, wo schon möglich.
+		if err := writeFeed(languageSite(site, language), localizedEntries(site, localeOnly(entries, settings), language), languageFeedPath(paths.feed, language)); err != nil {
+			return err
+		}
+	}
+	for _, config := range loadFeedConfigs(paths.feeds) {
+		if strings.TrimSpace(config.Output) == "" { // Ohne Ziel kein Feed (Konfigurationsfehler, aber kein Abbruch).
+			continue
+		}
+		scoped := entries
+		if config.Language == "" { // Feeds ohne Sprachfilter verhalten sich wie der Haupt-Feed.
+			scoped = localeOnly(entries, settings)
+		}
+		if err := writeFeed(config.site(site), config.filter(site, scoped), config.path(paths)); err != nil {
+			return err
+		}
+	}
+	if err := checkSizeBudget(paths); err != nil { // Optional: zu schwere Feeds melden bzw. den Lauf scheitern lassen.
+		return err
+	}
+	if err := signOutputs(paths); err != nil { // Optional: detached Signaturen neben die fertigen Feeds legen.
+		return err
+	}
+	return writeHeaderSidecars(paths) // Optional: _headers/.htaccess mit Content-Type, Cache-Control und ETag.
+}
+
+func writeFeed(site Site, entries []Entry, path string) error { // Ein Feed in allen Formaten: RSS (path) + JSON Feed (gleicher Name, .json) + optional Atom.
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // -output/FEED_OUTPUT bzw. "output" in feeds.json darf auf ein noch leeres Verzeichnis zeigen.
+		return err
+	}
+	if err := buildFeed(site, entries, path); err != nil { // Sortiert entries; JSON Feed und Atom nutzen dieselbe Reihenfolge.
+		return err
+	}
+	if err := buildJSONFeed(site, entries, jsonFeedPath(path)); err != nil {
+		return err
+	}
+	if !atomEnabled() {
+		return nil
+	}
+	return buildAtomFeed(site, entries, atomFeedPath(path))
+}
+
+func outputFiles(paths Paths) []string { // Alle generierten Dateien (Feeds + Signaturen), z.B. für Publish.
+	return append(feedFiles(paths), signatureFiles(paths)...)
+}
+
+func feedFiles(paths Paths) []string { // Alle Feed-Dateien (Haupt-Feed + abgeleitete Feeds, jeweils RSS + JSON + ggf. Atom).
+	atom := atomEnabled()
+	files := []string{}
+	for _, output := range rssFiles(paths) {
+		files = append(files, output, jsonFeedPath(output))
+		if atom {
+			files = append(files, atomFeedPath(output))
+		}
+	}
+	return files
+}
+
+func rssFiles(paths Paths) []string { // Die RSS-Dateien: feed.xml, Sprach-Feeds und abgeleitete Feeds.
+	outputs := []string{paths.feed}
+	for _, language := range feedLanguages(loadSite(paths.site)) {
+		outputs = append(outputs, languageFeedPath(paths.feed, language))
+	}
+	for _, config := range loadFeedConfigs(paths.feeds) {
+		if strings.TrimSpace(config.Output) != "" {
+			outputs = append(outputs, config.path(paths))
+		}
+	}
+	return outputs
+}
+
+func (config FeedConfig) path(paths Paths) string { // Ausgabepfad relativ zum Projektroot.
+	return filepath.Join(paths.root, filepath.FromSlash(config.Output))
+}
+
+func (config FeedConfig) site(site Site) Site { // Channel-Metadaten: Basis-Site, ggf. übersetzt, mit optionalen Overrides.
+	if config.Language != "" {
+		site = site.localize(config.Language)
+	}
+	if config.Title != "" {
+		site.Title = config.Title
+	}
+	if config.Description != "" {
+		site.Description = config.Description
+	}
+	if config.Language != "" {
+		site.Language = config.Language
+	}
+	return site
+}
+
+func (config FeedConfig) filter(site Site, entries []Entry) []Entry { // Wendet alle Filter an und kürzt auf max_items.
+	result := []Entry{}
+	for _, entry := range entries {
+		if len(config.Providers) > 0 && !containsFold(config.Providers, entry.Provider) {
+			continue
+		}
+		if len(config.Categories) > 0 && !anyContainsFold(config.Categories, entry.Categories) {
+			continue
+		}
+		if config.Language != "" && !strings.EqualFold(entryLanguage(site, entry), config.Language) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	if config.MaxItems > 0 && len(result) > config.MaxItems {
+		sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt > result[j].CreatedAt })
+		result = result[:config.MaxItems]
+	}
+	return result
+}
+
+func (site Site) localize(language string) Site { // Übernimmt die Übersetzung für language (auch "de" für "de-DE"), falls vorhanden.
+	text, ok := site.Localized[language]
+	if !ok {
+		base, _, _ := strings.Cut(language, "-")
+		for key, candidate := range site.Localized {
+			if strings.EqualFold(key, language) || strings.EqualFold(key, base) {
+				text, ok = candidate, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return site
+	}
+	if text.Title != "" {
+		site.Title = text.Title
+	}
+	if text.Description != "" {
+		site.Description = text.Description
+	}
+	return site
+}
+
+func entryLanguage(site Site, entry Entry) string { // Sprache eines Entries; ohne Angabe gilt die Sprache der Site.
+	if entry.Language != "" {
+		return entry.Language
+	}
+	return site.Language
+}
+
+func containsFold(values []string, value string) bool { // Case-insensitive "enthält".
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyContainsFold(values, candidates []string) bool { // Mindestens ein Kandidat ist (case-insensitive) in values.
+	for _, candidate := range candidates {
+		if containsFold(values, candidate) {
+			return true
+		}
+	}
+	return false
+}
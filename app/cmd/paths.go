@@ -0,0 +1,56 @@
+package cmd // Paket "cmd": konfigurierbare Orte für data/ und feed.xml (Container, Cron: das Working Directory ist nicht das Repo).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"path/filepath" // Relative Pfade auflösen.
+	"strings"       // Datei oder Verzeichnis unterscheiden.
+
+	"wapuugotchi/feed/app/env"
+)
+
+var ( // Per CLI gesetzt (-data-dir, -output); haben Vorrang vor FEED_DATA_DIR/FEED_OUTPUT.
+	dataDirFlag string
+	outputFlag  string
+)
+
+func SetDataDir(dir string) { // CLI: Verzeichnis mit site.json, entries.json, state.json, … (Default: ./data).
+	dataDirFlag = dir
+}
+
+func SetOutput(path string) { // CLI: Ausgabe – Pfad zu feed.xml oder Verzeichnis, in dem feed.xml & Co. landen (Default: ./feed.xml).
+	outputFlag = path
+}
+
+func dataDirectory(root string) string { // -data-dir, sonst FEED_DATA_DIR, sonst root/data; relative Angaben gelten ab root.
+	dir := dataDirFlag
+	if dir == "" {
+		_ = env.LoadDotEnv()
+		dir = env.ReadEnv("FEED_DATA_DIR")
+	}
+	if dir == "" {
+		return filepath.Join(root, "data")
+	}
+	return absolute(root, dir)
+}
+
+func outputPaths(root string) (string, string) { // Liefert (Ausgabe-Root, feed.xml): -output bzw. FEED_OUTPUT ist eine .xml-Datei oder ein Verzeichnis.
+	output := outputFlag
+	if output == "" {
+		_ = env.LoadDotEnv()
+		output = env.ReadEnv("FEED_OUTPUT")
+	}
+	if output == "" {
+		return root, filepath.Join(root, "feed.xml")
+	}
+	output = absolute(root, output)
+	if strings.EqualFold(filepath.Ext(output), ".xml") { // Datei: weitere Outputs (feed.json, index.html, …) daneben.
+		return filepath.Dir(output), output
+	}
+	return output, filepath.Join(output, "feed.xml")
+}
+
+func absolute(root, path string) string { // Relative Pfade ab root (Working Directory).
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(root, path)
+}
@@ -0,0 +1,38 @@
+package cmd // Paket "cmd": Entries anpinnen/lösen (bleiben unabhängig vom Datum oben im Feed).
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"  // Ausgabe + Fehler.
+	"time" // Zeitlich begrenztes Anpinnen.
+)
+
+func isPinned(entry Entry, now time.Time) bool { // Dauerhaft angepinnt oder pinned_until liegt noch in der Zukunft.
+	if entry.Pinned {
+		return true
+	}
+	until, err := parseTime(entry.PinnedUntil)
+	return err == nil && now.Before(until)
+}
+
+func RunPin(id string, pinned bool) error { // Setzt/entfernt das Pinned-Flag und baut die Feeds neu.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	entries := loadEntries(paths.entries)
+	for i := range entries {
+		if entries[i].ID != id {
+			continue
+		}
+		entries[i].Pinned = pinned
+		if err := finishUpdate(paths, loadSite(paths.site), entries, nil); err != nil {
+			return err
+		}
+		if pinned {
+			fmt.Printf("Entry '%s' pinned\n", entries[i].Title)
+		} else {
+			fmt.Printf("Entry '%s' unpinned\n", entries[i].Title)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown entry: %s", id)
+}
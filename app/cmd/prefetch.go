@@ -0,0 +1,117 @@
+package cmd // Paket "cmd": paralleler Abruf aller Provider mit begrenzter Parallelität und Timeout pro Quelle.
+
+import ( // Import-Block: Standardbibliothek + interne Pakete.
+	"context" // Timeout pro Provider.
+	"errors"  // Timeout erkennen.
+	"fmt"     // Fehlertexte.
+	"os"      // Stderr.
+	"strconv" // FEED_FETCH_CONCURRENCY parsen.
+	"sync"    // WaitGroup.
+	"time"    // FEED_FETCH_TIMEOUT.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/feed"
+)
+
+const defaultFetchConcurrency = 4           // So viele Quellen gleichzeitig (schont Quellen + KI-Rate-Limits).
+const defaultFetchTimeout = 2 * time.Minute // Frist pro Quelle (inkl. Folge-Requests wie Videoseiten).
+
+type fetchResult struct { // Ergebnis eines Provider-Abrufs.
+	item  feed.Item     // Fetch: neuestes Item.
+	items []feed.Item   // FetchAll/FetchNew: alle (neuen) Items.
+	err   error         // Fehler des Abrufs.
+	took  time.Duration // Dauer des Abrufs.
+
+	period time.Duration // sy:updatePeriod des ersten Feeds, der eine Angabe enthält (0 = keine).
+}
+
+func fetchConcurrency() int { // FEED_FETCH_CONCURRENCY (1 = nacheinander wie früher), Default 4.
+	_ = env.LoadDotEnv()
+	value := env.ReadEnv("FEED_FETCH_CONCURRENCY")
+	if value == "" {
+		return defaultFetchConcurrency
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		fmt.Fprintf(os.Stderr, "invalid FEED_FETCH_CONCURRENCY %q, using %d\n", value, defaultFetchConcurrency)
+		return defaultFetchConcurrency
+	}
+	return limit
+}
+
+func fetchTimeout() time.Duration { // FEED_FETCH_TIMEOUT als Go-Dauer (z.B. "90s"), Default 2m.
+	_ = env.LoadDotEnv()
+	value := env.ReadEnv("FEED_FETCH_TIMEOUT")
+	if value == "" {
+		return defaultFetchTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		fmt.Fprintf(os.Stderr, "invalid FEED_FETCH_TIMEOUT %q, using %s\n", value, defaultFetchTimeout)
+		return defaultFetchTimeout
+	}
+	return timeout
+}
+
+func prefetch(ctx context.Context, list []feedProvider) []feedProvider { // Ruft alle Provider parallel ab; die Rückgabe liefert die Ergebnisse in list-Reihenfolge (ohne erneuten Abruf); ctx bricht alle Abrufe ab.
+	results := make([]fetchResult, len(list)) // Ein Slot pro Provider: keine Locks, Reihenfolge bleibt stabil.
+	slots := make(chan struct{}, fetchConcurrency())
+	timeout := fetchTimeout()
+	var wg sync.WaitGroup
+	for i, provider := range list {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := &results[i]
+			select {
+			case slots <- struct{}{}: // Auf einen freien Platz warten…
+			case <-ctx.Done(): // …außer der Lauf wurde abgebrochen.
+				result.err = fmt.Errorf("%s: %w", provider.Name, ctx.Err())
+				return
+			}
+			defer func() { <-slots }()
+			ctx, cancel := context.WithTimeout(ctx, timeout) // Frist gilt für alle HTTP-Requests dieser Quelle.
+			defer cancel()
+			provider.ctx = ctx
+			started := time.Now()
+			defer func() { result.took = time.Since(started) }()
+			fetch := func(url, source string) ([]byte, error) { // Merkt sich nebenbei den Rhythmus des Feeds (für next_fetch_at).
+				body, err := provider.fetch(url, source)
+				if period, ok := feed.UpdatePeriod(body); ok && result.period == 0 {
+					result.period = period
+				}
+				return body, err
+			}
+			switch {
+			case provider.FetchNew != nil:
+				result.items, result.err = provider.FetchNew(fetch, provider.since)
+			case provider.FetchAll != nil:
+				result.items, result.err = provider.FetchAll(fetch)
+			default:
+				result.item, result.err = provider.Fetch(fetch)
+			}
+			if result.err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				result.err = fmt.Errorf("%s: timeout after %s: %w", provider.Name, timeout, result.err)
+			}
+		}()
+	}
+	wg.Wait()
+	fetched := make([]feedProvider, len(list))
+	for i, provider := range list { // Fetcher durch die fertigen Ergebnisse ersetzen: addLatest/syncAll bleiben unverändert.
+		result := results[i]
+		switch {
+		case provider.FetchNew != nil:
+			provider.FetchNew = func(func(url, source string) ([]byte, error), time.Time) ([]feed.Item, error) {
+				return result.items, result.err
+			}
+		case provider.FetchAll != nil:
+			provider.FetchAll = func(func(url, source string) ([]byte, error)) ([]feed.Item, error) { return result.items, result.err }
+		default:
+			provider.Fetch = func(func(url, source string) ([]byte, error)) (feed.Item, error) { return result.item, result.err }
+		}
+		provider.elapsed = result.took
+		provider.updatePeriod = result.period
+		fetched[i] = provider
+	}
+	return fetched
+}
@@ -0,0 +1,106 @@
+package cmd // Paket "cmd": lokaler Vorschau-Server mit Live-Reload (-preview).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"bytes"         // Reload-Skript einfügen.
+	"fmt"           // Ausgabe + Fingerprint.
+	"net/http"      // Server.
+	"os"            // Dateien lesen + stat.
+	"path/filepath" // Pfade.
+	"strings"       // Content-Type + Pfade.
+	"sync/atomic"   // Build-Version.
+	"time"          // Polling-Intervall.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/publish"
+)
+
+const defaultPreviewAddr = "127.0.0.1:8080" // Nur lokal erreichbar.
+
+const reloadScript = `<script>(function(){var v=null;setInterval(function(){fetch("/__preview/version").then(function(r){return r.text()}).then(function(t){if(v!==null&&t!==v){location.reload()}v=t}).catch(function(){})},1000)})();</script>` // Lädt die Seite neu, sobald ein neuer Build fertig ist.
+
+func RunPreview() error { // Baut die Outputs, beobachtet data/ + .env und liefert alles lokal aus (FEED_PREVIEW_ADDR).
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	_ = env.LoadDotEnv()
+	addr := env.ReadEnv("FEED_PREVIEW_ADDR")
+	if addr == "" {
+		addr = defaultPreviewAddr
+	}
+	var version atomic.Int64
+	rebuild := func() {
+		if err := buildOutputs(paths, loadSite(paths.site), loadEntries(paths.entries)); err != nil { // Nur bauen: kein Fetch, kein Publish, keine Notifier.
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+		fmt.Printf("preview: rebuilt (build %d)\n", version.Add(1))
+	}
+	rebuild()
+	go func() { // Polling statt fsnotify: nur Stdlib, und data/ ist klein.
+		last := watchFingerprint(paths)
+		for range time.Tick(time.Second) {
+			if current := watchFingerprint(paths); current != last {
+				last = current
+				rebuild()
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/__preview/version", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprint(w, version.Load())
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/")
+		if name == "" {
+			name = "index.html"
+		}
+		file := filepath.Join(paths.root, filepath.FromSlash(name))
+		if !servable(paths, file) { // Nur veröffentlichte Artefakte, niemals .env & Co.
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		contentType := publish.ContentType(file)
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".xml":
+			contentType = "text/xml; charset=utf-8" // Browser zeigen text/xml an, statt es herunterzuladen.
+		case ".html":
+			if index := bytes.LastIndex(data, []byte("</body>")); index != -1 {
+				data = append(data[:index:index], append([]byte(reloadScript), data[index:]...)...)
+			}
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(data)
+	})
+	fmt.Printf("preview: serving on http://%s/\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+func servable(paths Paths, file string) bool { // Gehört die Datei zu den Artefakten (Feeds, Signaturen, Sidecars, index.html)?
+	for _, artifact := range artifactFiles(paths) {
+		if filepath.Clean(artifact) == filepath.Clean(file) {
+			return true
+		}
+	}
+	return false
+}
+
+func watchFingerprint(paths Paths) string { // Änderungszeit + Größe aller Daten-/Konfigurationsdateien und index.html.
+	files, _ := filepath.Glob(filepath.Join(filepath.Dir(paths.entries), "*.json"))
+	files = append(files, filepath.Join(paths.root, ".env"), filepath.Join(paths.root, "index.html"))
+	var fingerprint strings.Builder
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			fmt.Fprintf(&fingerprint, "%s:%d:%d;", file, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	return fingerprint.String()
+}
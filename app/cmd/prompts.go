@@ -0,0 +1,98 @@
+package cmd // Paket "cmd": KI-Prompts als Go-Templates aus data/prompts/ – global (summary.tmpl) oder pro Provider (<provider>/summary.tmpl).
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"           // Fehlerausgabe.
+	"os"            // Stderr.
+	"path/filepath" // Dateinamen ↔ Prompt-Namen.
+	"sort"          // Stabile Reihenfolge der Meldungen.
+	"strings"       // Trimmen, Platzhalter prüfen.
+	"text/template" // Prompt-Templates.
+)
+
+type promptData struct { // Variablen in den Templates, z.B. {{.Title}} oder {{join .Categories ", "}}.
+	Title      string   // Titel des Items.
+	Link       string   // Link des Items.
+	Source     string   // Provider-Name (z.B. "wordpress-releases").
+	Language   string   // Sprache des Items (leer = unbekannt).
+	Categories []string // Kategorien (bereinigt).
+	Text       string   // Der zu verarbeitende Text; fehlt {{.Text}} im Template, wird er angehängt.
+}
+
+var promptFuncs = template.FuncMap{"join": strings.Join} // Kategorien als Liste ausgeben.
+
+var promptTemplates = map[string]string{} // Aktive Templates: "summary" bzw. "<provider>/summary" → Text; RunFeedUpdate lädt data/prompts/.
+
+func loadPrompts(dir string) map[string]string { // Liest dir/*.tmpl und dir/<provider>/*.tmpl; fehlt das Verzeichnis, gelten die eingebauten Prompts.
+	prompts := map[string]string{}
+	for _, pattern := range []string{"*.tmpl", filepath.Join("*", "*.tmpl")} {
+		for _, file := range globData(filepath.Join(dir, pattern)) {
+			data, err := readData(file)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				continue
+			}
+			name, err := filepath.Rel(dir, file)
+			if err != nil {
+				continue
+			}
+			prompts[strings.TrimSuffix(filepath.ToSlash(name), ".tmpl")] = string(data)
+		}
+	}
+	return prompts
+}
+
+func lookupPrompt(name string, provider feedProvider) (string, bool) { // Override des Providers vor dem globalen Template.
+	if text, ok := promptTemplates[provider.Name+"/"+name]; ok {
+		return text, true
+	}
+	text, ok := promptTemplates[name]
+	return text, ok
+}
+
+func renderPrompt(name, text string, data promptData) (string, error) { // Fertiger Prompt; ohne {{.Text}} steht der Text nach einer Leerzeile am Ende.
+	tmpl, err := template.New(name).Funcs(promptFuncs).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	prompt := strings.TrimSpace(out.String())
+	if !strings.Contains(text, ".Text") {
+		prompt += "\n\n" + data.Text
+	}
+	return prompt, nil
+}
+
+func providerPrompt(name string, provider feedProvider, data promptData, fallback string) (string, bool) { // Prompt aus data/prompts/ (kaputtes Template: gemeldet, dann fallback); false = weder Template noch fallback.
+	if text, ok := lookupPrompt(name, provider); ok {
+		prompt, err := renderPrompt(name, text, data)
+		if err == nil {
+			return prompt, true
+		}
+		fmt.Fprintf(os.Stderr, "prompt %s (%s): %v\n", name, provider.Name, err)
+	}
+	if fallback == "" {
+		return "", false
+	}
+	prompt, err := renderPrompt(name, fallback, data)
+	return prompt, err == nil
+}
+
+func validatePrompts(dir string) []string { // validate: Templates müssen parsen und mit Beispieldaten laufen.
+	problems := []string{}
+	sample := promptData{Title: "Title", Link: "https://example.org/", Source: "example", Categories: []string{"news"}, Text: "Text"}
+	prompts := loadPrompts(dir)
+	names := make([]string, 0, len(prompts))
+	for name := range prompts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := renderPrompt(name, prompts[name], sample); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", filepath.Join(dir, filepath.FromSlash(name)+".tmpl"), err))
+		}
+	}
+	return problems
+}
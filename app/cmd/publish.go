@@ -0,0 +1,66 @@
+package cmd // Paket "cmd": CLI-Anbindung des Publish-Schritts.
+
+import ( // Import-Block: Standardbibliothek + Publish-Paket.
+	"fmt"           // Statusausgabe.
+	"os"            // Prüfen, welche Artefakte existieren.
+	"path/filepath" // Pfade der Artefakte.
+
+	"wapuugotchi/feed/app/publish"
+)
+
+func RunPublish() error { // Lädt die aktuellen Artefakte hoch, ohne vorher ein Update zu fahren.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	if !publish.Configured() { // Explizit aufgerufen, aber kein Ziel gesetzt → als Fehler melden.
+		return fmt.Errorf("no publish target configured: set PUBLISH_TARGET")
+	}
+	return publishArtifacts(paths)
+}
+
+func publishArtifacts(paths Paths) error { // Lädt feed.xml (+ index.html) hoch, falls PUBLISH_TARGET gesetzt ist.
+	if !publish.Configured() {
+		return nil // Publish ist optional; ohne Konfiguration passiert nichts.
+	}
+	if err := publish.Publish(paths.root, artifactFiles(paths)); err != nil {
+		return err
+	}
+	fmt.Println("published")
+	return nil
+}
+
+func RunArtifacts() error { // Gibt die vorhandenen Artefakte relativ zum Projektroot aus (eine Datei pro Zeile), z.B. für git add in CI.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	for _, file := range artifactFiles(paths) {
+		if _, err := os.Stat(file); err != nil { // Nicht gebaut (z.B. Signieren aus): nichts zu committen.
+			continue
+		}
+		rel, err := filepath.Rel(paths.root, file)
+		if err != nil {
+			return err
+		}
+		fmt.Println(filepath.ToSlash(rel))
+	}
+	return nil
+}
+
+func pingServices(site Site) { // Pingt IndexNow/weblogUpdates; Fehler werden nur geloggt.
+	for _, err := range publish.Ping(site.Title, site.Link) {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func artifactFiles(paths Paths) []string { // Liste aller generierten/statischen Dateien, die zum Hosting gehören.
+	files := append(outputFiles(paths), sidecarFiles(paths)...)
+	for _, name := range []string{"index.html"} { // Statische Begleitdateien nur, wenn sie existieren.
+		path := filepath.Join(paths.root, name)
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,18 @@
+package cmd // Paket "cmd": Wortanzahl und Lesezeit pro Entry ("3 min read" im WapuuGotchi-Client).
+
+import "strings" // Wörter zählen.
+
+const wapuugotchiNS = "https://github.com/codeispoetry/wapugotchi_feed/ns/1.0" // Namespace für eigene RSS-Elemente (wapuugotchi:wordCount, …).
+
+const wordsPerMinute = 200 // Übliche Lesegeschwindigkeit für Fließtext am Bildschirm.
+
+func wordCount(content string) int { // Wörter im Textinhalt (ohne HTML).
+	return len(strings.Fields(plainText(content)))
+}
+
+func readingTime(words int) int { // Geschätzte Lesezeit in Minuten (aufgerundet, mindestens 1); 0 ohne Text.
+	if words <= 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,89 @@
+package cmd // Paket "cmd": maschinenlesbare Zusammenfassung eines Update-Laufs (-report report.json), z.B. für Outputs und Kommentare in GitHub Actions.
+
+import ( // Import-Block: Standardbibliothek.
+	"encoding/json" // Report schreiben.
+	"fmt"           // Stderr.
+	"os"            // Stderr.
+	"time"          // Zeitpunkte + Dauer.
+
+	"wapuugotchi/feed/app/ai"
+)
+
+var reportPath string // Per CLI gesetzt (-report); leer = kein Report.
+
+func SetReport(path string) { // CLI: Pfad für den JSON-Report des Update-Laufs.
+	reportPath = path
+}
+
+type Report struct { // Ergebnis eines Update-Laufs.
+	StartedAt  string           `json:"started_at"`        // Beginn (RFC3339).
+	FinishedAt string           `json:"finished_at"`       // Ende (RFC3339).
+	DryRun     bool             `json:"dry_run,omitempty"` // -dry-run: nichts wurde geschrieben.
+	Updated    bool             `json:"updated"`           // Feed bzw. Queue hat sich geändert.
+	NewEntries []string         `json:"new_entries"`       // IDs aller neuen Entries (alle Provider).
+	Failed     []string         `json:"failed"`            // Provider mit Fehler.
+	Skipped    []string         `json:"skipped,omitempty"` // Provider mit offenem Circuit (zu viele Fehler in Folge), nicht abgerufen.
+	Error      string           `json:"error,omitempty"`   // Fehler, an dem der Lauf gescheitert ist.
+	Providers  []ProviderReport `json:"providers"`         // Pro Provider, in Abruf-Reihenfolge.
+	AI         *ai.Usage        `json:"ai,omitempty"`      // KI-Verbrauch (Aufrufe, Tokens, wegen Budget übersprungen); fehlt ohne KI-Aufrufe.
+}
+
+type ProviderReport struct { // Ergebnis eines Providers.
+	Name       string   `json:"name"`
+	Changed    bool     `json:"changed"`              // Mindestens ein Entry neu oder geändert.
+	NewEntries []string `json:"new_entries"`          // IDs der neuen Entries.
+	DurationMS int64    `json:"duration_ms"`          // Dauer des Abrufs.
+	Error      string   `json:"error,omitempty"`      // Fehler des Abrufs.
+	OpenUntil  string   `json:"open_until,omitempty"` // Übersprungen: Circuit offen bis (RFC3339).
+}
+
+func newReport(dryRun bool) *Report { // Beginn eines Laufs: auch das KI-Budget (AI_MAX_CALLS, AI_MAX_TOKENS) zählt ab hier.
+	ai.ResetUsage()
+	return &Report{StartedAt: time.Now().UTC().Format(time.RFC3339), DryRun: dryRun, NewEntries: []string{}, Failed: []string{}, Providers: []ProviderReport{}}
+}
+
+func (report *Report) provider(provider feedProvider, changed bool, added []string, err error) { // Ergebnis eines Providers anhängen.
+	result := ProviderReport{Name: provider.Name, Changed: changed, NewEntries: added, DurationMS: provider.elapsed.Milliseconds()}
+	if err != nil {
+		result.Error = err.Error()
+		report.Failed = append(report.Failed, provider.Name)
+	}
+	report.NewEntries = append(report.NewEntries, added...)
+	report.Providers = append(report.Providers, result)
+}
+
+func (report *Report) write(err error) { // Schreibt den Report (falls -report gesetzt), auch wenn der Lauf gescheitert ist.
+	if reportPath == "" {
+		return
+	}
+	report.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	if err != nil {
+		report.Error = err.Error()
+	}
+	if usage := ai.CurrentUsage(); usage != (ai.Usage{}) {
+		report.AI = &usage
+	}
+	data, marshalErr := json.MarshalIndent(report, "", "  ")
+	if marshalErr == nil {
+		marshalErr = writeFileAtomic(reportPath, append(data, '\n'), 0o644)
+	}
+	if marshalErr != nil {
+		fmt.Fprintf(os.Stderr, "report: %v\n", marshalErr)
+	}
+}
+
+func (report *Report) skipped(provider feedProvider, openUntil string) { // Übersprungenen Provider (Circuit offen) anhängen.
+	report.Skipped = append(report.Skipped, provider.Name)
+	report.Providers = append(report.Providers, ProviderReport{Name: provider.Name, NewEntries: []string{}, OpenUntil: openUntil})
+}
+
+func newEntryIDs(entries []Entry, seen map[string]bool) []string { // IDs, die noch nicht in seen sind (und merkt sie sich).
+	ids := []string{}
+	for _, entry := range entries {
+		if !seen[entry.ID] {
+			seen[entry.ID] = true
+			ids = append(ids, entry.ID)
+		}
+	}
+	return ids
+}
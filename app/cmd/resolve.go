@@ -0,0 +1,57 @@
+package cmd // Paket "cmd": relative URLs im Content (href, src, srcset, …) gegen den Link des Entries auflösen – im Feedreader gibt es keine Basis-URL.
+
+import ( // Import-Block: Standardbibliothek.
+	"html"    // Attributwerte dekodieren/escapen.
+	"net/url" // URLs auflösen.
+	"regexp"  // Attribute im (bereinigten) Tag finden.
+	"strings" // Trimmen + srcset zerlegen.
+)
+
+var linkAttributePattern = regexp.MustCompile(`\s(href|src|poster|cite|srcset)="([^"]*)"`) // Nach sanitizeHTML sind alle Werte in "…" und escaped.
+
+func resolveURLs(content, link string) string { // Löst relative URLs in Tags gegen link auf; ohne absoluten link bleibt content unverändert.
+	base, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || !absoluteURL(base.String()) {
+		return content
+	}
+	return htmlTokenPattern.ReplaceAllStringFunc(content, func(token string) string {
+		if !strings.HasPrefix(token, "<") || strings.HasPrefix(token, "</") { // Text (auch Code-Beispiele mit "src=") bleibt unangetastet.
+			return token
+		}
+		return linkAttributePattern.ReplaceAllStringFunc(token, func(attribute string) string {
+			match := linkAttributePattern.FindStringSubmatch(attribute)
+			value := html.UnescapeString(match[2])
+			if match[1] == "srcset" {
+				value = resolveSrcset(base, value)
+			} else {
+				value = resolveURL(base, value)
+			}
+			return " " + match[1] + `="` + html.EscapeString(value) + `"`
+		})
+	})
+}
+
+func resolveURL(base *url.URL, value string) string { // Absolute URLs, mailto: und reine Anker (#…) bleiben, wie sie sind.
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return value
+	}
+	ref, err := url.Parse(trimmed)
+	if err != nil || ref.IsAbs() {
+		return value
+	}
+	return base.ResolveReference(ref).String()
+}
+
+func resolveSrcset(base *url.URL, value string) string { // "a.jpg 1x, b.jpg 2x": nur die URL jedes Kandidaten auflösen.
+	candidates := strings.Split(value, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = resolveURL(base, fields[0])
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
@@ -0,0 +1,120 @@
+package cmd // Paket "cmd": Aufbewahrungsregel für entries.json (maximale Anzahl und/oder maximales Alter).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"     // Ausgabe.
+	"sort"    // Neueste zuerst.
+	"strconv" // FEED_RETENTION_* parsen.
+	"time"    // Altersgrenze.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const maxPrunedIDs = 1000 // So viele entfernte IDs merkt sich state.json, damit Quellen sie nicht wieder einspielen.
+
+type retentionPolicy struct { // Aufbewahrung; Nullwerte = keine Grenze.
+	maxEntries int           // FEED_RETENTION_MAX_ENTRIES: höchstens so viele Entries (die neuesten bleiben).
+	maxAge     time.Duration // FEED_RETENTION_MAX_DAYS: ältere Entries fallen raus.
+	archive    bool          // FEED_RETENTION_ARCHIVE=true: Entferntes nach data/archive/ verschieben statt löschen.
+}
+
+func loadRetention() retentionPolicy { // Liest die Aufbewahrungsregel aus ENV/.env.
+	_ = env.LoadDotEnv()
+	policy := retentionPolicy{archive: env.ReadEnv("FEED_RETENTION_ARCHIVE") == "true"}
+	if count, err := strconv.Atoi(env.ReadEnv("FEED_RETENTION_MAX_ENTRIES")); err == nil && count > 0 {
+		policy.maxEntries = count
+	}
+	if days, err := strconv.Atoi(env.ReadEnv("FEED_RETENTION_MAX_DAYS")); err == nil && days > 0 {
+		policy.maxAge = time.Duration(days) * 24 * time.Hour
+	}
+	return policy
+}
+
+func (policy retentionPolicy) apply(entries []Entry, now time.Time) (kept, pruned []Entry) { // Teilt entries in behaltene und entfernte; angepinnte und noch geplante Entries bleiben immer.
+	if policy.maxEntries == 0 && policy.maxAge == 0 {
+		return entries, nil
+	}
+	candidates := []int{}
+	keep := make([]bool, len(entries))
+	for i, entry := range entries {
+		if isPinned(entry, now) || !isVisible(entry, now) {
+			keep[i] = true
+			continue
+		}
+		candidates = append(candidates, i)
+	}
+	sort.SliceStable(candidates, func(a, b int) bool { return entries[candidates[a]].CreatedAt > entries[candidates[b]].CreatedAt }) // Neueste zuerst.
+	for rank, i := range candidates {
+		created, err := parseTime(entries[i].CreatedAt)
+		tooOld := policy.maxAge > 0 && err == nil && now.Sub(created) > policy.maxAge
+		tooMany := policy.maxEntries > 0 && rank >= policy.maxEntries
+		keep[i] = !tooOld && !tooMany
+	}
+	for i, entry := range entries { // Ursprüngliche Reihenfolge beibehalten (kleine Diffs in entries.json).
+		if keep[i] {
+			kept = append(kept, entry)
+		} else {
+			pruned = append(pruned, entry)
+		}
+	}
+	return kept, pruned
+}
+
+func pruneEntries(entries []Entry) (kept, pruned []Entry) { // Wendet die Aufbewahrungsregel an; gespeichert wird erst mit recordPruned.
+	return loadRetention().apply(entries, time.Now().UTC())
+}
+
+func recordPruned(paths Paths, state *State, pruned []Entry) { // Archiviert Entferntes (optional) und merkt die IDs in state; aufrufen, wenn die Feeds gebaut sind.
+	if len(pruned) == 0 {
+		return
+	}
+	if loadRetention().archive {
+		archiveEntries(paths.archive, pruned)
+	}
+	for _, entry := range pruned {
+		state.Pruned = append(state.Pruned, entry.ID)
+	}
+	if len(state.Pruned) > maxPrunedIDs {
+		state.Pruned = state.Pruned[len(state.Pruned)-maxPrunedIDs:]
+	}
+	fmt.Printf("pruned %d entries (retention)\n", len(pruned))
+}
+
+func archiveEntries(path string, entries []Entry) { // Hängt entries an das Archiv an (pro Jahr, ohne Dubletten).
+	archived := loadYearEntries(path)
+	for _, entry := range entries {
+		if !idExists(archived, entry.ID) {
+			archived = append(archived, entry)
+		}
+	}
+	saveYearEntries(path, archived)
+}
+
+func prunedEntries(path string) []Entry { // Entfernte IDs (Aufbewahrung + "remove") als Pseudo-Entries (für idKnown): Quellen sollen sie nicht erneut einspielen.
+	state := loadState(path)
+	ids := append(state.Pruned, state.Removed...)
+	entries := make([]Entry, 0, len(ids))
+	for _, id := range ids {
+		entries = append(entries, Entry{ID: id})
+	}
+	return entries
+}
+
+func RunPrune() error { // Wendet die Aufbewahrungsregel ohne Update-Lauf an und baut die Feeds neu.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	kept, pruned := pruneEntries(loadEntries(paths.entries))
+	if len(pruned) == 0 {
+		fmt.Println("nothing to prune")
+		return nil
+	}
+	if err := buildOutputs(paths, loadSite(paths.site), kept); err != nil {
+		return err
+	}
+	saveEntries(paths.entries, kept)
+	state := loadState(paths.state)
+	recordPruned(paths, &state, pruned)
+	saveState(paths.state, state)
+	return nil
+}
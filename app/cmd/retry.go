@@ -0,0 +1,95 @@
+package cmd // Paket "cmd": Timeout und Retry-Strategie für HTTP-Abrufe, pro Provider einstellbar (providers.json/sources.json).
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"          // Warnungen bei ungültigen Werten.
+	"math/rand/v2" // Jitter.
+	"net/http"     // Statuscodes + Retry-After.
+	"os"           // Stderr.
+	"strconv"      // Retry-After in Sekunden.
+	"strings"      // Backoff-Namen normalisieren.
+	"time"         // Dauern.
+)
+
+type fetchPolicy struct { // Wie lange ein Request dauern darf und wie oft/wann er wiederholt wird.
+	timeout  time.Duration // Frist pro Request (Default 15s).
+	attempts int           // Versuche insgesamt (Default 2 = ein Retry).
+	backoff  string        // "exponential" (Default, mit Jitter) oder "fixed".
+	delay    time.Duration // Basis-Wartezeit vor dem ersten Retry (Default 2s).
+	maxDelay time.Duration // Obergrenze pro Wartezeit, auch für Retry-After (Default 1m).
+	maxBody  int64         // Größte Response (entpackt) in Bytes (FEED_MAX_BODY_SIZE, Default 5 MB).
+}
+
+var defaultFetchPolicy = fetchPolicy{timeout: 15 * time.Second, attempts: 2, backoff: "exponential", delay: 2 * time.Second, maxDelay: time.Minute, maxBody: defaultMaxBodySize}
+
+func (provider feedProvider) fetchPolicy() fetchPolicy { // Werte aus den Einstellungen des Providers; ungültige Angaben → Default + Warnung.
+	settings, policy := provider.Settings, defaultFetchPolicy
+	policy.timeout = parsePolicyDuration(provider.Name, "timeout", settings.Timeout, policy.timeout)
+	policy.delay = parsePolicyDuration(provider.Name, "retry_delay", settings.RetryDelay, policy.delay)
+	policy.maxDelay = parsePolicyDuration(provider.Name, "max_retry_delay", settings.MaxRetryDelay, policy.maxDelay)
+	if settings.MaxAttempts > 0 {
+		policy.attempts = settings.MaxAttempts
+	}
+	policy.maxBody = maxBodySize()
+	if settings.MaxBodySize > 0 {
+		policy.maxBody = settings.MaxBodySize
+	}
+	switch backoff := strings.ToLower(strings.TrimSpace(settings.Backoff)); backoff {
+	case "":
+	case "exponential", "fixed":
+		policy.backoff = backoff
+	default:
+		fmt.Fprintf(os.Stderr, "%s: unknown backoff %q, using %s\n", provider.Name, settings.Backoff, policy.backoff)
+	}
+	return policy
+}
+
+func parsePolicyDuration(provider, field, value string, fallback time.Duration) time.Duration { // Go-Dauer wie "30s"; leer = fallback.
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		fmt.Fprintf(os.Stderr, "%s: invalid %s %q, using %s\n", provider, field, value, fallback)
+		return fallback
+	}
+	return duration
+}
+
+func retryableStatus(status int) bool { // Rate-Limit und vorübergehende Serverfehler; alles andere wird nicht wiederholt.
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (policy fetchPolicy) wait(attempt int, resp *http.Response) time.Duration { // Wartezeit vor Versuch attempt+1: Retry-After des Servers, sonst Backoff.
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, policy.maxDelay)
+	}
+	if policy.backoff == "fixed" {
+		return policy.delay
+	}
+	wait := policy.delay << attempt // delay, 2×delay, 4×delay, …
+	if wait <= 0 || wait > policy.maxDelay {
+		wait = policy.maxDelay
+	}
+	return wait/2 + rand.N(wait/2+1) // Jitter: zwischen halber und voller Wartezeit, damit parallele Läufe sich nicht synchronisieren.
+}
+
+func retryAfter(resp *http.Response) (time.Duration, bool) { // Retry-After als Sekunden oder HTTP-Datum.
+	if resp == nil {
+		return 0, false
+	}
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,147 @@
+package cmd // Paket "cmd": regelbasierter Spam-/Qualitätsfilter vor der Aufnahme (data/rules.json).
+
+import ( // Import-Block: Standardbibliothek + internes Feed-Paket.
+	"fmt"          // Log-Ausgabe für gefilterte Items.
+	"net/url"      // Host aus dem Link.
+	"os"           // Stderr für Konfigurationsfehler.
+	"regexp"       // Muster auf Titel/Content.
+	"strings"      // Case-insensitive Vergleiche.
+	"time"         // FetchNew-Signatur (Watermark).
+	"unicode/utf8" // Mindestlänge in Zeichen statt Bytes.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+type Rule struct { // Eine Regel: trifft eine Bedingung zu, wird das Item verworfen.
+	Name           string   `json:"name"`                      // Name für Logs (z.B. "crypto-spam").
+	Providers      []string `json:"providers,omitempty"`       // Nur für diese Quellen (leer = alle).
+	TitlePattern   string   `json:"title_pattern,omitempty"`   // Regex auf den Titel.
+	ContentPattern string   `json:"content_pattern,omitempty"` // Regex auf den Content (HTML).
+	MinLength      int      `json:"min_length,omitempty"`      // Mindestlänge des Textinhalts (ohne HTML) in Zeichen.
+	BannedDomains  []string `json:"banned_domains,omitempty"`  // Links auf diese Domains (inkl. Subdomains) werden verworfen.
+
+	title   *regexp.Regexp // Kompiliertes TitlePattern.
+	content *regexp.Regexp // Kompiliertes ContentPattern.
+}
+
+func loadRules(path string) []Rule { // Lädt data/rules.json; ungültige Regeln werden gemeldet und übersprungen.
+	rules := []Rule{}
+	readJSON(path, &rules)
+	valid := rules[:0]
+	for _, rule := range rules {
+		var err error
+		if rule.TitlePattern != "" {
+			if rule.title, err = regexp.Compile(rule.TitlePattern); err != nil {
+				fmt.Fprintf(os.Stderr, "rule %s: %v\n", rule.Name, err)
+				continue
+			}
+		}
+		if rule.ContentPattern != "" {
+			if rule.content, err = regexp.Compile(rule.ContentPattern); err != nil {
+				fmt.Fprintf(os.Stderr, "rule %s: %v\n", rule.Name, err)
+				continue
+			}
+		}
+		valid = append(valid, rule)
+	}
+	return valid
+}
+
+func (rule Rule) matches(provider string, item feed.Item) bool { // true = Item verstößt gegen diese Regel.
+	if len(rule.Providers) > 0 && !containsFold(rule.Providers, provider) {
+		return false
+	}
+	if rule.title != nil && rule.title.MatchString(item.Title) {
+		return true
+	}
+	if rule.content != nil && rule.content.MatchString(item.Content) {
+		return true
+	}
+	if rule.MinLength > 0 && utf8.RuneCountInString(plainText(item.Content)) < rule.MinLength {
+		return true
+	}
+	if len(rule.BannedDomains) > 0 {
+		if parsed, err := url.Parse(strings.TrimSpace(item.Link)); err == nil && bannedHost(parsed.Hostname(), rule.BannedDomains) {
+			return true
+		}
+	}
+	return false
+}
+
+func bannedHost(host string, domains []string) bool { // host ist eine der Domains oder eine Subdomain davon.
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.Trim(strings.TrimSpace(domain), "."))
+		if domain != "" && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return true
+		}
+	}
+	return false
+}
+
+func rejectedBy(rules []Rule, provider string, item feed.Item) string { // Name der ersten zutreffenden Regel ("" = Item ist ok).
+	for _, rule := range rules {
+		if rule.matches(provider, item) {
+			if rule.Name == "" {
+				return "unnamed rule"
+			}
+			return rule.Name
+		}
+	}
+	return ""
+}
+
+func applyRules(provider feedProvider, rules []Rule) feedProvider { // Hängt den Filter vor die Fetcher eines Providers.
+	if len(rules) == 0 {
+		return provider
+	}
+	return withItemFilter(provider, func(item feed.Item) bool {
+		if name := rejectedBy(rules, provider.Name, item); name != "" {
+			fmt.Printf("filtered %s: %q (%s)\n", provider.Name, item.Title, name)
+			return true
+		}
+		return false
+	})
+}
+
+func withItemFilter(provider feedProvider, skip func(item feed.Item) bool) feedProvider { // Verwirft Items, für die skip true liefert, schon beim Fetch.
+	if fetch := provider.Fetch; fetch != nil {
+		provider.Fetch = func(fetcher func(url, source string) ([]byte, error)) (feed.Item, error) {
+			item, err := fetch(fetcher)
+			if err != nil || strings.TrimSpace(item.Title) == "" || !skip(item) {
+				return item, err
+			}
+			return feed.Item{}, nil // Leeres Item: addLatest ignoriert es wie ein Item ohne Titel.
+		}
+	}
+	if fetchNew := provider.FetchNew; fetchNew != nil {
+		provider.FetchNew = func(fetcher func(url, source string) ([]byte, error), since time.Time) ([]feed.Item, error) {
+			items, err := fetchNew(fetcher, since)
+			if err != nil {
+				return items, err
+			}
+			for i, item := range items {
+				if strings.TrimSpace(item.Title) != "" && skip(item) {
+					items[i] = feed.Item{PubDate: item.PubDate} // Ohne Titel ignoriert, schiebt aber die Watermark weiter (kein erneuter Abruf).
+				}
+			}
+			return items, nil
+		}
+	}
+	if fetchAll := provider.FetchAll; fetchAll != nil {
+		provider.FetchAll = func(fetcher func(url, source string) ([]byte, error)) ([]feed.Item, error) {
+			items, err := fetchAll(fetcher)
+			if err != nil {
+				return items, err
+			}
+			kept := items[:0]
+			for _, item := range items {
+				if strings.TrimSpace(item.Title) == "" || !skip(item) {
+					kept = append(kept, item)
+				}
+			}
+			return kept, nil
+		}
+	}
+	return provider
+}
@@ -0,0 +1,189 @@
+package cmd // Paket "cmd": HTML-Content vor dem Speichern bereinigen (Allowlist für Tags/Attribute, keine Skripte, keine Tracking-Pixel).
+
+import ( // Import-Block: Standardbibliothek.
+	"html"    // Attributwerte dekodieren/escapen.
+	"regexp"  // Attribute parsen.
+	"strings" // Builder + Vergleiche.
+)
+
+var attributePattern = regexp.MustCompile(`([a-zA-Z_:][-a-zA-Z0-9_:.]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`) // name, name="…", name='…', name=…
+
+var allowedTags = map[string]bool{ // Alles, was ein Feedreader sicher darstellen kann.
+	"a": true, "abbr": true, "audio": true, "b": true, "blockquote": true, "br": true, "caption": true, "cite": true, "code": true,
+	"dd": true, "del": true, "details": true, "div": true, "dl": true, "dt": true, "em": true, "figcaption": true, "figure": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "hr": true, "i": true, "img": true, "ins": true,
+	"li": true, "mark": true, "ol": true, "p": true, "picture": true, "pre": true, "q": true, "s": true, "small": true,
+	"source": true, "span": true, "strong": true, "sub": true, "summary": true, "sup": true, "table": true, "tbody": true,
+	"td": true, "tfoot": true, "th": true, "thead": true, "time": true, "tr": true, "u": true, "ul": true, "video": true,
+}
+
+var droppedWithContent = map[string]bool{"script": true, "style": true, "template": true, "object": true, "iframe": true, "embed": true, "form": true, "svg": true, "math": true} // Nicht erlaubt: samt Inhalt entfernen (sonst bleibt z.B. Skript-Code als Text stehen).
+
+var allowedAttributes = map[string][]string{ // Pro Tag; "*" gilt für alle.
+	"*":          {"title", "lang", "dir"},
+	"a":          {"href"},
+	"img":        {"src", "srcset", "sizes", "alt", "width", "height", "loading"},
+	"source":     {"src", "srcset", "sizes", "type", "media"},
+	"video":      {"src", "poster", "controls", "width", "height", "preload"},
+	"audio":      {"src", "controls", "preload"},
+	"blockquote": {"cite"},
+	"q":          {"cite"},
+	"del":        {"cite", "datetime"},
+	"ins":        {"cite", "datetime"},
+	"time":       {"datetime"},
+	"td":         {"colspan", "rowspan"},
+	"th":         {"colspan", "rowspan", "scope"},
+	"ol":         {"start", "reversed", "type"},
+	"iframe":     {"src", "width", "height", "allow", "allowfullscreen", "frameborder", "title"}, // Nur mit Ausnahme pro Provider (allow_tags).
+}
+
+var urlAttributes = map[string]bool{"href": true, "src": true, "poster": true, "cite": true} // Nur http(s), mailto oder relativ.
+
+var trackingHosts = []string{"pixel.wp.com", "stats.wordpress.com", "feeds.feedburner.com/~r/", "feedproxy.google.com/~r/", "www.google-analytics.com"} // Bekannte Zählpixel in Feeds.
+
+func sanitizeHTML(content string, extraTags []string) string { // Entfernt alles außerhalb der Allowlist; extraTags erlaubt zusätzlich z.B. "iframe".
+	extra := map[string]bool{}
+	for _, tag := range extraTags {
+		extra[strings.ToLower(strings.TrimSpace(tag))] = true
+	}
+	var out strings.Builder
+	skip, depth := "", 0 // Innerhalb eines entfernten Elements (z.B. <script>): alles bis zum passenden Ende verwerfen.
+	for _, token := range htmlTokenPattern.FindAllString(content, -1) {
+		if !strings.HasPrefix(token, "<") {
+			if skip == "" {
+				out.WriteString(strings.ReplaceAll(token, ">", "&gt;"))
+			}
+			continue
+		}
+		match := tagNamePattern.FindStringSubmatch(token)
+		if match == nil { // Kommentare, Doctype, CDATA, kaputte Tags.
+			continue
+		}
+		name := strings.ToLower(match[1])
+		closing := strings.HasPrefix(token, "</")
+		if skip != "" {
+			if name == skip && closing {
+				depth--
+			} else if name == skip && !strings.HasSuffix(token, "/>") {
+				depth++
+			}
+			if depth == 0 {
+				skip = ""
+			}
+			continue
+		}
+		if !allowedTags[name] && !extra[name] {
+			if droppedWithContent[name] && !closing && !strings.HasSuffix(token, "/>") {
+				skip, depth = name, 1
+			}
+			continue // Sonst nur das Tag entfernen, Inhalt bleibt (z.B. <font>, <noscript>).
+		}
+		if closing {
+			if !voidElements[name] {
+				out.WriteString("</" + name + ">")
+			}
+			continue
+		}
+		tag, ok := sanitizeTag(name, token[len(match[0]):])
+		if ok {
+			out.WriteString(tag)
+		} else if !voidElements[name] && !strings.HasSuffix(token, "/>") { // Verworfenes iframe: samt Inhalt und End-Tag entfernen.
+			skip, depth = name, 1
+		}
+	}
+	return strings.TrimSpace(out.String())
+}
+
+func sanitizeTag(name, rest string) (string, bool) { // Baut ein öffnendes Tag nur aus erlaubten Attributen neu auf; false = Tag verwerfen (Tracking-Pixel, iframe ohne https).
+	allowed := map[string]bool{}
+	for _, attribute := range append(allowedAttributes["*"], allowedAttributes[name]...) {
+		allowed[attribute] = true
+	}
+	rest = strings.TrimSuffix(strings.TrimSuffix(rest, ">"), "/")
+	values := map[string]string{}
+	var b strings.Builder
+	b.WriteString("<" + name)
+	for _, match := range attributePattern.FindAllStringSubmatch(rest, -1) {
+		attribute := strings.ToLower(match[1])
+		if !allowed[attribute] {
+			continue // on*-Handler, style, class, data-*, …
+		}
+		value := html.UnescapeString(match[2] + match[3] + match[4])
+		if urlAttributes[attribute] && !safeURL(value) {
+			continue
+		}
+		if attribute == "srcset" && !safeSrcset(value) {
+			continue
+		}
+		values[attribute] = value
+		b.WriteString(" " + attribute)
+		if match[0] != match[1] { // Boolesche Attribute (controls, allowfullscreen) ohne Wert.
+			b.WriteString(`="` + html.EscapeString(value) + `"`)
+		}
+	}
+	switch name {
+	case "img":
+		if trackingPixel(values) {
+			return "", false
+		}
+	case "iframe":
+		if !strings.HasPrefix(strings.ToLower(values["src"]), "https://") {
+			return "", false
+		}
+	}
+	b.WriteString(">")
+	return b.String(), true
+}
+
+func safeURL(value string) bool { // Relativ, http(s) oder mailto; kein javascript:, data:, vbscript: …
+	value = strings.ToLower(strings.TrimSpace(value))
+	colon := strings.IndexByte(value, ':')
+	if colon == -1 || strings.ContainsAny(value[:colon], "/?#") { // Kein Schema.
+		return true
+	}
+	switch value[:colon] {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
+
+func safeSrcset(value string) bool { // Jede Kandidaten-URL muss sicher sein.
+	for _, candidate := range strings.Split(value, ",") {
+		if fields := strings.Fields(candidate); len(fields) > 0 && !safeURL(fields[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func trackingPixel(values map[string]string) bool { // 1×1-Bilder und bekannte Zählpixel-Hosts.
+	if strings.TrimSpace(values["src"]) == "" {
+		return true // Ohne Bildquelle ist ein <img> nutzlos.
+	}
+	width, height := strings.TrimSpace(values["width"]), strings.TrimSpace(values["height"])
+	if (width == "0" || width == "1") && (height == "0" || height == "1") {
+		return true
+	}
+	src := strings.ToLower(values["src"])
+	for _, host := range trackingHosts {
+		if strings.Contains(src, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func allowedExtraTags(provider feedProvider) []string { // Ausnahmen pro Provider (allow_tags); WordPress.tv braucht das Player-iframe.
+	settings := provider.Settings
+	tags := append([]string{}, settings.AllowTags...)
+	if provider.Name == "wordpress-tv" || settings.Type == "wordpress-tv" {
+		tags = append(tags, "iframe")
+	}
+	for _, name := range settings.Transformers {
+		if name == "wordpress-tv" {
+			tags = append(tags, "iframe")
+		}
+	}
+	return tags
+}
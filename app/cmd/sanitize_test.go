@@ -0,0 +1,36 @@
+package cmd // Tests für sanitizeHTML: Allowlist, entfernte Inhalte, Attribute und Tracking-Pixel.
+
+import "testing"
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		extra []string
+		want  string
+	}{
+		{"erlaubtes Markup bleibt", `<p>Hallo <strong>Welt</strong></p>`, nil, `<p>Hallo <strong>Welt</strong></p>`},
+		{"script samt Inhalt weg", `<p>a</p><script>alert(1)</script><p>b</p>`, nil, `<p>a</p><p>b</p>`},
+		{"verschachteltes iframe weg", `<iframe src="https://x"><iframe></iframe>text</iframe>ok`, nil, `ok`},
+		{"unbekanntes Tag, Inhalt bleibt", `<font color="red">rot</font>`, nil, `rot`},
+		{"Event-Handler und style weg", `<p onclick="x()" style="color:red" class="c">t</p>`, nil, `<p>t</p>`},
+		{"javascript-Link weg", `<a href="javascript:alert(1)">x</a>`, nil, `<a>x</a>`},
+		{"https-Link bleibt", `<a href="https://example.com/?a=1&amp;b=2">x</a>`, nil, `<a href="https://example.com/?a=1&amp;b=2">x</a>`},
+		{"relativer Link bleibt", `<a href="/pfad">x</a>`, nil, `<a href="/pfad">x</a>`},
+		{"Zählpixel 1x1 weg", `<img src="https://example.com/p.gif" width="1" height="1">`, nil, ``},
+		{"Zählpixel-Host weg", `<img src="https://pixel.wp.com/g.gif">`, nil, ``},
+		{"Bild bleibt", `<img src="https://example.com/a.jpg" alt="A" onerror="x()">`, nil, `<img src="https://example.com/a.jpg" alt="A">`},
+		{"unsicheres srcset weg", `<img src="a.jpg" srcset="javascript:x 1x">`, nil, `<img src="a.jpg">`},
+		{"iframe per extraTags", `<iframe src="https://video.example/embed"></iframe>`, []string{"iframe"}, `<iframe src="https://video.example/embed"></iframe>`},
+		{"iframe ohne https weg", `<iframe src="http://video.example/embed">x</iframe>ok`, []string{"iframe"}, `ok`},
+		{"Kommentar weg", `a<!-- b -->c`, nil, `ac`},
+		{"spitze Klammer im Text escaped", `a > b`, nil, `a &gt; b`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := sanitizeHTML(test.input, test.extra); got != test.want {
+				t.Errorf("sanitizeHTML(%q) = %q, want %q", test.input, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package cmd // Paket "cmd": geplante Veröffentlichung (Embargo) über Entry.PublishAt.
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"     // Ausgabe + Fehler.
+	"strings" // "id=zeit" parsen.
+	"time"    // Zeitvergleiche.
+)
+
+func isVisible(entry Entry, now time.Time) bool { // Ein Entry ohne publish_at ist sofort sichtbar, sonst erst ab diesem Zeitpunkt.
+	if strings.TrimSpace(entry.PublishAt) == "" {
+		return true
+	}
+	publishAt, err := parseTime(entry.PublishAt)
+	if err != nil { // Kaputter Zeitstempel: lieber zurückhalten als versehentlich zu früh veröffentlichen.
+		return false
+	}
+	return !publishAt.After(now)
+}
+
+func visibleEntries(entries []Entry, now time.Time) []Entry { // Filtert alle Entries heraus, deren Embargo noch läuft.
+	result := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if isVisible(entry, now) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func releasedSince(entries []Entry, lastBuild string, now time.Time) []Entry { // Entries, deren Embargo seit dem letzten Build abgelaufen ist.
+	result := []Entry{}
+	since, err := parseTime(lastBuild)
+	for _, entry := range entries {
+		if strings.TrimSpace(entry.PublishAt) == "" || !isVisible(entry, now) {
+			continue
+		}
+		publishAt, _ := parseTime(entry.PublishAt)
+		if err != nil || publishAt.After(since) { // Ohne bekannten letzten Build gilt jedes fällige Embargo als neu.
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func RunSchedule(spec string) error { // Setzt publish_at für einen Entry (Feed oder Queue): "id=2025-01-02T10:00:00Z", leere Zeit hebt auf.
+	id, at, found := strings.Cut(spec, "=")
+	id, at = strings.TrimSpace(id), strings.TrimSpace(at)
+	if !found || id == "" {
+		return fmt.Errorf("invalid schedule %q: use id=RFC3339", spec)
+	}
+	if at != "" {
+		parsed, err := parseTime(at)
+		if err != nil {
+			return err
+		}
+		at = parsed.UTC().Format(time.RFC3339)
+	}
+
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	queue := loadQueue(paths.pending)
+	if index := queue.find(id); index != -1 { // Wartende Entries: nur vormerken, sichtbar werden sie erst nach Freigabe.
+		queue.Pending[index].PublishAt = at
+		saveQueue(paths.pending, queue)
+		fmt.Printf("Entry '%s' scheduled\n", queue.Pending[index].Title)
+		return nil
+	}
+
+	entries := loadEntries(paths.entries)
+	for i := range entries {
+		if entries[i].ID != id {
+			continue
+		}
+		entries[i].PublishAt = at
+		if err := finishUpdate(paths, loadSite(paths.site), entries, nil); err != nil {
+			return err
+		}
+		fmt.Printf("Entry '%s' scheduled\n", entries[i].Title)
+		return nil
+	}
+	return fmt.Errorf("unknown entry: %s", id)
+}
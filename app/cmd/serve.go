@@ -0,0 +1,148 @@
+package cmd // Paket "cmd": HTTP-Server für die generierten Feeds + HTML-Vorschau der Entries (feed serve) – für Entwicklung und Self-Hosting.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper + Publish-Paket (Content-Types).
+	"bytes"         // Inhalt für ServeContent.
+	"crypto/md5"    // ETag aus dem Dateiinhalt.
+	"fmt"           // Ausgabe + Header.
+	"html/template" // Vorschau-Seite.
+	"net/http"      // Server.
+	"os"            // Dateien lesen.
+	"path/filepath" // Pfade.
+	"sort"          // Neueste zuerst.
+	"strings"       // Pfade.
+	"time"          // Zeitstempel.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/publish"
+)
+
+var serveTemplate = template.Must(template.New("serve").Parse(`<!doctype html>
+<html{{with .Language}} lang="{{.}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{range .Feeds}}<link rel="alternate" type="{{.Type}}" title="{{$.Title}}" href="/{{.Name}}">
+{{end}}<style>
+  body { max-width: 720px; margin: 0 auto; padding: 24px 16px; font-family: system-ui, sans-serif; line-height: 1.6; color: #1e1e1e; }
+  h2 { font-size: 20px; margin: 32px 0 4px; }
+  h2 a { color: #1e1e1e; }
+  .meta, .feeds { font-size: 14px; color: #757575; }
+  img, iframe, video { max-width: 100%; height: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>{{.Description}}</p>
+<p class="feeds">{{range .Feeds}}<a href="/{{.Name}}">{{.Name}}</a> {{end}}</p>
+{{range .Entries}}<article>
+<h2><a href="{{.Link}}">{{.Title}}</a></h2>
+<p class="meta">{{.Date}}{{if .Provider}} · {{.Provider}}{{end}}</p>
+{{.Content}}
+</article>
+{{end}}</body>
+</html>
+`))
+
+type serveEntry struct { // Entry in der Form, die die Vorschau erwartet.
+	Title    string
+	Link     string
+	Date     string
+	Provider string
+	Content  template.HTML // Vor der Ausgabe durch sanitizeHTML (ältere Entries stammen von vor dem Sanitizer).
+}
+
+type serveFeed struct { // Verlinkter Feed (Name relativ zum Root + Content-Type).
+	Name string
+	Type string
+}
+
+func RunServe(addr string) error { // Liefert feed.xml, feed.json & Co. mit Content-Type, Cache-Control und ETag aus; "/" zeigt die Entries als HTML.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	if addr == "" {
+		_ = env.LoadDotEnv()
+		addr = env.ReadEnv("FEED_SERVE_ADDR")
+	}
+	if addr == "" {
+		addr = defaultPreviewAddr
+	}
+	fmt.Printf("serve: http://%s/\n", addr)
+	return http.ListenAndServe(addr, serveMux(paths))
+}
+
+func serveMux(paths Paths) *http.ServeMux { // Routen von feed serve (auch für feed daemon -addr): Vorschau, Artefakte, /metrics, /healthz.
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	mux.Handle("/healthz", serveHealth(paths))
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		page, err := renderServePage(paths)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Cache-Control", "no-cache") // Entries ändern sich mit jedem Lauf: immer revalidieren.
+		serveBytes(w, r, "index.html", time.Now(), page)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		file := filepath.Join(paths.root, filepath.FromSlash(strings.TrimPrefix(r.URL.Path, "/")))
+		if !servable(paths, file) { // Nur veröffentlichte Artefakte, niemals data/ oder .env.
+			http.NotFound(w, r)
+			return
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", cacheMaxAge())) // Wie die Header-Sidecars (FEED_CACHE_MAX_AGE).
+		serveBytes(w, r, file, info.ModTime(), data)
+	})
+	return mux
+}
+
+func serveBytes(w http.ResponseWriter, r *http.Request, name string, modified time.Time, data []byte) { // Content-Type + ETag; ServeContent beantwortet If-None-Match/If-Modified-Since mit 304.
+	w.Header().Set("Content-Type", publish.ContentType(name))
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, md5.Sum(data)))
+	http.ServeContent(w, r, name, modified, bytes.NewReader(data))
+}
+
+func renderServePage(paths Paths) ([]byte, error) { // HTML-Vorschau: sichtbare Entries, neueste zuerst.
+	site := loadSite(paths.site)
+	entries := visibleEntries(loadEntries(paths.entries), time.Now().UTC())
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].CreatedAt > entries[j].CreatedAt })
+	data := struct {
+		Title       string
+		Description string
+		Language    string
+		Feeds       []serveFeed
+		Entries     []serveEntry
+	}{Title: site.Title, Description: site.Description, Language: site.Language}
+	for _, file := range feedFiles(paths) {
+		if _, err := os.Stat(file); err != nil {
+			continue
+		}
+		if rel, err := filepath.Rel(paths.root, file); err == nil {
+			data.Feeds = append(data.Feeds, serveFeed{Name: filepath.ToSlash(rel), Type: strings.Split(publish.ContentType(file), ";")[0]})
+		}
+	}
+	for _, entry := range entries {
+		date := entry.CreatedAt
+		if parsed, err := parseTime(entry.CreatedAt); err == nil {
+			date = parsed.Format("2006-01-02 15:04")
+		}
+		data.Entries = append(data.Entries, serveEntry{Title: entry.Title, Link: entry.Link, Date: date, Provider: entry.Provider, Content: template.HTML(sanitizeHTML(entry.Content, nil))})
+	}
+	var out bytes.Buffer
+	if err := serveTemplate.Execute(&out, data); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
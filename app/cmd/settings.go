@@ -0,0 +1,168 @@
+package cmd // Paket "cmd": Einstellungen pro Provider (data/providers.json).
+
+import ( // Import-Block: Standardbibliothek + interne Pakete.
+	"fmt"     // Stderr-Ausgabe bei Konfigurationsfehlern.
+	"os"      // Stderr.
+	"sort"    // Stabile Reihenfolge konfigurierter Quellen.
+	"strings" // Transformer-Namen trimmen/auflisten.
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/feed"
+)
+
+type ProviderSettings struct { // Optionale Feineinstellungen einer Quelle; Schlüssel in providers.json ist der Provider-Name, in sources.json das Feld "name".
+	Name               string   `json:"name,omitempty"`                 // sources.json: Name der Quelle (geht in die Entry-IDs ein, daher stabil halten).
+	Disabled           bool     `json:"disabled,omitempty"`             // Quelle abschalten (auch eingebaute Standardquellen wie "wordpress-releases").
+	Translate          bool     `json:"translate,omitempty"`            // Neue Items per KI in die Zielsprache übersetzen.
+	Summarize          bool     `json:"summarize,omitempty"`            // Neue Items per KI zusammenfassen (<description>); global: FEED_AI_SUMMARY=true.
+	TranslateTo        string   `json:"translate_to,omitempty"`         // Zielsprache der Übersetzung (Default FEED_LANGUAGE, sonst "en").
+	Enabled            bool     `json:"enabled,omitempty"`              // Optionale eingebaute Quelle (z.B. "five-for-the-future") einschalten.
+	Type               string   `json:"type,omitempty"`                 // Zusätzliche Quelle: "wp-rest" (WordPress REST API), "github-releases", "wp-plugin", "wp-events", "trac-milestone", "rss" (beliebiger RSS- oder Atom-Feed) oder ein eingebauter Parser ("wordpress-releases", "wordpress-tv", "wordpress-com"); leer = nur Einstellungen für einen eingebauten Provider.
+	URL                string   `json:"url,omitempty"`                  // Basis-URL der zusätzlichen Quelle (z.B. "https://wordpress.org/news").
+	Repos              []string `json:"repos,omitempty"`                // github-releases: Repos als "owner/name".
+	Plugin             string   `json:"plugin,omitempty"`               // wp-plugin: Slug im Plugin-Verzeichnis von WordPress.org (Default "wapuugotchi").
+	MaxItems           int      `json:"max_items,omitempty"`            // github-releases/wp-events: so viele Items pro Repo bzw. Ort prüfen (Default 10).
+	Teams              []string `json:"teams,omitempty"`                // make: Teams auf make.wordpress.org (Default core, design, community); je Team ein Provider "make-<team>".
+	Locations          []string `json:"locations,omitempty"`            // wp-events: Orte, für die kommende Events gesucht werden (z.B. "Berlin").
+	Milestone          string   `json:"milestone,omitempty"`            // trac-milestone: Milestone, dessen gefixte Tickets wöchentlich zusammengefasst werden (z.B. "6.6").
+	Locales            []string `json:"locales,omitempty"`              // Locale-Filter (z.B. polyglots): nur passende Items, und nur in den Feeds dieser Sprache.
+	TitleSuffixes      []string `json:"title_suffixes,omitempty"`       // Zusätzliche Site-Namen, die am Titelende entfernt werden (z.B. "Make WordPress Core").
+	Keywords           []string `json:"keywords,omitempty"`             // Nur Items, die mit einem dieser Schlagwörter getaggt sind (Kategorie, case-insensitive).
+	StableOnly         bool     `json:"stable_only,omitempty"`          // Release-Provider: Betas/RCs/Nightlies überspringen.
+	Versions           string   `json:"versions,omitempty"`             // Release-Provider: "major" (nur X.0.0), "minor" (nur X.Y.0), leer = alle.
+	MaxContentLength   int      `json:"max_content_length,omitempty"`   // Content wird beim Speichern auf etwa so viele Zeichen HTML gekürzt (0 = unbegrenzt).
+	ReadMore           string   `json:"read_more,omitempty"`            // Linktext unter gekürztem Content (Default "Read more").
+	Attribution        string   `json:"attribution,omitempty"`          // Template für eine Quellenzeile unter dem Content, z.B. "Originally published on {{.Host}}".
+	UserAgent          string   `json:"user_agent,omitempty"`           // Eigener User-Agent für diese Quelle; "browser" = Browser-UA für Server, die Bots blocken.
+	InsecureSkipVerify bool     `json:"insecure_skip_verify,omitempty"` // Notausgang: TLS-Zertifikat dieser Quelle nicht prüfen (nur Staging/interne CAs).
+	Transformers       []string `json:"transformers,omitempty"`         // rss: Nachbearbeitung des neuesten Items, z.B. ["summary-only"] oder ["wordpress-tv"] (siehe feed.TransformerNames).
+	AllowTags          []string `json:"allow_tags,omitempty"`           // Zusätzlich erlaubte HTML-Tags im Content, z.B. ["iframe"] (nur https-Quellen); sonst gilt die Allowlist in sanitize.go.
+	Timeout            string   `json:"timeout,omitempty"`              // Frist pro HTTP-Request als Go-Dauer (z.B. "30s"; Default 15s).
+	MaxAttempts        int      `json:"max_attempts,omitempty"`         // Versuche pro Request bei 429/502/503/504 (Default 2; 1 = kein Retry).
+	Backoff            string   `json:"backoff,omitempty"`              // Wartezeit zwischen Versuchen: "exponential" (Default, mit Jitter) oder "fixed"; Retry-After hat Vorrang.
+	RetryDelay         string   `json:"retry_delay,omitempty"`          // Basis-Wartezeit vor dem ersten Retry (Default 2s).
+	MaxRetryDelay      string   `json:"max_retry_delay,omitempty"`      // Obergrenze pro Wartezeit, auch für Retry-After (Default 1m).
+
+	Filter *ItemFilter `json:"filter,omitempty"` // Include/Exclude-Regeln für Titel, Pflicht-Kategorien und Mindestlänge (siehe filters.go).
+
+	Headers map[string]string `json:"headers,omitempty"` // Zusätzliche HTTP-Header; "${NAME}" im Wert wird aus ENV ersetzt (Secrets nie in providers.json).
+	Auth    *SourceAuth       `json:"auth,omitempty"`    // Basic- oder Bearer-Auth mit Zugangsdaten aus ENV (siehe useragent.go).
+
+	MaxBodySize int64 `json:"max_body_size,omitempty"` // Größte Response (entpackt) in Bytes; Default FEED_MAX_BODY_SIZE bzw. 5 MB.
+	Enrich      bool  `json:"enrich,omitempty"`        // Items ohne Inhalt: Beschreibung + Bild aus Open Graph/Meta-Tags der Zielseite (Cache: data/enrich.json).
+
+	Interval string `json:"interval,omitempty"` // Mindestabstand zwischen zwei Abrufen dieser Quelle (Go-Dauer, z.B. "6h"); Default: sy:updatePeriod des Feeds bzw. -interval von feed daemon.
+}
+
+func loadProviderSettings(path string) map[string]ProviderSettings { // Lädt data/providers.json + data/sources.json; fehlen beide, gelten die Defaults.
+	settings := map[string]ProviderSettings{}
+	readJSON(path, &settings)
+	loadSources(path, settings) // sources.json hat Vorrang (gleicher Name).
+	return settings
+}
+
+func optionalProviders(settings map[string]ProviderSettings) []feedProvider { // Eingebaute Quellen, die erst mit "enabled": true laufen.
+	list := []feedProvider{}
+	for _, provider := range []feedProvider{ // Reihenfolge ist die Abfrage-Reihenfolge.
+		{Name: "five-for-the-future", FetchNew: feed.LatestFiveForTheFuture},
+		{Name: "wp-tavern", FetchNew: feed.LatestWPTavern, Dedupe: true}, // Spiegelt oft wordpress.org-Ankündigungen.
+		{Name: "polyglots", FetchNew: feed.LatestPolyglots(settings["polyglots"].Locales)},
+		{Name: "do-action", Fetch: feed.LatestDoAction},
+		{Name: "pattern-directory", Fetch: feed.LatestPattern},
+		{Name: "buddypress", FetchNew: feed.LatestBuddyPressRelease},
+		{Name: "bbpress", FetchNew: feed.LatestBBPressRelease},
+		{Name: "ma-tt", FetchAll: feed.MattPosts}, // Persönlicher Blog: nur über den Keyword-Filter (Default "WordPress").
+	} {
+		if settings[provider.Name].Enabled {
+			list = append(list, provider)
+		}
+	}
+	return append(list, makeProviders(settings["make"])...)
+}
+
+var defaultMakeTeams = []string{"core", "design", "community"} // Die wichtigsten Kanäle für Neuigkeiten aus dem WordPress-Projekt.
+
+func makeProviders(setting ProviderSettings) []feedProvider { // "make": {"enabled": true, "teams": […]} – ein Provider pro Team, jeder mit eigener Watermark (und eigenen Einstellungen unter "make-<team>").
+	if !setting.Enabled {
+		return nil
+	}
+	teams := setting.Teams
+	if len(teams) == 0 {
+		teams = defaultMakeTeams
+	}
+	list := []feedProvider{}
+	for _, team := range teams {
+		if team = strings.ToLower(strings.Trim(strings.TrimSpace(team), "/")); team != "" {
+			list = append(list, feedProvider{Name: "make-" + team, FetchNew: feed.LatestMakeBlog(team)})
+		}
+	}
+	return list
+}
+
+func localeOnly(entries []Entry, settings map[string]ProviderSettings) []Entry { // Entfernt Entries locale-gefilterter Provider (nur für Feeds ohne Sprachfilter).
+	result := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if len(settings[entry.Provider].Locales) == 0 {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func configuredProviders(settings map[string]ProviderSettings) []feedProvider { // Zusätzliche Quellen, die nur in providers.json definiert sind.
+	names := make([]string, 0, len(settings))
+	for name, setting := range settings {
+		if setting.Type != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names) // Map-Reihenfolge ist zufällig; Abfrage-Reihenfolge soll stabil sein.
+	list := []feedProvider{}
+	for _, name := range names {
+		setting := settings[name]
+		switch setting.Type {
+		case "rss":
+			list = append(list, feedProvider{Name: name, FetchNew: feed.LatestRSS(setting.URL, transformers(name, setting.Transformers)...)})
+		case "wordpress-releases", "wordpress-tv", "wordpress-com":
+			list = append(list, feedProvider{Name: name, FetchNew: builtinFetcher(setting.Type)})
+		case "wp-rest":
+			list = append(list, feedProvider{Name: name, Fetch: feed.LatestWordPressREST(setting.URL)})
+		case "wp-events":
+			list = append(list, feedProvider{Name: name, FetchAll: feed.UpcomingEvents(setting.Locations, setting.MaxItems)})
+		case "trac-milestone":
+			list = append(list, feedProvider{Name: name, Fetch: feed.TracMilestoneDigest(setting.URL, setting.Milestone)})
+		case "wp-plugin":
+			list = append(list, feedProvider{Name: name, Fetch: feed.LatestPluginVersion(setting.Plugin)})
+		case "github-releases":
+			list = append(list, feedProvider{Name: name, FetchAll: feed.GitHubReleases(setting.Repos, setting.MaxItems), Headers: githubHeaders()})
+		default:
+			fmt.Fprintf(os.Stderr, "provider %s: unknown type %q\n", name, setting.Type)
+		}
+	}
+	return list
+}
+
+func transformers(provider string, names []string) []feed.Transformer { // Löst Transformer-Namen auf; unbekannte werden gemeldet und übersprungen.
+	list := []feed.Transformer{}
+	for _, name := range names {
+		transformer, ok := feed.LookupTransformer(strings.TrimSpace(name))
+		if !ok {
+			fmt.Fprintf(os.Stderr, "provider %s: unknown transformer %q (available: %s)\n", provider, name, strings.Join(feed.TransformerNames(), ", "))
+			continue
+		}
+		list = append(list, transformer)
+	}
+	return list
+}
+
+func githubHeaders() map[string]string { // API-Header für GitHub; GITHUB_TOKEN hebt das Rate-Limit (60 → 5000 Requests/h).
+	headers := map[string]string{
+		"Accept":               "application/vnd.github.full+json", // Liefert body_html (Markdown serverseitig gerendert).
+		"X-GitHub-Api-Version": "2022-11-28",
+	}
+	_ = env.LoadDotEnv()
+	if token := env.ReadEnv("GITHUB_TOKEN", "GH_TOKEN"); token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return headers
+}
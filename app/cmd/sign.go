@@ -0,0 +1,50 @@
+package cmd // Paket "cmd": Signaturdateien (.minisig) für alle generierten Feeds.
+
+import ( // Import-Block: Standardbibliothek + Sign-Paket.
+	"fmt"           // Ausgabe der Schlüssel.
+	"os"            // Dateien lesen/schreiben.
+	"path/filepath" // Dateiname für den trusted comment.
+
+	"wapuugotchi/feed/app/sign"
+)
+
+func signOutputs(paths Paths) error { // Schreibt <feed>.minisig neben jede Feed-Datei (nur mit FEED_SIGNING_KEY).
+	if !sign.Enabled() {
+		return nil
+	}
+	for _, file := range feedFiles(paths) {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		signature, err := sign.Sign(data, filepath.Base(file))
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(file+".minisig", []byte(signature), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func signatureFiles(paths Paths) []string { // Alle Signaturdateien (für Publish), falls Signieren aktiv ist.
+	if !sign.Enabled() {
+		return nil
+	}
+	files := []string{}
+	for _, file := range feedFiles(paths) {
+		files = append(files, file+".minisig")
+	}
+	return files
+}
+
+func RunKeygen() error { // Erzeugt ein Schlüsselpaar und gibt Public Key (für das Plugin) + Secret (für FEED_SIGNING_KEY) aus.
+	publicKey, secret, err := sign.GenerateKey()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Public key (minisign, verify with: minisign -V -P <key> -m feed.xml):\n%s\n\n", publicKey)
+	fmt.Printf("Secret for FEED_SIGNING_KEY (keep private):\n%s\n", secret)
+	return nil
+}
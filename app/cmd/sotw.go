@@ -0,0 +1,96 @@
+package cmd // Paket "cmd": State of the Word – Ankündigung und Aufzeichnung über Provider hinweg zu einem Entry zusammenführen.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"     // Ausgabe.
+	"regexp"  // Titel erkennen.
+	"sort"    // Teile chronologisch ordnen.
+	"strconv" // FEED_SOTW_PIN_DAYS.
+	"time"    // Pin-Fenster.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultSotWPinDays = 7 // So lange bleibt der State of the Word nach dem letzten Teil oben.
+
+var sotwPattern = regexp.MustCompile(`(?i)\bstate\s+of\s+the\s+word\b`) // Erkennt Ankündigung, Livestream und Aufzeichnung.
+
+func sotwPinWindow() time.Duration { // FEED_SOTW_PIN_DAYS (Default 7, 0 = nicht anpinnen).
+	_ = env.LoadDotEnv()
+	if days, err := strconv.Atoi(env.ReadEnv("FEED_SOTW_PIN_DAYS")); err == nil && days >= 0 {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return defaultSotWPinDays * 24 * time.Hour
+}
+
+func isRecording(entry Entry) bool { // Aufzeichnung: Video-Metadaten oder Video-Quelle.
+	return entry.Duration > 0 || entry.Thumbnail != "" || entry.Transcript != "" || entry.Provider == "wordpress-tv"
+}
+
+func mergeStateOfTheWord(entries *[]Entry, known *int) bool { // Führt alle SotW-Entries eines Jahres zusammen und pinnt sie; true bei Änderungen.
+	groups := map[string][]int{} // Jahr → Indizes der SotW-Entries.
+	for i, entry := range *entries {
+		if sotwPattern.MatchString(entry.Title) && len(entry.CreatedAt) >= 4 {
+			groups[entry.CreatedAt[:4]] = append(groups[entry.CreatedAt[:4]], i)
+		}
+	}
+	changed := false
+	remove := map[int]bool{}
+	for _, indices := range groups {
+		sort.Slice(indices, func(a, b int) bool { return (*entries)[indices[a]].CreatedAt < (*entries)[indices[b]].CreatedAt })
+		primary := &(*entries)[indices[0]] // Die früheste Meldung (meist die Ankündigung) bleibt erhalten.
+		newest := primary.CreatedAt
+		for _, index := range indices[1:] {
+			part := (*entries)[index]
+			if isRecording(part) { // Aufzeichnung: Video-Metadaten + Inhalt übernehmen.
+				primary.Content += "\n<p><strong>Recording</strong></p>\n" + part.Content
+				if primary.Thumbnail == "" {
+					primary.Thumbnail = part.Thumbnail
+				}
+				if primary.Image == "" {
+					primary.Image = part.Image
+				}
+				if primary.Duration == 0 {
+					primary.Duration = part.Duration
+				}
+				if primary.Transcript == "" {
+					primary.Transcript = part.Transcript
+				}
+			}
+			for _, category := range part.Categories {
+				if !containsFold(primary.Categories, category) {
+					primary.Categories = append(primary.Categories, category)
+				}
+			}
+			primary.Aliases = append(append(primary.Aliases, part.ID), part.Aliases...) // Gemergte IDs bleiben bekannt (kein erneutes Einsammeln).
+			newest = part.CreatedAt
+			remove[index] = true
+			changed = true
+			fmt.Printf("merged '%s' into '%s'\n", part.Title, primary.Title)
+		}
+		if window := sotwPinWindow(); window > 0 { // Pin-Fenster ab dem neuesten Teil.
+			if last, err := parseTime(newest); err == nil {
+				if until := last.Add(window).UTC().Format(time.RFC3339); primary.PinnedUntil != until {
+					primary.PinnedUntil = until
+					changed = true
+				}
+			}
+		}
+	}
+	if len(remove) == 0 {
+		return changed
+	}
+	kept := make([]Entry, 0, len(*entries)-len(remove))
+	removedBefore := 0 // Entfernte Entries vor known verschieben die Grenze "neu seit diesem Lauf".
+	for i, entry := range *entries {
+		if remove[i] {
+			if i < *known {
+				removedBefore++
+			}
+			continue
+		}
+		kept = append(kept, entry)
+	}
+	*entries = kept
+	*known -= removedBefore
+	return changed
+}
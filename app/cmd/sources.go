@@ -0,0 +1,90 @@
+package cmd // Paket "cmd": Quellen aus data/sources.json (neue Feeds ohne Codeänderung).
+
+import ( // Import-Block: Standardbibliothek + interne Pakete.
+	"fmt"           // Stderr-Ausgabe.
+	"os"            // Stderr.
+	"path/filepath" // sources.json neben providers.json.
+	"strings"       // Prompt bauen.
+	"time"          // FetchNew-Signatur (Watermark).
+
+	"wapuugotchi/feed/app/env"
+	"wapuugotchi/feed/app/feed"
+)
+
+func loadSources(path string, settings map[string]ProviderSettings) { // Liest data/sources.json (Liste mit "name") und legt die Einträge über providers.json.
+	sources := []ProviderSettings{}
+	readJSON(filepath.Join(filepath.Dir(path), "sources.json"), &sources)
+	for _, source := range sources {
+		name := strings.TrimSpace(source.Name)
+		if name == "" {
+			fmt.Fprintln(os.Stderr, "sources.json: source without name skipped")
+			continue
+		}
+		settings[name] = source
+	}
+}
+
+func builtinFetcher(kind string) func(fetch func(url, source string) ([]byte, error), since time.Time) ([]feed.Item, error) { // Eingebaute Parser, die per "type" auch unter eigenem Namen laufen können.
+	switch kind {
+	case "wordpress-releases":
+		return feed.LatestReleases
+	case "wordpress-tv":
+		return feed.LatestWordPressTV
+	case "wordpress-com":
+		return feed.LatestWordPressComBlog
+	}
+	return nil
+}
+
+func activeProviders(list []feedProvider, settings map[string]ProviderSettings) []feedProvider { // Entfernt Quellen mit "disabled": true und doppelte Namen (der erste gewinnt).
+	kept := list[:0]
+	seen := map[string]bool{}
+	for _, provider := range list {
+		if settings[provider.Name].Disabled || seen[provider.Name] {
+			continue
+		}
+		seen[provider.Name] = true
+		kept = append(kept, provider)
+	}
+	return kept
+}
+
+func translateItem(provider feedProvider, item feed.Item) feed.Item { // "translate": true – Titel + Inhalt per KI in die Zielsprache übersetzen (nur für neue Items).
+	if !provider.Settings.Translate {
+		return item
+	}
+	target := provider.Settings.TranslateTo
+	if target == "" {
+		_ = env.LoadDotEnv()
+		target = env.ReadEnv("FEED_LANGUAGE")
+	}
+	if target == "" {
+		target = "en"
+	}
+	if strings.EqualFold(item.Language, target) { // Schon in der Zielsprache.
+		return item
+	}
+	translator, err := newTranslator() // TRANSLATE_PROVIDER: KI-Prompt (Default), OpenAI, DeepL oder keiner; schon Übersetztes kommt aus dem Cache.
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "translate %s: %v\n", provider.Name, err)
+		return item
+	}
+	content, err := translator.Translate(item.Content, target)
+	if err != nil { // Übersetzer nicht erreichbar: Original behalten statt Item zu verlieren.
+		fmt.Fprintf(os.Stderr, "translate %s: %v\n", provider.Name, err)
+		return item
+	}
+	if content == item.Content { // Passthrough (TRANSLATE_PROVIDER=none): Sprache bleibt die des Originals.
+		return item
+	}
+	if title, err := translator.Translate(item.Title, target); err == nil && strings.TrimSpace(title) != "" {
+		item.Title = strings.TrimSpace(title)
+	}
+	item.Content = content
+	item.SourceLanguage = item.Language
+	if item.SourceLanguage == "" {
+		item.SourceLanguage = "und" // BCP 47: Originalsprache unbekannt.
+	}
+	item.Language = target
+	return item
+}
@@ -0,0 +1,27 @@
+package cmd // Paket "cmd": Laufzeit-Zustand zwischen zwei Läufen (data/state.json).
+
+type State struct { // Alles, was kein Entry ist, aber zwischen Läufen erhalten bleiben muss.
+	LastBuild  string            `json:"last_build,omitempty"` // Zeitpunkt des letzten erfolgreichen Feed-Builds (RFC3339).
+	Icons      *IconCache        `json:"icons,omitempty"`      // Gecachte Icon-Suche für die Website.
+	Watermarks map[string]string `json:"watermarks,omitempty"` // Pro Provider: Zeitpunkt des neuesten gesehenen Items (RFC3339).
+	Pruned     []string          `json:"pruned,omitempty"`     // IDs, die die Aufbewahrungsregel entfernt hat (die letzten 1000).
+	Removed    []string          `json:"removed,omitempty"`    // IDs, die "remove" zurückgezogen hat (werden nie wieder aufgenommen).
+
+	Languages  map[string]LanguageState  `json:"languages,omitempty"`  // Pro Ausgabesprache: offene Übersetzungen + letzter Fehler.
+	Notified   map[string][]string       `json:"notified,omitempty"`   // Pro Kanal (mastodon, bluesky, …): zuletzt angekündigte Entry-IDs.
+	Unnotified map[string]int            `json:"unnotified,omitempty"` // Entry-IDs, deren Ankündigung auf mindestens einem Kanal scheiterte → bisherige Versuche (siehe notify.go).
+	Health     map[string]ProviderHealth `json:"health,omitempty"`     // Pro Provider: Fehler in Folge + Circuit Breaker (siehe health.go).
+
+	NextFetch  map[string]string `json:"next_fetch_at,omitempty"` // Pro Provider: frühester nächster Abruf (RFC3339; siehe nextfetch.go).
+	LastUpdate string            `json:"last_update,omitempty"`   // Zeitpunkt des letzten erfolgreich beendeten Update-Laufs, auch ohne Änderungen (RFC3339; für /healthz).
+}
+
+func loadState(path string) State { // Lädt state.json; fehlt die Datei, ist der Zustand leer.
+	state := State{}
+	readJSON(path, &state)
+	return state
+}
+
+func saveState(path string, state State) { // Speichert state.json.
+	writeJSON(path, state)
+}
@@ -0,0 +1,220 @@
+package cmd // Paket "cmd": Speicher-Backend für data/ (site.json, entries.json, state.json, …) – Dateisystem oder z.B. eine Datenbank.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"errors"        // Fehlende Dateien erkennen.
+	"fmt"           // Fehlertexte.
+	"os"            // Dateisystem.
+	"path/filepath" // Namen ↔ Pfade.
+	"sort"          // Stabile Liste der Backends.
+	"strings"       // Pfad-Präfix prüfen.
+	"time"          // Zeitpunkt im Abruf-Verlauf.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type Store interface { // Speicher für die Dateien unter data/; Namen sind relativ mit "/" (z.B. "entries-2025.json", "archive/entries.json").
+	Read(name string) ([]byte, error)      // Inhalt; fehlt die Datei, ein Fehler mit errors.Is(err, os.ErrNotExist).
+	Write(name string, data []byte) error  // Ersetzt den Inhalt vollständig (atomar, soweit das Backend es kann).
+	Remove(name string) error              // Entfernt die Datei; fehlt sie, ist das kein Fehler.
+	List(pattern string) ([]string, error) // Namen passend zu pattern (Syntax wie path.Match), sortiert.
+}
+
+var storeBackends = map[string]func(dir string) (Store, error){ // FEED_STORE → Konstruktor; weitere Backends registrieren sich per init().
+	"file": func(dir string) (Store, error) { return fileStore{dir: dir}, nil },
+}
+
+var ( // Aktives Backend (getPaths öffnet es); ohne Backend wird direkt ins Dateisystem geschrieben.
+	dataStore    Store
+	dataStoreDir string
+)
+
+func openStore(dir string) error { // Öffnet das Backend aus FEED_STORE (Default: file) für das Datenverzeichnis dir.
+	if dataStore != nil && dataStoreDir == dir { // Mehrfacher getPaths-Aufruf im selben Lauf.
+		return nil
+	}
+	_ = env.LoadDotEnv()
+	name := strings.ToLower(env.ReadEnv("FEED_STORE"))
+	if name == "" {
+		name = "file"
+	}
+	open, ok := storeBackends[name]
+	if !ok {
+		names := make([]string, 0, len(storeBackends))
+		for backend := range storeBackends {
+			names = append(names, backend)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown FEED_STORE %q (available: %s)", name, strings.Join(names, ", "))
+	}
+	store, err := open(dir)
+	if err != nil {
+		return fmt.Errorf("open store %s: %w", name, err)
+	}
+	dataStore, dataStoreDir = store, dir
+	return nil
+}
+
+func storeName(path string) (string, bool) { // Name im Backend, wenn path unter data/ liegt (sonst z.B. Merge-Treiber-Dateien: Dateisystem).
+	if dataStore == nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(dataStoreDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+func readData(path string) ([]byte, error) { // Liest path über das Backend (data/) oder direkt.
+	if name, ok := storeName(path); ok {
+		return dataStore.Read(name)
+	}
+	return os.ReadFile(path)
+}
+
+func writeData(path string, data []byte) error { // Schreibt path über das Backend (data/) oder direkt (atomar).
+	if name, ok := storeName(path); ok {
+		return dataStore.Write(name, data)
+	}
+	return writeFileAtomic(path, data, 0o644)
+}
+
+func removeData(path string) error { // Entfernt path; fehlt die Datei, ist das kein Fehler.
+	if name, ok := storeName(path); ok {
+		return dataStore.Remove(name)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func globData(pattern string) []string { // Wie filepath.Glob, aber über das Backend; liefert absolute Pfade.
+	name, ok := storeName(pattern)
+	if !ok {
+		files, _ := filepath.Glob(pattern)
+		return files
+	}
+	names, err := dataStore.List(name)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return nil
+	}
+	files := make([]string, 0, len(names))
+	for _, name := range names {
+		files = append(files, filepath.Join(dataStoreDir, filepath.FromSlash(name)))
+	}
+	return files
+}
+
+type fileStore struct { // Standard: Dateien unter dir (git-freundlich, wie bisher).
+	dir string
+}
+
+func (store fileStore) path(name string) string {
+	return filepath.Join(store.dir, filepath.FromSlash(name))
+}
+
+func (store fileStore) Read(name string) ([]byte, error) {
+	return os.ReadFile(store.path(name))
+}
+
+func (store fileStore) Write(name string, data []byte) error {
+	path := store.path(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { // z.B. archive/ beim ersten Mal.
+		return err
+	}
+	return writeFileAtomic(path, data, 0o644)
+}
+
+func (store fileStore) Remove(name string) error {
+	if err := os.Remove(store.path(name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (store fileStore) List(pattern string) ([]string, error) {
+	files, err := filepath.Glob(store.path(pattern))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(store.dir, file)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, filepath.ToSlash(rel))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+type fetchRecorder interface { // Optional: Backends, die den Abruf-Verlauf pro Provider mitschreiben (z.B. SQLite).
+	RecordFetch(provider string, at time.Time, err error, added bool) error
+}
+
+func recordFetch(provider string, err error, added bool) { // Abruf im Verlauf vermerken, falls das Backend das kann (best-effort).
+	recorder, ok := dataStore.(fetchRecorder)
+	if !ok {
+		return
+	}
+	if err := recorder.RecordFetch(provider, time.Now().UTC(), err, added); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func migrationTargets() []string { // Registrierte Backends außer "file", sortiert.
+	names := []string{}
+	for name := range storeBackends {
+		if name != "file" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func RunMigrateStore(to string) error { // Kopiert die JSON-Dateien aus data/ in das Backend to (z.B. sqlite; leer = das einzige Nicht-Datei-Backend dieses Builds); danach FEED_STORE=to setzen.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(paths.entries)
+	targets := migrationTargets()
+	if len(targets) == 0 {
+		return errors.New("no store backend to migrate to in this build (build with -tags sqlite)")
+	}
+	if to == "" {
+		to = targets[0]
+	}
+	open, ok := storeBackends[to]
+	if !ok || to == "file" {
+		return fmt.Errorf("unknown target store %q (available: %s)", to, strings.Join(targets, ", "))
+	}
+	target, err := open(dir)
+	if err != nil {
+		return fmt.Errorf("open store %s: %w", to, err)
+	}
+	source := fileStore{dir: dir}
+	count := 0
+	for _, pattern := range []string{"*.json", "archive/*.json"} {
+		names, err := source.List(pattern)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			data, err := source.Read(name)
+			if err != nil {
+				return err
+			}
+			if err := target.Write(name, data); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			count++
+		}
+	}
+	fmt.Printf("migrated %d files to %s; set FEED_STORE=%s to use it\n", count, to, to)
+	return nil
+}
@@ -0,0 +1,253 @@
+//go:build sqlite
+
+package cmd // Paket "cmd": SQLite-Backend für data/ (data/feed.db) – nur mit `go build -tags sqlite` (Treiber: modernc.org/sqlite, reines Go).
+
+import ( // Import-Block: Standardbibliothek + SQLite-Treiber.
+	"database/sql"  // Datenbankzugriff.
+	"encoding/json" // Entries/State zerlegen und wieder zusammensetzen.
+	"errors"        // sql.ErrNoRows.
+	"fmt"           // Fehlertexte.
+	"os"            // os.ErrNotExist für fehlende "Dateien".
+	"path"          // Muster für List (path.Match).
+	"path/filepath" // Pfad der Datenbank.
+	"regexp"        // entries.json + Jahresdateien erkennen.
+	"sort"          // List sortiert.
+	"time"          // Zeitstempel im Abruf-Verlauf.
+
+	_ "modernc.org/sqlite" // Registriert den Treiber "sqlite".
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS entries (
+	file       TEXT    NOT NULL,
+	position   INTEGER NOT NULL,
+	id         TEXT    NOT NULL,
+	created_at TEXT    NOT NULL DEFAULT '',
+	data       TEXT    NOT NULL,
+	PRIMARY KEY (file, id)
+);
+CREATE INDEX IF NOT EXISTS entries_created_at ON entries (created_at);
+CREATE TABLE IF NOT EXISTS provider_state (
+	provider  TEXT PRIMARY KEY,
+	watermark TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS fetch_history (
+	provider   TEXT    NOT NULL,
+	fetched_at TEXT    NOT NULL,
+	error      TEXT    NOT NULL DEFAULT '',
+	added      INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS fetch_history_provider ON fetch_history (provider, fetched_at);
+CREATE TABLE IF NOT EXISTS files (
+	name TEXT PRIMARY KEY,
+	data BLOB NOT NULL
+);`
+
+var entriesFile = regexp.MustCompile(`(^|/)entries(-[0-9]{4})?\.json$`) // entries.json, entries-2025.json, archive/entries-2024.json.
+
+func init() { // FEED_STORE=sqlite.
+	storeBackends["sqlite"] = openSQLiteStore
+}
+
+type sqliteStore struct { // Entries als Zeilen (schnell, keine Merge-Konflikte), Watermarks in provider_state, Rest als Blobs in files.
+	db *sql.DB
+}
+
+func openSQLiteStore(dir string) (Store, error) { // Öffnet (bzw. legt an) dir/feed.db.
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", filepath.Join(dir, "feed.db"))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1) // Ein Schreiber: SQLite serialisiert ohnehin.
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return sqliteStore{db: db}, nil
+}
+
+func (store sqliteStore) Read(name string) ([]byte, error) {
+	if entriesFile.MatchString(name) {
+		return store.readEntries(name)
+	}
+	var data []byte
+	err := store.db.QueryRow(`SELECT data FROM files WHERE name = ?`, name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+	}
+	if err != nil || name != "state.json" {
+		return data, err
+	}
+	return store.withWatermarks(data)
+}
+
+func (store sqliteStore) readEntries(name string) ([]byte, error) { // Zeilen in gespeicherter Reihenfolge als JSON-Array.
+	rows, err := store.db.Query(`SELECT data FROM entries WHERE file = ? ORDER BY position`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := []json.RawMessage{}
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		entries = append(entries, json.RawMessage(data))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 { // Leere Jahresdateien gibt es im Dateisystem auch nicht.
+		return nil, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+	}
+	return json.Marshal(entries)
+}
+
+func (store sqliteStore) withWatermarks(data []byte) ([]byte, error) { // state.json + Watermarks aus provider_state.
+	state := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	rows, err := store.db.Query(`SELECT provider, watermark FROM provider_state`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	watermarks := map[string]string{}
+	for rows.Next() {
+		var provider, watermark string
+		if err := rows.Scan(&provider, &watermark); err != nil {
+			return nil, err
+		}
+		watermarks[provider] = watermark
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(watermarks) > 0 {
+		encoded, err := json.Marshal(watermarks)
+		if err != nil {
+			return nil, err
+		}
+		state["watermarks"] = encoded
+	}
+	return json.Marshal(state)
+}
+
+func (store sqliteStore) Write(name string, data []byte) error { // Eine Transaktion pro Datei: Abbruch = alter Stand.
+	tx, err := store.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // Nach Commit ein No-op.
+	switch {
+	case entriesFile.MatchString(name):
+		err = writeEntries(tx, name, data)
+	case name == "state.json":
+		err = writeState(tx, data)
+	default:
+		_, err = tx.Exec(`INSERT INTO files (name, data) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET data = excluded.data`, name, data)
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func writeEntries(tx *sql.Tx, name string, data []byte) error { // Ersetzt alle Zeilen der "Datei" name.
+	entries := []json.RawMessage{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM entries WHERE file = ?`, name); err != nil {
+		return err
+	}
+	for position, raw := range entries {
+		var entry struct {
+			ID        string `json:"id"`
+			CreatedAt string `json:"created_at"`
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO entries (file, position, id, created_at, data) VALUES (?, ?, ?, ?, ?)`, name, position, entry.ID, entry.CreatedAt, string(raw)); err != nil {
+			return fmt.Errorf("entry %s: %w", entry.ID, err)
+		}
+	}
+	return nil
+}
+
+func writeState(tx *sql.Tx, data []byte) error { // Watermarks → provider_state, der Rest als Blob.
+	state := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	watermarks := map[string]string{}
+	if raw, ok := state["watermarks"]; ok {
+		if err := json.Unmarshal(raw, &watermarks); err != nil {
+			return err
+		}
+		delete(state, "watermarks")
+	}
+	if _, err := tx.Exec(`DELETE FROM provider_state`); err != nil {
+		return err
+	}
+	for provider, watermark := range watermarks {
+		if _, err := tx.Exec(`INSERT INTO provider_state (provider, watermark) VALUES (?, ?)`, provider, watermark); err != nil {
+			return err
+		}
+	}
+	rest, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`INSERT INTO files (name, data) VALUES ('state.json', ?) ON CONFLICT (name) DO UPDATE SET data = excluded.data`, rest)
+	return err
+}
+
+func (store sqliteStore) Remove(name string) error {
+	if entriesFile.MatchString(name) {
+		_, err := store.db.Exec(`DELETE FROM entries WHERE file = ?`, name)
+		return err
+	}
+	_, err := store.db.Exec(`DELETE FROM files WHERE name = ?`, name)
+	return err
+}
+
+func (store sqliteStore) List(pattern string) ([]string, error) {
+	rows, err := store.db.Query(`SELECT name FROM files UNION SELECT DISTINCT file FROM entries`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		if ok, err := path.Match(pattern, name); err != nil {
+			return nil, err
+		} else if ok {
+			names = append(names, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (store sqliteStore) RecordFetch(provider string, at time.Time, err error, added bool) error { // fetch_history: ein Eintrag pro Provider und Lauf.
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+	_, execErr := store.db.Exec(`INSERT INTO fetch_history (provider, fetched_at, error, added) VALUES (?, ?, ?, ?)`, provider, at.Format(time.RFC3339), message, added)
+	return execErr
+}
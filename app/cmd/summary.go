@@ -0,0 +1,51 @@
+package cmd // Paket "cmd": KI-Zusammenfassung (2–3 Sätze) pro neuem Entry – kostet Tokens, daher nur mit FEED_AI_SUMMARY=true bzw. "summarize": true.
+
+import ( // Import-Block: Standardbibliothek + interne Pakete.
+	"fmt"     // Fehlerausgabe.
+	"html"    // Klartext für <description> escapen.
+	"os"      // Stderr.
+	"strings" // Trimmen.
+
+	"wapuugotchi/feed/app/ai"
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultSummaryPrompt = "Summarize the following text in 2-3 sentences, in the language of the text. Output plain text only: no HTML, no Markdown, no introduction.\n\nText:\n\n{{.Text}}" // Prompt für die Zusammenfassung; data/prompts/summary.tmpl bzw. <provider>/summary.tmpl ersetzt ihn.
+
+const maxSummaryInput = 8000 // Zeichen Klartext, die höchstens an die KI gehen (lange Beiträge kosten sonst unnötig Tokens).
+
+func summariesEnabled(provider feedProvider) bool { // Global (FEED_AI_SUMMARY=true) oder pro Quelle ("summarize": true).
+	if provider.Settings.Summarize {
+		return true
+	}
+	_ = env.LoadDotEnv()
+	return env.ReadEnv("FEED_AI_SUMMARY") == "true"
+}
+
+func summarizeEntry(provider feedProvider, entry Entry) string { // Zusammenfassung des Inhalts; leer, wenn abgeschaltet, ohne Text oder bei KI-Fehlern (dann gilt weiter der volle Inhalt).
+	if !summariesEnabled(provider) {
+		return ""
+	}
+	text := plainText(entry.Content)
+	if text == "" {
+		return ""
+	}
+	if runes := []rune(text); len(runes) > maxSummaryInput {
+		text = string(runes[:maxSummaryInput])
+	}
+	data := promptData{Title: entry.Title, Link: entry.Link, Source: provider.Name, Language: entry.Language, Categories: entry.Categories, Text: text}
+	prompt, _ := providerPrompt("summary", provider, data, defaultSummaryPrompt)
+	summary, err := ai.TransformText("", prompt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "summary %s: %v\n", provider.Name, err)
+		return ""
+	}
+	return strings.TrimSpace(summary)
+}
+
+func itemDescription(entry Entry) string { // <description>: Zusammenfassung, falls vorhanden, sonst der volle Inhalt.
+	if entry.Summary != "" {
+		return html.EscapeString(entry.Summary) // Klartext: "&" und "<" nicht als HTML deuten lassen.
+	}
+	return entry.Content
+}
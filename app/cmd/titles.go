@@ -0,0 +1,71 @@
+package cmd // Paket "cmd": Titel bereinigen (Entities, Whitespace, Unicode-NFC, Site-Suffixe) vor Speicherung und Dedupe.
+
+import ( // Import-Block: Standardbibliothek.
+	"html"    // Entities dekodieren.
+	"strings" // Whitespace + Suffixe.
+)
+
+var defaultTitleSuffixes = []string{"WordPress News", "WordPress.org", "WordPress.tv", "WordPress.com", "WP Tavern"} // Site-Namen, die Quellen an Titel hängen.
+
+var titleSeparators = []string{" – ", " — ", " | ", " - ", " · "} // Trenner zwischen Titel und Site-Name.
+
+var composeTable = map[[2]rune]rune{ // NFC für Latin-1 + Latin Extended-A: Basisbuchstabe + kombinierendes Zeichen → vorkomponiert (die Stdlib hat keine Normalisierung).
+	{'A', 0x0300}: 'À', {'A', 0x0301}: 'Á', {'A', 0x0302}: 'Â', {'A', 0x0303}: 'Ã', {'A', 0x0308}: 'Ä', {'A', 0x030A}: 'Å',
+	{'C', 0x0327}: 'Ç', {'E', 0x0300}: 'È', {'E', 0x0301}: 'É', {'E', 0x0302}: 'Ê', {'E', 0x0308}: 'Ë', {'I', 0x0300}: 'Ì',
+	{'I', 0x0301}: 'Í', {'I', 0x0302}: 'Î', {'I', 0x0308}: 'Ï', {'N', 0x0303}: 'Ñ', {'O', 0x0300}: 'Ò', {'O', 0x0301}: 'Ó',
+	{'O', 0x0302}: 'Ô', {'O', 0x0303}: 'Õ', {'O', 0x0308}: 'Ö', {'U', 0x0300}: 'Ù', {'U', 0x0301}: 'Ú', {'U', 0x0302}: 'Û',
+	{'U', 0x0308}: 'Ü', {'Y', 0x0301}: 'Ý', {'a', 0x0300}: 'à', {'a', 0x0301}: 'á', {'a', 0x0302}: 'â', {'a', 0x0303}: 'ã',
+	{'a', 0x0308}: 'ä', {'a', 0x030A}: 'å', {'c', 0x0327}: 'ç', {'e', 0x0300}: 'è', {'e', 0x0301}: 'é', {'e', 0x0302}: 'ê',
+	{'e', 0x0308}: 'ë', {'i', 0x0300}: 'ì', {'i', 0x0301}: 'í', {'i', 0x0302}: 'î', {'i', 0x0308}: 'ï', {'n', 0x0303}: 'ñ',
+	{'o', 0x0300}: 'ò', {'o', 0x0301}: 'ó', {'o', 0x0302}: 'ô', {'o', 0x0303}: 'õ', {'o', 0x0308}: 'ö', {'u', 0x0300}: 'ù',
+	{'u', 0x0301}: 'ú', {'u', 0x0302}: 'û', {'u', 0x0308}: 'ü', {'y', 0x0301}: 'ý', {'y', 0x0308}: 'ÿ', {'A', 0x0304}: 'Ā',
+	{'a', 0x0304}: 'ā', {'A', 0x0306}: 'Ă', {'a', 0x0306}: 'ă', {'A', 0x0328}: 'Ą', {'a', 0x0328}: 'ą', {'C', 0x0301}: 'Ć',
+	{'c', 0x0301}: 'ć', {'C', 0x0302}: 'Ĉ', {'c', 0x0302}: 'ĉ', {'C', 0x0307}: 'Ċ', {'c', 0x0307}: 'ċ', {'C', 0x030C}: 'Č',
+	{'c', 0x030C}: 'č', {'D', 0x030C}: 'Ď', {'d', 0x030C}: 'ď', {'E', 0x0304}: 'Ē', {'e', 0x0304}: 'ē', {'E', 0x0306}: 'Ĕ',
+	{'e', 0x0306}: 'ĕ', {'E', 0x0307}: 'Ė', {'e', 0x0307}: 'ė', {'E', 0x0328}: 'Ę', {'e', 0x0328}: 'ę', {'E', 0x030C}: 'Ě',
+	{'e', 0x030C}: 'ě', {'G', 0x0302}: 'Ĝ', {'g', 0x0302}: 'ĝ', {'G', 0x0306}: 'Ğ', {'g', 0x0306}: 'ğ', {'G', 0x0307}: 'Ġ',
+	{'g', 0x0307}: 'ġ', {'G', 0x0327}: 'Ģ', {'g', 0x0327}: 'ģ', {'H', 0x0302}: 'Ĥ', {'h', 0x0302}: 'ĥ', {'I', 0x0303}: 'Ĩ',
+	{'i', 0x0303}: 'ĩ', {'I', 0x0304}: 'Ī', {'i', 0x0304}: 'ī', {'I', 0x0306}: 'Ĭ', {'i', 0x0306}: 'ĭ', {'I', 0x0328}: 'Į',
+	{'i', 0x0328}: 'į', {'I', 0x0307}: 'İ', {'J', 0x0302}: 'Ĵ', {'j', 0x0302}: 'ĵ', {'K', 0x0327}: 'Ķ', {'k', 0x0327}: 'ķ',
+	{'L', 0x0301}: 'Ĺ', {'l', 0x0301}: 'ĺ', {'L', 0x0327}: 'Ļ', {'l', 0x0327}: 'ļ', {'L', 0x030C}: 'Ľ', {'l', 0x030C}: 'ľ',
+	{'N', 0x0301}: 'Ń', {'n', 0x0301}: 'ń', {'N', 0x0327}: 'Ņ', {'n', 0x0327}: 'ņ', {'N', 0x030C}: 'Ň', {'n', 0x030C}: 'ň',
+	{'O', 0x0304}: 'Ō', {'o', 0x0304}: 'ō', {'O', 0x0306}: 'Ŏ', {'o', 0x0306}: 'ŏ', {'O', 0x030B}: 'Ő', {'o', 0x030B}: 'ő',
+	{'R', 0x0301}: 'Ŕ', {'r', 0x0301}: 'ŕ', {'R', 0x0327}: 'Ŗ', {'r', 0x0327}: 'ŗ', {'R', 0x030C}: 'Ř', {'r', 0x030C}: 'ř',
+	{'S', 0x0301}: 'Ś', {'s', 0x0301}: 'ś', {'S', 0x0302}: 'Ŝ', {'s', 0x0302}: 'ŝ', {'S', 0x0327}: 'Ş', {'s', 0x0327}: 'ş',
+	{'S', 0x030C}: 'Š', {'s', 0x030C}: 'š', {'T', 0x0327}: 'Ţ', {'t', 0x0327}: 'ţ', {'T', 0x030C}: 'Ť', {'t', 0x030C}: 'ť',
+	{'U', 0x0303}: 'Ũ', {'u', 0x0303}: 'ũ', {'U', 0x0304}: 'Ū', {'u', 0x0304}: 'ū', {'U', 0x0306}: 'Ŭ', {'u', 0x0306}: 'ŭ',
+	{'U', 0x030A}: 'Ů', {'u', 0x030A}: 'ů', {'U', 0x030B}: 'Ű', {'u', 0x030B}: 'ű', {'U', 0x0328}: 'Ų', {'u', 0x0328}: 'ų',
+	{'W', 0x0302}: 'Ŵ', {'w', 0x0302}: 'ŵ', {'Y', 0x0302}: 'Ŷ', {'y', 0x0302}: 'ŷ', {'Y', 0x0308}: 'Ÿ', {'Z', 0x0301}: 'Ź',
+	{'z', 0x0301}: 'ź', {'Z', 0x0307}: 'Ż', {'z', 0x0307}: 'ż', {'Z', 0x030C}: 'Ž', {'z', 0x030C}: 'ž',
+}
+
+func cleanTitle(title string, suffixes []string) string { // Dekodiert Entities, komponiert Umlaute (NFC), fasst Whitespace zusammen und entfernt Site-Suffixe.
+	for i := 0; i < 2; i++ { // Doppelt kodierte Entities ("&amp;#8211;") kommen in Feeds vor.
+		title = html.UnescapeString(title)
+	}
+	title = strings.Join(strings.Fields(composeNFC(title)), " ") // Fields trennt auch an NBSP, Tabs und Zeilenumbrüchen.
+	for _, suffix := range append(append([]string{}, suffixes...), defaultTitleSuffixes...) {
+		for _, separator := range titleSeparators {
+			tail := separator + strings.TrimSpace(suffix)
+			if len(title) > len(tail) && strings.EqualFold(title[len(title)-len(tail):], tail) {
+				title = strings.TrimSpace(title[:len(title)-len(tail)])
+			}
+		}
+	}
+	return title
+}
+
+func composeNFC(text string) string { // Ersetzt zerlegte Sequenzen ("e" + U+0301) durch das vorkomponierte Zeichen ("é").
+	runes := []rune(text)
+	result := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if last := len(result) - 1; last >= 0 {
+			if composed, ok := composeTable[[2]rune{result[last], r}]; ok {
+				result[last] = composed
+				continue
+			}
+		}
+		result = append(result, r)
+	}
+	return string(result)
+}
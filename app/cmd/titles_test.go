@@ -0,0 +1,30 @@
+package cmd // Tests für cleanTitle: Entities, Whitespace, NFC und Site-Suffixe.
+
+import "testing"
+
+func TestCleanTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		suffixes []string
+		want     string
+	}{
+		{"unverändert", "WordPress 6.5 Beta 1", nil, "WordPress 6.5 Beta 1"},
+		{"Entities", "Tom &amp; Jerry &#8211; Teil 2", nil, "Tom & Jerry – Teil 2"},
+		{"doppelt kodiert", "A &amp;#8211; B", nil, "A – B"},
+		{"Whitespace + NBSP", "  Hallo \n\tWelt  ", nil, "Hallo Welt"},
+		{"NFC", "Café München", nil, "Café München"},
+		{"Default-Suffix", "Release Notes – WordPress News", nil, "Release Notes"},
+		{"Suffix ohne Groß-/Kleinschreibung", "Release Notes | wordpress.org", nil, "Release Notes"},
+		{"eigener Suffix", "Neuer Beitrag - Mein Blog", []string{"Mein Blog"}, "Neuer Beitrag"},
+		{"nur der Suffix bleibt", "WP Tavern", nil, "WP Tavern"},
+		{"Suffix mitten im Titel bleibt", "WordPress.org – Neuigkeiten", nil, "WordPress.org – Neuigkeiten"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := cleanTitle(test.title, test.suffixes); got != test.want {
+				t.Errorf("cleanTitle(%q) = %q, want %q", test.title, got, test.want)
+			}
+		})
+	}
+}
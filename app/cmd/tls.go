@@ -0,0 +1,68 @@
+package cmd // Paket "cmd": TLS-Einstellungen für ausgehende Requests (eigene CA, Mindestversion, Verify-Notausgang).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"crypto/tls"  // tls.Config.
+	"crypto/x509" // CA-Pool.
+	"fmt"         // Fehlertexte + Warnung.
+	"net/http"    // Client + Transport.
+	"os"          // CA-Bundle lesen, Stderr.
+	"strings"     // Normalisieren.
+	"sync"        // Clients nur einmal bauen.
+	"time"        // Timeout.
+
+	"wapuugotchi/feed/app/env"
+)
+
+var secureClient = sync.OnceValues(func() (*http.Client, error) { return newHTTPClient(false) })  // Standard-Client für alle Quellen.
+var insecureClient = sync.OnceValues(func() (*http.Client, error) { return newHTTPClient(true) }) // Nur für Quellen mit insecure_skip_verify.
+
+func httpClient(insecure bool) (*http.Client, error) { // Liefert den (gecachten) Client; FEED_TLS_INSECURE_SKIP_VERIFY gilt für alle Quellen.
+	_ = env.LoadDotEnv()
+	if insecure || env.ReadEnv("FEED_TLS_INSECURE_SKIP_VERIFY") == "true" {
+		return insecureClient()
+	}
+	return secureClient()
+}
+
+func newHTTPClient(insecure bool) (*http.Client, error) { // Client mit Timeout und TLS-Konfiguration aus FEED_CA_BUNDLE / FEED_TLS_MIN_VERSION.
+	config, err := tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if insecure { // Notausgang für Staging/Self-Hosted hinter internen CAs: Zertifikate werden NICHT geprüft.
+		fmt.Fprintln(os.Stderr, "warning: TLS certificate verification disabled")
+		config.InsecureSkipVerify = true
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone() // Proxy/Keep-Alive-Defaults behalten.
+	transport.TLSClientConfig = config
+	if debugHTTPDir != "" { // -debug-http: jeden Request mitschneiden.
+		return &http.Client{Timeout: 15 * time.Second, Transport: debugTransport{next: transport}}, nil
+	}
+	return &http.Client{Timeout: 15 * time.Second, Transport: transport}, nil // Timeout schützt vor Hängern.
+}
+
+func tlsConfig() (*tls.Config, error) { // Baut die tls.Config aus den ENV-Variablen.
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	switch version := strings.TrimSpace(env.ReadEnv("FEED_TLS_MIN_VERSION")); version {
+	case "", "1.2":
+	case "1.3":
+		config.MinVersion = tls.VersionTLS13
+	default:
+		return nil, fmt.Errorf("FEED_TLS_MIN_VERSION: unsupported version %q (use 1.2 or 1.3)", version)
+	}
+	if bundle := strings.TrimSpace(env.ReadEnv("FEED_CA_BUNDLE")); bundle != "" { // Zusätzliche CAs (PEM), z.B. einer internen PKI.
+		pem, err := os.ReadFile(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("FEED_CA_BUNDLE: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("FEED_CA_BUNDLE: no certificates found in %s", bundle)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
@@ -0,0 +1,65 @@
+package cmd // Paket "cmd": Übersetzungs-Cache (data/translations.json) – derselbe Text wird nur einmal bezahlt.
+
+import ( // Import-Block: Standardbibliothek + KI-Paket.
+	"strings" // Leere Texte.
+
+	"wapuugotchi/feed/app/ai"
+)
+
+var translations *translationCache // Cache des laufenden Updates (RunFeedUpdate lädt und speichert ihn); nil = ohne Cache.
+
+type translationCache struct { // md5(Zielsprache + Text) → Übersetzung.
+	path    string
+	entries map[string]string
+	dirty   bool // Seit dem Laden ergänzt: beim Speichern schreiben.
+}
+
+func loadTranslationCache(path string) *translationCache { // Fehlt die Datei, beginnt der Cache leer.
+	cache := &translationCache{path: path, entries: map[string]string{}}
+	readJSON(path, &cache.entries)
+	return cache
+}
+
+func (cache *translationCache) save() { // Schreibt nur, wenn neue Übersetzungen dazukamen.
+	if cache == nil || !cache.dirty {
+		return
+	}
+	writeJSON(cache.path, cache.entries)
+	cache.dirty = false
+}
+
+func newTranslator() (ai.Translator, error) { // Backend aus TRANSLATE_PROVIDER, vor dem der Cache des Laufs sitzt.
+	translator, err := ai.NewTranslator()
+	if err != nil || translations == nil {
+		return translator, err
+	}
+	return cachedTranslator{Translator: translator, cache: translations}, nil
+}
+
+func translationKey(text, target string) string { // Zielsprache gehört zum Schlüssel: derselbe Text auf Deutsch und Französisch sind zwei Einträge.
+	return hashString(strings.ToLower(target) + "\n" + text)
+}
+
+type cachedTranslator struct { // Fragt erst den Cache, dann das Backend.
+	ai.Translator
+	cache *translationCache
+}
+
+func (translator cachedTranslator) Translate(text, target string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return translator.Translator.Translate(text, target)
+	}
+	key := translationKey(text, target)
+	if cached, ok := translator.cache.entries[key]; ok {
+		return cached, nil
+	}
+	translated, err := translator.Translator.Translate(text, target)
+	if err != nil {
+		return "", err
+	}
+	if translated != text { // Passthrough nicht cachen: ein später konfigurierter Übersetzer soll greifen.
+		translator.cache.entries[key] = translated
+		translator.cache.dirty = true
+	}
+	return translated, nil
+}
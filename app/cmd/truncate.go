@@ -0,0 +1,79 @@
+package cmd // Paket "cmd": HTML sicher an Tag-Grenzen kürzen.
+
+import ( // Import-Block: Standardbibliothek.
+	"html"    // Link im "Read more" escapen.
+	"regexp"  // Tokenizer für Tags/Text.
+	"strings" // Builder + Tag-Namen.
+)
+
+var htmlTokenPattern = regexp.MustCompile(`<[^>]*>|[^<]+`)                // Ein Token ist entweder ein Tag oder ein Textstück.
+var tagNamePattern = regexp.MustCompile(`^</?\s*([a-zA-Z][a-zA-Z0-9-]*)`) // Name eines öffnenden/schließenden Tags.
+var voidElements = map[string]bool{"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true, "input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true}
+
+func truncateHTML(content string, limit int, link, readMore string) string { // Kürzt content auf ca. limit Zeichen, schließt offene Tags und hängt einen Weiterlesen-Link an.
+	if limit <= 0 || len(content) <= limit {
+		return content
+	}
+	var out strings.Builder
+	open := []string{} // Stack der offenen Tags (zum sauberen Schließen).
+	for _, token := range htmlTokenPattern.FindAllString(content, -1) {
+		if !strings.HasPrefix(token, "<") { // Text: passt er nicht mehr, an einer Wortgrenze abschneiden.
+			if out.Len()+len(token) > limit {
+				out.WriteString(cutText(token, limit-out.Len()))
+				break
+			}
+			out.WriteString(token)
+			continue
+		}
+		if out.Len()+len(token) > limit { // Tags werden nie zerteilt.
+			break
+		}
+		out.WriteString(token)
+		match := tagNamePattern.FindStringSubmatch(token)
+		if match == nil || strings.HasPrefix(token, "<!") { // Kommentare/Doctype: kein Stack-Eintrag.
+			continue
+		}
+		name := strings.ToLower(match[1])
+		switch {
+		case strings.HasPrefix(token, "</"):
+			for i := len(open) - 1; i >= 0; i-- { // Bis zum passenden öffnenden Tag abbauen (toleriert kaputtes HTML).
+				if open[i] == name {
+					open = open[:i]
+					break
+				}
+			}
+		case !voidElements[name] && !strings.HasSuffix(token, "/>"):
+			open = append(open, name)
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- { // Offene Tags in umgekehrter Reihenfolge schließen.
+		out.WriteString("</" + open[i] + ">")
+	}
+	if readMore == "" {
+		readMore = "Read more"
+	}
+	if link != "" {
+		out.WriteString(`<p><a href="` + html.EscapeString(link) + `">` + html.EscapeString(readMore) + `</a></p>`)
+	}
+	return out.String()
+}
+
+func cutText(text string, budget int) string { // Schneidet Text auf budget Bytes, ohne Wörter, Runen oder Entities zu zerteilen.
+	if budget <= 0 {
+		return "…"
+	}
+	cut := text[:budget]
+	for len(cut) > 0 && cut[len(cut)-1]&0xC0 == 0x80 { // Keine halbe UTF-8-Sequenz stehen lassen.
+		cut = cut[:len(cut)-1]
+	}
+	if len(cut) > 0 && cut[len(cut)-1] >= 0xC0 {
+		cut = cut[:len(cut)-1]
+	}
+	if amp := strings.LastIndex(cut, "&"); amp != -1 && !strings.Contains(cut[amp:], ";") { // Angefangene Entity verwerfen.
+		cut = cut[:amp]
+	}
+	if space := strings.LastIndexAny(cut, " \t\n"); space > 0 {
+		cut = cut[:space]
+	}
+	return strings.TrimRight(cut, " \t\n.,;:") + "…"
+}
@@ -0,0 +1,32 @@
+package cmd // Tests für truncateHTML: Kürzen an Tag- und Wortgrenzen, offene Tags schließen, Weiterlesen-Link.
+
+import "testing"
+
+func TestTruncateHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		limit    int
+		link     string
+		readMore string
+		want     string
+	}{
+		{"kurz genug", `<p>Hallo</p>`, 100, "https://example.com", "", `<p>Hallo</p>`},
+		{"Limit 0 = aus", `<p>Hallo Welt</p>`, 0, "", "", `<p>Hallo Welt</p>`},
+		{"Wortgrenze + Tag schließen", `<p>Hallo schöne Welt</p>`, 16, "", "", `<p>Hallo…</p>`},
+		{"verschachtelte Tags", `<div><p><em>eins zwei drei vier</em></p></div>`, 25, "", "", `<div><p><em>eins zwei…</em></p></div>`},
+		{"Tag wird nicht zerteilt", `<p>abc</p><a href="https://example.com/sehr/lang">x</a>`, 15, "", "", `<p>abc</p>`},
+		{"Entity nicht zerteilt", `<p>Tom &amp; Jerry</p>`, 10, "", "", `<p>Tom…</p>`},
+		{"UTF-8 nicht zerteilt", `<p>ääääää</p>`, 8, "", "", `<p>ää…</p>`},
+		{"void-Elemente ohne End-Tag", `<p>a<br>b<img src="x.jpg"> c d e f g</p>`, 30, "", "", `<p>a<br>b<img src="x.jpg"> c…</p>`},
+		{"Weiterlesen-Link", `<p>eins zwei drei</p>`, 12, "https://example.com/?a=1&b=2", "Mehr", `<p>eins…</p><p><a href="https://example.com/?a=1&amp;b=2">Mehr</a></p>`},
+		{"Default-Text", `<p>eins zwei drei</p>`, 12, "https://example.com", "", `<p>eins…</p><p><a href="https://example.com">Read more</a></p>`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := truncateHTML(test.content, test.limit, test.link, test.readMore); got != test.want {
+				t.Errorf("truncateHTML(%q, %d) = %q, want %q", test.content, test.limit, got, test.want)
+			}
+		})
+	}
+}
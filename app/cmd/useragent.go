@@ -0,0 +1,107 @@
+package cmd // Paket "cmd": ehrlicher, konfigurierbarer User-Agent mit Kontaktadresse sowie Header und Auth pro Quelle.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"encoding/base64" // Basic-Auth.
+	"fmt"             // Fehlertexte.
+	"os"              // ${NAME} in Header-Werten, Stderr.
+	"strings"         // Trimmen + Vergleich.
+	"sync"            // ENV nur einmal lesen.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const defaultContact = "https://github.com/codeispoetry/wapugotchi_feed" // Kontakt, wenn FEED_CONTACT fehlt: Betreiber von Quellen finden so das Projekt.
+
+var userAgent = sync.OnceValue(func() string { // FEED_USER_AGENT ersetzt den UA komplett, sonst "wapuugotchi-feed/1.2 (+<FEED_CONTACT>)".
+	_ = env.LoadDotEnv()
+	if agent := strings.TrimSpace(env.ReadEnv("FEED_USER_AGENT")); agent != "" {
+		return agent
+	}
+	contact := strings.TrimSpace(env.ReadEnv("FEED_CONTACT")) // URL oder E-Mail-Adresse.
+	if contact == "" {
+		contact = defaultContact
+	}
+	return userAgentName + " (+" + contact + ")"
+})
+
+func (provider feedProvider) requestHeaders() map[string]string { // Header des Providers plus optionaler User-Agent aus providers.json.
+	agent := strings.TrimSpace(provider.Settings.UserAgent)
+	if agent == "" {
+		return provider.Headers
+	}
+	if strings.EqualFold(agent, "browser") {
+		agent = browserUserAgent
+	}
+	headers := map[string]string{"User-Agent": agent}
+	for key, value := range provider.Headers {
+		headers[key] = value
+	}
+	return headers
+}
+
+type SourceAuth struct { // Zugangsdaten einer Quelle; nur die Namen der ENV-Variablen stehen in providers.json.
+	Type        string `json:"type"`                   // "basic" oder "bearer".
+	Username    string `json:"username,omitempty"`     // basic: Benutzername (oder username_env).
+	UsernameEnv string `json:"username_env,omitempty"` // basic: ENV-Variable mit dem Benutzernamen.
+	PasswordEnv string `json:"password_env,omitempty"` // basic: ENV-Variable mit dem Passwort.
+	TokenEnv    string `json:"token_env,omitempty"`    // bearer: ENV-Variable mit dem Token.
+}
+
+func applySourceHeaders(provider feedProvider) feedProvider { // Header und Auth aus providers.json zu den Headern des Providers; fehlende ENV-Werte werden gemeldet, der Header entfällt.
+	settings := provider.Settings
+	if len(settings.Headers) == 0 && settings.Auth == nil {
+		return provider
+	}
+	_ = env.LoadDotEnv()
+	headers := map[string]string{}
+	for key, value := range provider.Headers {
+		headers[key] = value
+	}
+	for key, value := range settings.Headers {
+		missing := ""
+		value = os.Expand(value, func(name string) string {
+			resolved := env.ReadEnv(name)
+			if resolved == "" {
+				missing = name
+			}
+			return resolved
+		})
+		if missing != "" {
+			fmt.Fprintf(os.Stderr, "provider %s: header %s: %s is not set\n", provider.Name, key, missing)
+			continue
+		}
+		headers[key] = value
+	}
+	if auth, err := authorization(settings.Auth); err != nil {
+		fmt.Fprintf(os.Stderr, "provider %s: auth: %v\n", provider.Name, err)
+	} else if auth != "" {
+		headers["Authorization"] = auth
+	}
+	provider.Headers = headers
+	return provider
+}
+
+func authorization(auth *SourceAuth) (string, error) { // Wert für den Authorization-Header; "" ohne Auth.
+	if auth == nil {
+		return "", nil
+	}
+	switch strings.ToLower(strings.TrimSpace(auth.Type)) {
+	case "basic":
+		username := auth.Username
+		if auth.UsernameEnv != "" {
+			username = env.ReadEnv(auth.UsernameEnv)
+		}
+		password := env.ReadEnv(auth.PasswordEnv)
+		if username == "" || password == "" {
+			return "", fmt.Errorf("basic auth needs username and %s", orNone(auth.PasswordEnv))
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+	case "bearer":
+		token := env.ReadEnv(auth.TokenEnv)
+		if token == "" {
+			return "", fmt.Errorf("bearer auth: %s is not set", orNone(auth.TokenEnv))
+		}
+		return "Bearer " + token, nil
+	}
+	return "", fmt.Errorf("unknown type %q (basic, bearer)", auth.Type)
+}
@@ -0,0 +1,64 @@
+package cmd // Paket "cmd": Daten und Konfiguration prüfen, bevor ein Lauf sie still ignoriert.
+
+import ( // Import-Block: Standardbibliothek.
+	"encoding/json" // Strikt parsen (readJSON ignoriert Fehler).
+	"errors"        // Fehler-Zusammenfassung.
+	"fmt"           // Ausgabe.
+	"os"            // Dateien lesen.
+)
+
+func RunValidate() error { // Prüft alle JSON-Dateien und Prompt-Templates unter data/, die Entries und die generierten RSS-Feeds; meldet jedes Problem, Fehler bei mindestens einem.
+	paths, err := getPaths()
+	if err != nil {
+		return err
+	}
+	problems := []string{}
+	files := append([]string{paths.site, paths.feeds, paths.pending, paths.state, paths.rules, paths.settings, paths.checkpoint, paths.categories, paths.enrich, paths.entries}, yearFiles(paths.entries)...)
+	for _, file := range files {
+		data, err := readData(file)
+		if errors.Is(err, os.ErrNotExist) { // Optionale Dateien dürfen fehlen.
+			continue
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		var value any
+		if err := json.Unmarshal(data, &value); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", file, err))
+		}
+	}
+	problems = append(problems, validatePrompts(paths.prompts)...)
+	problems = append(problems, validateEntries(loadEntries(paths.entries))...)
+	problems = append(problems, validateFeedFiles(paths)...) // feed.xml & Co.: Generierungsfehler vor dem Veröffentlichen finden.
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("validate: %d problems", len(problems))
+	}
+	fmt.Println("ok")
+	return nil
+}
+
+func validateEntries(entries []Entry) []string { // Pflichtfelder und gültige Zeitstempel (doppelte IDs verwirft schon loadEntries).
+	problems := []string{}
+	for i, entry := range entries {
+		ref := fmt.Sprintf("entry %d (%s)", i+1, entry.ID)
+		if entry.ID == "" {
+			problems = append(problems, fmt.Sprintf("entry %d: missing id", i+1))
+		}
+		if entry.Title == "" {
+			problems = append(problems, ref+": missing title")
+		}
+		if _, err := parseTime(entry.CreatedAt); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid created_at %q", ref, entry.CreatedAt))
+		}
+		for _, field := range []struct{ name, value string }{{"pinned_until", entry.PinnedUntil}, {"publish_at", entry.PublishAt}, {"dead_since", entry.DeadSince}, {"starts_at", entry.StartsAt}} {
+			if _, err := parseTime(field.value); field.value != "" && err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid %s %q", ref, field.name, field.value))
+			}
+		}
+	}
+	return problems
+}
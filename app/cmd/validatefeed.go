@@ -0,0 +1,184 @@
+package cmd // Paket "cmd": generierte RSS-Feeds gegen die Regeln von RSS 2.0 prüfen (Pflichtfelder, Datumsformat, eindeutige GUIDs, absolute Links).
+
+import ( // Import-Block: Standardbibliothek.
+	"bytes"        // Datei zeilenweise für den Kontext.
+	"encoding/xml" // Tokenweise parsen (mit Zeilennummern).
+	"errors"       // Fehlende Dateien erkennen.
+	"fmt"          // Meldungen.
+	"io"           // Ende des Dokuments.
+	"net/url"      // Absolute Links prüfen.
+	"os"           // Datei lesen.
+	"strings"      // Text trimmen.
+	"time"         // RFC1123Z.
+)
+
+type rssField struct { // Text eines Elements + Zeile, in der es beginnt.
+	text  string
+	line  int
+	attrs []xml.Attr
+}
+
+type rssProblem struct { // Ein Verstoß mit Zeile (0 = ohne Zeilenbezug).
+	line    int
+	message string
+}
+
+func validateFeedFiles(paths Paths) []string { // Alle generierten RSS-Feeds (feed.xml, Sprach-Feeds, abgeleitete Feeds).
+	problems := []string{}
+	for _, file := range rssFiles(paths) {
+		data, err := os.ReadFile(file)
+		if errors.Is(err, os.ErrNotExist) {
+			if file == paths.feed {
+				problems = append(problems, fmt.Sprintf("%s: not found (run build first)", file))
+			}
+			continue
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		lines := bytes.Split(data, []byte("\n"))
+		for _, problem := range validateRSS(data) {
+			message := fmt.Sprintf("%s: %s", file, problem.message)
+			if problem.line > 0 && problem.line <= len(lines) { // Mit Zeile + Quelltext, damit der Fehler im Generator schnell zu finden ist.
+				message = fmt.Sprintf("%s:%d: %s\n    | %s", file, problem.line, problem.message, strings.TrimSpace(string(lines[problem.line-1])))
+			}
+			problems = append(problems, message)
+		}
+	}
+	return problems
+}
+
+func validateRSS(data []byte) []rssProblem { // Prüft ein RSS-2.0-Dokument.
+	problems := []rssProblem{}
+	add := func(line int, format string, args ...any) {
+		problems = append(problems, rssProblem{line: line, message: fmt.Sprintf(format, args...)})
+	}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	stack := []string{}
+	channel := map[string]rssField{}
+	var item map[string]rssField
+	var text strings.Builder
+	var current *rssField
+	items, itemLine := 0, 0
+	guids := map[string]int{} // GUID → Zeile des ersten Vorkommens.
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		line, _ := decoder.InputPos()
+		if err != nil {
+			add(line, "invalid XML: %v", err)
+			return problems
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			name := token.Name.Local
+			if token.Name.Space != "" { // Erweiterungen (content:, dc:, media:, …) sind nicht Teil der Prüfung.
+				name = token.Name.Space + ":" + name
+			}
+			stack = append(stack, name)
+			switch {
+			case len(stack) == 1:
+				if name != "rss" {
+					add(line, "root element is <%s>, want <rss>", name)
+					return problems
+				}
+				if version := xmlAttr(token.Attr, "version"); version != "2.0" {
+					add(line, "rss version is %q, want \"2.0\"", version)
+				}
+			case len(stack) == 2 && name != "channel":
+				add(line, "unexpected <%s> outside <channel>", name)
+			case len(stack) == 3 && name == "item":
+				items++
+				item, itemLine = map[string]rssField{}, line
+			case len(stack) == 3 || (len(stack) == 4 && stack[2] == "item"):
+				text.Reset()
+				current = &rssField{line: line, attrs: token.Attr}
+			}
+		case xml.CharData:
+			if current != nil {
+				text.Write(token)
+			}
+		case xml.EndElement:
+			name := stack[len(stack)-1]
+			switch {
+			case len(stack) == 3 && name == "item":
+				validateItem(item, itemLine, items, guids, add)
+				item = nil
+			case current != nil && len(stack) == 4 && stack[2] == "item":
+				current.text = strings.TrimSpace(text.String())
+				item[name] = *current
+				current = nil
+			case current != nil && len(stack) == 3:
+				current.text = strings.TrimSpace(text.String())
+				channel[name] = *current
+				current = nil
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for _, name := range []string{"title", "link", "description"} { // Pflichtfelder des Channels.
+		if _, ok := channel[name]; !ok {
+			add(0, "channel: missing <%s>", name)
+		}
+	}
+	if field, ok := channel["link"]; ok && !absoluteURL(field.text) {
+		add(field.line, "channel: link %q is not an absolute URL", field.text)
+	}
+	for _, name := range []string{"lastBuildDate", "pubDate"} {
+		if field, ok := channel[name]; ok && !validRSSDate(field.text) {
+			add(field.line, "channel: invalid %s %q (want RFC1123Z, e.g. %q)", name, field.text, time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC).Format(time.RFC1123Z))
+		}
+	}
+	return problems
+}
+
+func validateItem(item map[string]rssField, line, number int, guids map[string]int, add func(int, string, ...any)) { // Ein <item>: Titel oder Beschreibung, Datum, Links, eindeutige GUID.
+	ref := fmt.Sprintf("item %d", number)
+	if item["title"].text == "" && item["description"].text == "" {
+		add(line, "%s: needs a <title> or <description>", ref)
+	}
+	if field, ok := item["link"]; ok && !absoluteURL(field.text) {
+		add(field.line, "%s: link %q is not an absolute URL", ref, field.text)
+	}
+	if field, ok := item["pubDate"]; ok && !validRSSDate(field.text) {
+		add(field.line, "%s: invalid pubDate %q (want RFC1123Z)", ref, field.text)
+	}
+	guid, ok := item["guid"]
+	if !ok {
+		guid, ok = item["id"] // Ältere Feeds dieses Projekts: <id> statt <guid>.
+	}
+	if !ok || guid.text == "" {
+		add(line, "%s: missing <guid>", ref)
+		return
+	}
+	if first, seen := guids[guid.text]; seen {
+		add(guid.line, "%s: duplicate guid %q (first on line %d)", ref, guid.text, first)
+	} else {
+		guids[guid.text] = guid.line
+	}
+	if xmlAttr(guid.attrs, "isPermaLink") != "false" && item["guid"].text != "" && !absoluteURL(guid.text) { // Ohne isPermaLink="false" muss die GUID eine URL sein.
+		add(guid.line, "%s: guid %q is a permalink but not an absolute URL (set isPermaLink=\"false\")", ref, guid.text)
+	}
+}
+
+func xmlAttr(attrs []xml.Attr, name string) string { // Wert eines Attributs ohne Namespace.
+	for _, attribute := range attrs {
+		if attribute.Name.Space == "" && attribute.Name.Local == name {
+			return attribute.Value
+		}
+	}
+	return ""
+}
+
+func absoluteURL(value string) bool { // http(s)-URL mit Host.
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+func validRSSDate(value string) bool { // RFC1123Z ("Mon, 02 Jan 2006 15:04:05 -0700"), wie buildFeed schreibt.
+	_, err := time.Parse(time.RFC1123Z, value)
+	return err == nil
+}
@@ -0,0 +1,62 @@
+package cmd // Paket "cmd": Versionsfilter für Release-Provider (nur stabile bzw. nur Major/Minor-Versionen).
+
+import ( // Import-Block: Standardbibliothek + internes Feed-Paket.
+	"fmt"     // Log-Ausgabe.
+	"regexp"  // Version im Titel finden.
+	"strconv" // Versionsteile parsen.
+	"strings" // Normalisieren.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+var versionPattern = regexp.MustCompile(`(?i)\bv?(\d+)\.(\d+)(?:\.(\d+))?(?:[-.+ ]?((?:alpha|beta|rc|release candidate|pre|preview|nightly|dev|snapshot)[\w.-]*))?`) // "6.5", "v17.0.0-rc.1", "6.5 RC2".
+
+type version struct { // Geparste Version aus einem Titel.
+	Major, Minor, Patch int    // Zahlenteile (fehlender Patch = 0).
+	Pre                 string // Pre-Release-Kennung ("rc.1", "beta2", …); leer = stabil.
+}
+
+func parseVersion(title string) (version, bool) { // Erste Versionsnummer im Titel; false, wenn keine gefunden wurde.
+	match := versionPattern.FindStringSubmatch(title)
+	if match == nil {
+		return version{}, false
+	}
+	v := version{Pre: strings.ToLower(match[4])}
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		v.Patch, _ = strconv.Atoi(match[3])
+	}
+	return v, true
+}
+
+func versionAllowed(settings ProviderSettings, title string) bool { // Prüft stable_only + versions gegen den Titel.
+	v, ok := parseVersion(title)
+	if !ok { // Ohne erkennbare Version: nur "nightly" im Titel zählt als instabil, sonst durchlassen.
+		return !settings.StableOnly || !strings.Contains(strings.ToLower(title), "nightly")
+	}
+	if settings.StableOnly && v.Pre != "" {
+		return false
+	}
+	switch strings.ToLower(settings.Versions) {
+	case "major": // Nur X.0.0.
+		return v.Minor == 0 && v.Patch == 0
+	case "minor": // Nur X.Y.0 (keine Patch-Releases).
+		return v.Patch == 0
+	}
+	return true
+}
+
+func applyVersionFilter(provider feedProvider) feedProvider { // Filtert Items nach den Versions-Einstellungen des Providers.
+	settings := provider.Settings
+	if !settings.StableOnly && settings.Versions == "" {
+		return provider
+	}
+	return withItemFilter(provider, func(item feed.Item) bool {
+		if versionAllowed(settings, item.Title) {
+			return false
+		}
+		fmt.Printf("skipped %s: %q (version filter)\n", provider.Name, item.Title)
+		return true
+	})
+}
@@ -0,0 +1,48 @@
+package cmd // Paket "cmd": Watermarks pro Provider – mehrere neue Items pro Lauf statt nur des neuesten.
+
+import ( // Import-Block: Standardbibliothek + internes feed-Paket.
+	"sort" // Chronologische Reihenfolge.
+	"time" // Watermark-Zeitpunkte.
+
+	"wapuugotchi/feed/app/feed"
+)
+
+func addNew(provider feedProvider, entries *[]Entry, watermarks map[string]string, known ...[]Entry) (bool, error) { // Übernimmt alle Items seit der Watermark (älteste zuerst) und schiebt die Watermark weiter.
+	items, err := provider.FetchNew(provider.fetch, provider.since)
+	if err != nil {
+		return false, err // Watermark bleibt: der nächste Lauf holt dieselben Items erneut.
+	}
+	sort.SliceStable(items, func(i, j int) bool { return pickEntryTime(items[i]) < pickEntryTime(items[j]) }) // Chronologisch: ältere Posts bekommen auch die ältere Position.
+	added := false
+	newest := provider.since
+	for _, item := range items {
+		if addItem(provider, item, entries, known...) {
+			added = true
+		}
+		if published, ok := feed.ItemTime(item); ok && published.After(newest) { // Auch gefilterte/bekannte Items zählen: sie sollen nicht erneut geholt werden.
+			newest = published
+		}
+	}
+	if newest.After(provider.since) {
+		watermarks[provider.Name] = newest.UTC().Format(time.RFC3339)
+	}
+	return added, nil
+}
+
+func saveWatermarks(path string, watermarks map[string]string) { // Schreibt die Watermarks in state.json (nur bei Änderung).
+	state := loadState(path)
+	if len(watermarks) == len(state.Watermarks) {
+		changed := false
+		for name, value := range watermarks {
+			if state.Watermarks[name] != value {
+				changed = true
+				break
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+	state.Watermarks = watermarks
+	saveState(path, state)
+}
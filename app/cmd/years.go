@@ -0,0 +1,67 @@
+package cmd // Paket "cmd": entries.json pro Jahr aufteilen (entries-2024.json, entries-2025.json, …).
+
+import ( // Import-Block: Standardbibliothek.
+	"fmt"           // Stderr-Ausgabe.
+	"os"            // Dateien entfernen.
+	"path/filepath" // Glob + Dateinamen.
+	"sort"          // Jahre sortieren.
+	"strings"       // Dateinamen zerlegen.
+)
+
+func yearFiles(path string) []string { // Alle vorhandenen Jahresdateien neben entries.json, aufsteigend nach Jahr.
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	files := globData(base + "-[0-9][0-9][0-9][0-9]" + filepath.Ext(path))
+	sort.Strings(files)
+	return files
+}
+
+func yearFile(path, year string) string { // data/entries.json + "2025" → data/entries-2025.json.
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-" + year + ext
+}
+
+func entryYear(entry Entry) string { // Jahr aus CreatedAt; leer, wenn es kein gültiger Zeitstempel ist.
+	if created, err := parseTime(entry.CreatedAt); err == nil {
+		return fmt.Sprint(created.UTC().Year())
+	}
+	return ""
+}
+
+func loadYearEntries(path string) []Entry { // Liest entries.json (Altbestand/undatiert) + alle Jahresdateien als eine Liste.
+	entries := []Entry{}
+	seen := map[string]bool{}
+	for _, file := range append([]string{path}, yearFiles(path)...) {
+		part := []Entry{}
+		readJSON(file, &part)
+		for _, entry := range part {
+			if seen[entry.ID] { // Beim Umzug abgebrochen: doppelt vorhandene Entries nur einmal.
+				continue
+			}
+			seen[entry.ID] = true
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func saveYearEntries(path string, entries []Entry) { // Schreibt jeden Entry in die Datei seines Jahres; undatierte bleiben in entries.json.
+	groups := map[string][]Entry{}
+	for _, entry := range entries {
+		file := path
+		if year := entryYear(entry); year != "" {
+			file = yearFile(path, year)
+		}
+		groups[file] = append(groups[file], entry)
+	}
+	for _, file := range append([]string{path}, yearFiles(path)...) { // Dateien ohne Entries (alte Gesamtdatei, geleerte Jahre) aufräumen.
+		if _, used := groups[file]; used {
+			continue
+		}
+		if err := removeData(file); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	for file, group := range groups {
+		writeJSON(file, group)
+	}
+}
@@ -0,0 +1,271 @@
+package main // Paket "main": Subcommands (feed update, feed build, …); die alten Flags in main.go bleiben gültig.
+
+import ( // Import-Block: Standardbibliothek + internes cmd-Paket.
+	"context"   // Abbruch per Signal.
+	"errors"    // errNoUpdate erkennen.
+	"flag"      // Eigenes FlagSet pro Subcommand.
+	"fmt"       // Usage + Fehlerausgabe.
+	"os"        // Exit-Codes.
+	"os/signal" // SIGINT/SIGTERM abfangen.
+	"syscall"   // SIGTERM (CI-Abbruch).
+	"time"      // -jitter.
+
+	"wapuugotchi/feed/app/cmd"
+)
+
+const exitNoUpdate = 3 // -changed-exit-code: Update ohne Änderungen (0 = aktualisiert, 1 = Fehler, 2 = falscher Aufruf).
+
+var errNoUpdate = errors.New("no update") // runUpdate mit -changed-exit-code: nichts geändert → exitNoUpdate.
+
+type command struct { // Ein Subcommand: Name, Kurzbeschreibung und Ausführung mit eigenen Flags.
+	name    string
+	summary string
+	run     func(flags *flag.FlagSet, args []string) error
+}
+
+var commands = []command{ // Reihenfolge = Reihenfolge in der Hilfe (entspricht der Pipeline).
+	{name: "update", summary: "Fetch all providers, add new entries, build and publish the feeds", run: runUpdate},
+	{name: "build", summary: "Rebuild feed.xml and derived feeds from the stored entries (no fetch, no publish)", run: runBuild},
+	{name: "artifacts", summary: "Print the generated files (feeds, signatures, header sidecars) relative to the project root, one per line", run: runArtifacts},
+	{name: "validate", summary: "Check data/*.json, the stored entries and the generated RSS feeds (RSS 2.0 rules)", run: runValidate},
+	{name: "add-entry", summary: "Add a hand-written entry (announcement) to entries.json and rebuild the feeds", run: runAddEntry},
+	{name: "edit", summary: "Correct a stored entry by ID (flags, or $EDITOR without flags) and rebuild the feeds", run: runEdit},
+	{name: "remove", summary: "Take down a stored entry by ID and rebuild the feeds; it is never fetched again", run: runRemove},
+	{name: "list", summary: "Show the stored entries", run: runList},
+	{name: "prune", summary: "Apply the retention policy (FEED_RETENTION_*) and rebuild the feeds", run: runPrune},
+	{name: "migrate", summary: "Copy the JSON files in data/ into another store backend (FEED_STORE)", run: runMigrate},
+	{name: "daemon", summary: "Run update continuously every -interval (per provider: \"interval\" in the settings) until SIGINT/SIGTERM", run: runDaemon},
+	{name: "serve", summary: "Serve feed.xml, feed.json & co. with caching headers and an HTML preview of the entries", run: runServe},
+}
+
+func runCommand(name string, args []string) { // Führt den Subcommand aus; unbekannte Namen → Hilfe + Exit-Code 2.
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		flags := flag.NewFlagSet(c.name, flag.ExitOnError)
+		pathFlags(flags)
+		flags.Usage = func() {
+			fmt.Fprintf(flags.Output(), "Usage: %s %s [flags]\n\n%s\n", os.Args[0], c.name, c.summary)
+			flags.PrintDefaults()
+		}
+		if err := c.run(flags, args); errors.Is(err, errNoUpdate) {
+			os.Exit(exitNoUpdate)
+		} else if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", name)
+	printCommands()
+	os.Exit(2)
+}
+
+func printCommands() { // Liste der Subcommands (für -h und unbekannte Namen).
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", os.Args[0])
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", c.name, c.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun '%s <command> -h' for the flags of a command.\n", os.Args[0])
+}
+
+func pathFlags(flags *flag.FlagSet) { // Orte für data/ und feed.xml; für alle Kommandos gleich.
+	flags.Func("data-dir", "Directory with site.json, entries.json, state.json, … (default ./data; same as FEED_DATA_DIR)", func(dir string) error {
+		cmd.SetDataDir(dir)
+		return nil
+	})
+	flags.Func("output", "Path of feed.xml or directory for all generated files (default ./feed.xml; same as FEED_OUTPUT)", func(path string) error {
+		cmd.SetOutput(path)
+		return nil
+	})
+}
+
+func outputFlags(flags *flag.FlagSet) func() error { // Gemeinsame Output-Flags für Kommandos, die Feeds bauen.
+	atom := flags.Bool("atom", false, "Also write Atom 1.0 feeds (atom.xml next to feed.xml); same as FEED_ATOM=true")
+	debugHTTP := flags.Bool("debug-http", false, "Dump HTTP requests/responses with secrets redacted to FEED_DEBUG_HTTP_DIR (default debug-http/)")
+	return func() error { // Nach flags.Parse aufrufen.
+		if *atom {
+			cmd.EnableAtom()
+		}
+		if *debugHTTP {
+			return cmd.EnableDebugHTTP()
+		}
+		return nil
+	}
+}
+
+func runUpdate(flags *flag.FlagSet, args []string) error {
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+	dryRun := flags.Bool("dry-run", false, "Fetch and process all providers, print what would change, but write no files (state.json, entries.json, feed.xml)")
+	report := flags.String("report", "", "Write a JSON summary of the run (providers, new entry IDs, durations, errors) to this file")
+	changedExitCode := flags.Bool("changed-exit-code", false, "Exit with code 3 when nothing changed (0 = updated, 1 = error), so CI can skip the commit step")
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	cmd.SetReport(*report)
+	ctx, stop := signalContext()
+	defer stop()
+	changed, err := cmd.RunFeedUpdate(ctx, *verbose, *dryRun)
+	if err == nil && !changed && *changedExitCode {
+		return errNoUpdate
+	}
+	return err
+}
+
+func signalContext() (context.Context, context.CancelFunc) { // SIGINT/SIGTERM bricht den Lauf vor dem Schreiben ab; ein zweites Signal beendet sofort.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop() // Standardverhalten wiederherstellen.
+	}()
+	return ctx, stop
+}
+
+func runBuild(flags *flag.FlagSet, args []string) error {
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	return cmd.RunBuild()
+}
+
+func runArtifacts(flags *flag.FlagSet, args []string) error {
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	return cmd.RunArtifacts()
+}
+
+func runValidate(flags *flag.FlagSet, args []string) error {
+	flags.Parse(args)
+	return cmd.RunValidate()
+}
+
+func runAddEntry(flags *flag.FlagSet, args []string) error {
+	var entry cmd.Entry
+	flags.StringVar(&entry.Title, "title", "", "Title of the entry (required)")
+	flags.StringVar(&entry.Link, "link", "", "Absolute link to the announcement")
+	flags.StringVar(&entry.Content, "content", "", "HTML content (sanitized like fetched content)")
+	flags.Func("category", "Category (repeatable)", func(category string) error {
+		entry.Categories = append(entry.Categories, category)
+		return nil
+	})
+	fromFile := flags.String("from-file", "", "Read the entry from a JSON file (same fields as entries.json); flags override its values")
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	return cmd.RunAddEntry(entry, *fromFile)
+}
+
+func runEdit(flags *flag.FlagSet, args []string) error {
+	var changes cmd.EntryChanges
+	flags.Func("title", "New title", func(value string) error { changes.Title = &value; return nil })
+	flags.Func("link", "New absolute link", func(value string) error { changes.Link = &value; return nil })
+	flags.Func("content", "New HTML content (sanitized like fetched content)", func(value string) error { changes.Content = &value; return nil })
+	flags.Func("category", "Category (repeatable; replaces all categories)", func(value string) error {
+		changes.Categories = append(changes.Categories, value)
+		return nil
+	})
+	flags.Func("created-at", "New publication time (RFC3339)", func(value string) error { changes.CreatedAt = &value; return nil })
+	id, err := entryID(flags, args)
+	if err != nil {
+		return err
+	}
+	return cmd.RunEditEntry(id, changes)
+}
+
+func runRemove(flags *flag.FlagSet, args []string) error {
+	id, err := entryID(flags, args)
+	if err != nil {
+		return err
+	}
+	return cmd.RunRemoveEntry(id)
+}
+
+func entryID(flags *flag.FlagSet, args []string) (string, error) { // "edit <id> [flags]" bzw. "edit [flags] <id>": die ID ist das einzige Argument.
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s %s <id> [flags]\n\n", os.Args[0], flags.Name())
+		flags.PrintDefaults()
+	}
+	apply := outputFlags(flags)
+	rest := []string{}
+	for len(args) > 0 { // Flags vor und nach der ID erlauben.
+		flags.Parse(args)
+		if args = flags.Args(); len(args) > 0 {
+			rest, args = append(rest, args[0]), args[1:]
+		}
+	}
+	if len(rest) != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	return rest[0], apply()
+}
+
+func runList(flags *flag.FlagSet, args []string) error {
+	pending := flags.Bool("pending", false, "Show entries waiting for approval (FEED_MODERATION) instead")
+	flags.Parse(args)
+	if *pending {
+		return cmd.RunListPending()
+	}
+	cmd.RunListItems()
+	return nil
+}
+
+func runPrune(flags *flag.FlagSet, args []string) error {
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	return cmd.RunPrune()
+}
+
+func runMigrate(flags *flag.FlagSet, args []string) error {
+	to := flags.String("to", "", "Target store backend (default: the one compiled in, e.g. sqlite with -tags sqlite)")
+	flags.Parse(args)
+	return cmd.RunMigrateStore(*to)
+}
+
+func runDaemon(flags *flag.FlagSet, args []string) error {
+	interval := flags.String("interval", "", "Time between two runs, e.g. 30m (default 30m; same as FEED_DAEMON_INTERVAL); providers may set their own \"interval\"")
+	jitter := flags.Duration("jitter", time.Minute, "Add a random delay up to this duration to each provider's next fetch")
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+	report := flags.String("report", "", "Write a JSON summary of the latest run to this file")
+	addr := flags.String("addr", "", "Also serve the feeds, the preview and /metrics on this address, e.g. 127.0.0.1:8080 (default off; same as FEED_DAEMON_ADDR)")
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	every, err := cmd.DaemonInterval(*interval)
+	if err != nil {
+		return err
+	}
+	cmd.SetReport(*report)
+	ctx, stop := signalContext()
+	defer stop()
+	return cmd.RunDaemon(ctx, *addr, every, *jitter, *verbose)
+}
+
+func runServe(flags *flag.FlagSet, args []string) error {
+	addr := flags.String("addr", "", "Listen address (default 127.0.0.1:8080; same as FEED_SERVE_ADDR)")
+	watch := flags.Bool("watch", false, "Development: rebuild on data/config changes and live-reload the browser (FEED_PREVIEW_ADDR)")
+	apply := outputFlags(flags)
+	flags.Parse(args)
+	if err := apply(); err != nil {
+		return err
+	}
+	if *watch {
+		return cmd.RunPreview()
+	}
+	return cmd.RunServe(*addr)
+}
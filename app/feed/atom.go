@@ -0,0 +1,159 @@
+package feed // Paket "feed": Atom-1.0-Parser + Formaterkennung (RSS oder Atom) anhand des Root-Elements.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"bytes"        // Decoder über den Body.
+	"encoding/xml" // Atom-XML parsen.
+	"errors"       // io.EOF erkennen.
+	"fmt"          // Fehlertexte.
+	"html"         // Text-Inhalte als HTML escapen.
+	"io"           // io.EOF.
+	"strings"      // Trimmen.
+	"time"         // Datumsformat (RFC3339 → RFC1123Z).
+)
+
+type atomDocument struct { // Atom 1.0 (RFC 4287), reduziert auf das, was wir als Item brauchen.
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct { // Ein <entry>.
+	Title atomText `xml:"title"` // Titel.
+	Links []struct {
+		Rel  string `xml:"rel,attr"`  // "alternate" (Default), "enclosure", "self", …
+		Href string `xml:"href,attr"` // Ziel-URL.
+		Type string `xml:"type,attr"` // MIME-Type (bei Anhängen).
+	} `xml:"link"`
+	Published  string   `xml:"published"` // Erstveröffentlichung (RFC3339).
+	Updated    string   `xml:"updated"`   // Letzte Änderung (RFC3339, Pflichtfeld).
+	Summary    atomText `xml:"summary"`   // Auszug.
+	Content    atomText `xml:"content"`   // Vollinhalt.
+	Categories []struct {
+		Term string `xml:"term,attr"` // Kategorie.
+	} `xml:"category"`
+	Authors []struct {
+		Name string `xml:"name"` // Name der Autor:in.
+	} `xml:"author"`
+	Thumbnails []mediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"` // <media:thumbnail> direkt am Entry.
+	Group      struct {
+		Thumbnails  []mediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`   // z.B. YouTube: Vorschaubild in <media:group>.
+		Description string           `xml:"http://search.yahoo.com/mrss/ description"` // z.B. YouTube: Videobeschreibung (Text).
+	} `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+type atomText struct { // Atom-Textkonstrukt: type="text" (Default), "html" oder "xhtml".
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"` // text/html.
+	Inner string `xml:",innerxml"` // xhtml: <div xmlns="…">…</div>.
+}
+
+func ParseFeed(body []byte) ([]Item, error) { // Erkennt das Format am Root-Element (<rss> oder <feed>) und parst entsprechend.
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.Strict = false                // Nur das Root-Element interessiert; kaputte Entities weiter hinten meldet erst der eigentliche Parser.
+	decoder.CharsetReader = CharsetReader // <?xml encoding="ISO-8859-1"?> & Co.
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse feed: no root element")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse feed: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok { // XML-Deklaration, Kommentare, Whitespace, Stylesheets …
+			continue
+		}
+		switch start.Name.Local {
+		case "rss":
+			return ParseRSS(body)
+		case "feed":
+			return ParseAtom(body)
+		}
+		return nil, fmt.Errorf("parse feed: unsupported root element <%s>", start.Name.Local)
+	}
+}
+
+func ParseAtom(body []byte) ([]Item, error) { // Parst ein Atom-1.0-Dokument in Items (gleiche Felder wie ParseRSS).
+	var document atomDocument
+	if err := decodeXML(body, &document); err != nil {
+		return nil, fmt.Errorf("parse atom: %w", err)
+	}
+	items := make([]Item, 0, len(document.Entries))
+	for _, entry := range document.Entries {
+		summary := entry.Summary.html()
+		if summary == "" && entry.Group.Description != "" {
+			summary = html.EscapeString(strings.TrimSpace(entry.Group.Description))
+		}
+		content := entry.Content.html() // Vollinhalt bevorzugen…
+		if content == "" {
+			content = summary // …sonst den Auszug.
+		}
+		item := Item{
+			Title:     strings.TrimSpace(entry.Title.Text),
+			Link:      atomLink(entry),
+			PubDate:   atomDate(entry.Published, entry.Updated),
+			Summary:   summary,
+			Content:   content,
+			Thumbnail: firstThumbnail(entry.Thumbnails, entry.Group.Thumbnails),
+			Image:     atomImage(entry),
+		}
+		if len(entry.Authors) > 0 {
+			item.Author = strings.TrimSpace(entry.Authors[0].Name)
+		}
+		for _, category := range entry.Categories {
+			if term := strings.TrimSpace(category.Term); term != "" {
+				item.Categories = append(item.Categories, term)
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (text atomText) html() string { // Inhalt als HTML: html unverändert, xhtml als Markup, text escaped.
+	switch strings.ToLower(strings.TrimSpace(text.Type)) {
+	case "html", "text/html":
+		return strings.TrimSpace(text.Text)
+	case "xhtml":
+		return strings.TrimSpace(text.Inner)
+	}
+	return html.EscapeString(strings.TrimSpace(text.Text))
+}
+
+func atomLink(entry atomEntry) string { // rel="alternate" (bzw. ohne rel) ist der Link zum Beitrag.
+	for _, link := range entry.Links {
+		if rel := strings.TrimSpace(link.Rel); rel == "" || rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+func atomImage(entry atomEntry) string { // Bild-Anhang (rel="enclosure" mit image/*) als Beitragsbild.
+	for _, link := range entry.Links {
+		if link.Rel == "enclosure" && strings.HasPrefix(link.Type, "image/") {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+func atomDate(published, updated string) string { // published (sonst updated) als RFC1123Z wie bei RSS; Unparsbares bleibt roh.
+	value := strings.TrimSpace(published)
+	if value == "" {
+		value = strings.TrimSpace(updated)
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.Format(time.RFC1123Z)
+	}
+	return value
+}
+
+func firstThumbnail(lists ...[]mediaThumbnail) string { // Erste nicht-leere Thumbnail-URL.
+	for _, list := range lists {
+		for _, thumbnail := range list {
+			if url := strings.TrimSpace(thumbnail.URL); url != "" {
+				return url
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package feed // Tests für ParseAtom (Textkonstrukte, Links, Datum, YouTube-Media) und die Formaterkennung von ParseFeed.
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const atomHeader = `<?xml version="1.0" encoding="utf-8"?><feed xmlns="http://www.w3.org/2005/Atom" xmlns:media="http://search.yahoo.com/mrss/"><title>T</title>`
+
+func TestParseAtom(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries string
+		want    []Item
+	}{
+		{"keine Entries", ``, []Item{}},
+		{
+			"html-Content, alternate-Link, published",
+			`<entry><title>A</title><link rel="self" href="https://example.com/self"/><link href="https://example.com/a"/><published>2024-05-01T12:00:00Z</published><updated>2024-05-02T12:00:00Z</updated><summary>Kurz &amp; knapp</summary><content type="html">&lt;p&gt;Lang&lt;/p&gt;</content><category term="News"/><category term=" "/><author><name>Ada</name></author><author><name>Bob</name></author></entry>`,
+			[]Item{{Title: "A", Link: "https://example.com/a", PubDate: "Wed, 01 May 2024 12:00:00 +0000", Summary: "Kurz &amp; knapp", Content: "<p>Lang</p>", Categories: []string{"News"}, Author: "Ada"}},
+		},
+		{
+			"xhtml-Content, updated als Datum",
+			`<entry><title>X</title><link rel="alternate" href="https://example.com/x"/><updated>2024-05-02T14:00:00+02:00</updated><content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Hallo</p></div></content></entry>`,
+			[]Item{{Title: "X", Link: "https://example.com/x", PubDate: "Thu, 02 May 2024 14:00:00 +0200", Content: `<div xmlns="http://www.w3.org/1999/xhtml"><p>Hallo</p></div>`}},
+		},
+		{
+			"Text wird escaped, Summary ersetzt fehlenden Content",
+			`<entry><title>S</title><updated>gestern</updated><summary>a &lt; b</summary></entry>`,
+			[]Item{{Title: "S", PubDate: "gestern", Summary: "a &lt; b", Content: "a &lt; b"}},
+		},
+		{
+			"YouTube: media:group",
+			`<entry><title>V</title><link rel="alternate" href="https://www.youtube.com/watch?v=x"/><link rel="enclosure" type="image/jpeg" href="https://example.com/e.jpg"/><media:group><media:thumbnail url="https://i.ytimg.com/x.jpg"/><media:description>Video &amp; mehr</media:description></media:group></entry>`,
+			[]Item{{Title: "V", Link: "https://www.youtube.com/watch?v=x", Summary: "Video &amp; mehr", Content: "Video &amp; mehr", Thumbnail: "https://i.ytimg.com/x.jpg", Image: "https://example.com/e.jpg"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := ParseAtom([]byte(atomHeader + test.entries + `</feed>`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, test.want) {
+				t.Errorf("ParseAtom = %+v, want %+v", items, test.want)
+			}
+		})
+	}
+}
+
+func TestParseFeed(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantTitle string // Titel des ersten Items.
+		wantErr   string
+	}{
+		{"RSS", rssHeader + `<item><title>R</title></item></channel></rss>`, "R", ""},
+		{"Atom", atomHeader + `<entry><title>A</title></entry></feed>`, "A", ""},
+		{"Stylesheet + Kommentar vor dem Root", `<?xml version="1.0"?><?xml-stylesheet href="s.xsl"?><!-- x --><rss><channel><item><title>R</title></item></channel></rss>`, "R", ""},
+		{"Latin-1-Deklaration", "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rss><channel><item><title>M\xfcnchen</title></item></channel></rss>", "München", ""},
+		{"HTML statt Feed", `<html><body>Fehler</body></html>`, "", "unsupported root element <html>"},
+		{"leer", ``, "", "no root element"},
+		{"unbekannter Zeichensatz", `<?xml version="1.0" encoding="koi8-r"?><rss></rss>`, "", "unsupported charset"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := ParseFeed([]byte(test.body))
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("ParseFeed error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) == 0 || items[0].Title != test.wantTitle {
+				t.Errorf("ParseFeed = %+v, want first title %q", items, test.wantTitle)
+			}
+		})
+	}
+}
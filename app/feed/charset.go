@@ -0,0 +1,62 @@
+package feed // Paket "feed": Feeds in ISO-8859-1, windows-1252 oder ISO-8859-15 lesen (encoding/xml kann von sich aus nur UTF-8).
+
+import ( // Import-Block: Standardbibliothek.
+	"bytes"        // Body als Reader + Puffer für UTF-8.
+	"encoding/xml" // Decoder mit CharsetReader.
+	"fmt"          // Fehlertexte.
+	"io"           // Reader.
+	"strings"      // Labels normalisieren.
+	"unicode/utf8" // Runen kodieren.
+)
+
+var windows1252 = [32]rune{ // 0x80–0x9F; der Rest entspricht ISO-8859-1 (= Unicode U+0000–U+00FF).
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8D, 'Ž', 0x8F,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9D, 'ž', 'Ÿ',
+}
+
+var latin9 = map[byte]rune{0xA4: '€', 0xA6: 'Š', 0xA8: 'š', 0xB4: 'Ž', 0xB8: 'ž', 0xBC: 'Œ', 0xBD: 'œ', 0xBE: 'Ÿ'} // ISO-8859-15: Abweichungen von ISO-8859-1.
+
+func CharsetReader(label string, input io.Reader) (io.Reader, error) { // Für xml.Decoder.CharsetReader: wandelt nach UTF-8; unbekannte Zeichensätze sind ein Fehler.
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "utf-8", "utf8":
+		return input, nil
+	case "iso-8859-1", "iso8859-1", "latin1", "l1", "windows-1252", "cp1252", "us-ascii", "ascii": // Wie Browser: ISO-8859-1 als windows-1252 lesen (Server deklarieren oft falsch).
+		return singleByteReader(input, func(b byte) rune {
+			if b >= 0x80 && b <= 0x9F {
+				return windows1252[b-0x80]
+			}
+			return rune(b)
+		})
+	case "iso-8859-15", "iso8859-15", "latin9", "latin-9":
+		return singleByteReader(input, func(b byte) rune {
+			if r, ok := latin9[b]; ok {
+				return r
+			}
+			return rune(b)
+		})
+	}
+	return nil, fmt.Errorf("unsupported charset %q", label)
+}
+
+func singleByteReader(input io.Reader, decode func(byte) rune) (io.Reader, error) { // Ein Byte = ein Zeichen → UTF-8 (der Body liegt ohnehin komplett im Speicher).
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	out.Grow(len(data))
+	for _, b := range data {
+		if b < utf8.RuneSelf {
+			out.WriteByte(b)
+			continue
+		}
+		out.WriteRune(decode(b))
+	}
+	return &out, nil
+}
+
+func decodeXML(body []byte, target any) error { // Wie xml.Unmarshal, aber mit Zeichensatz aus der XML-Deklaration.
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = CharsetReader
+	return decoder.Decode(target)
+}
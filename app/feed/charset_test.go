@@ -0,0 +1,74 @@
+package feed // Tests für CharsetReader/decodeXML: ISO-8859-1, windows-1252 und ISO-8859-15 nach UTF-8.
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestCharsetReader(t *testing.T) {
+	tests := []struct {
+		name    string
+		label   string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"UTF-8 unverändert", "UTF-8", "Grüße €", "Grüße €", false},
+		{"Latin-1", "ISO-8859-1", "Gr\xfc\xdfe", "Grüße", false},
+		{"Latin-1 wie windows-1252", "iso-8859-1", "\x93Zitat\x94 \x96 \x80", "“Zitat” – €", false},
+		{"windows-1252", "windows-1252", "\x85 \x99", "… ™", false},
+		{"undefinierte windows-1252-Bytes", "cp1252", "\x81\x9d", "\u0081\u009d", false},
+		{"ISO-8859-15", "ISO-8859-15", "\xa4 \xbd \xe9", "€ œ é", false},
+		{"ASCII", "us-ascii", "plain", "plain", false},
+		{"Label mit Leerzeichen und Großbuchstaben", " Latin1 ", "\xe4", "ä", false},
+		{"unbekannt", "koi8-r", "x", "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader, err := CharsetReader(test.label, strings.NewReader(test.input))
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("CharsetReader(%q) succeeded, want error", test.label)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("CharsetReader(%q) = %q, want %q", test.label, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeXML(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"ohne Deklaration", `<t>Grüße</t>`, "Grüße"},
+		{"UTF-8", `<?xml version="1.0" encoding="UTF-8"?><t>Grüße</t>`, "Grüße"},
+		{"windows-1252", "<?xml version=\"1.0\" encoding=\"windows-1252\"?><t>\x84Gr\xfc\xdfe\x93</t>", "„Grüße“"},
+		{"ISO-8859-15", "<?xml version='1.0' encoding='ISO-8859-15'?><t>5 \xa4</t>", "5 €"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var target struct {
+				Text string `xml:",chardata"`
+			}
+			if err := decodeXML([]byte(test.body), &target); err != nil {
+				t.Fatal(err)
+			}
+			if target.Text != test.want {
+				t.Errorf("decodeXML = %q, want %q", target.Text, test.want)
+			}
+		})
+	}
+}
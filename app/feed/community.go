@@ -0,0 +1,110 @@
+package feed // Paket "feed": Community-Blogs von WordPress.org (Five for the Future & Co.).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Feed-URL pro Team.
+	"net/url" // Team-Namen in der URL escapen.
+	"regexp"  // "appeared first on"-Absatz entfernen.
+	"strings" // Trimmen.
+	"time"    // Watermark: nur Beiträge nach diesem Zeitpunkt.
+)
+
+const fiveForTheFutureFeedURL = "https://wordpress.org/five-for-the-future/feed/" // Blog des Five-for-the-Future-Programms.
+const wpTavernFeedURL = "https://wptavern.com/feed"                               // WP Tavern (News aus dem WordPress-Ökosystem).
+const polyglotsFeedURL = "https://make.wordpress.org/polyglots/feed/"             // Make Polyglots (Übersetzungs-Community).
+const mattFeedURL = "https://ma.tt/feed/"                                         // Blog von Matt Mullenweg (privat + WordPress).
+const makeFeedURL = "https://make.wordpress.org/%s/feed/"                         // Make-Blog eines Teams (core, design, community, …).
+
+var appearedFirstPattern = regexp.MustCompile(`(?is)<p>\s*The post\b.*?appeared first on\b.*?</p>`) // WordPress-Standardzeile am Ende der Description.
+
+func LatestFiveForTheFuture(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert die Beiträge des Five-for-the-Future-Blogs seit since (ohne since: den neuesten).
+	return wordPressPostsSince(fetch, fiveForTheFutureFeedURL, "five for the future", false, since)
+}
+
+func LatestWPTavern(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert die WP-Tavern-Artikel seit since (ohne since: den neuesten) mit Vollinhalt (content:encoded).
+	return wordPressPostsSince(fetch, wpTavernFeedURL, "wp tavern", true, since)
+}
+
+func LatestPolyglots(locales []string) func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert die neuen Polyglots-Beiträge, die mit einer der Locales getaggt sind (z.B. "de" passt auf "de_DE"); Language wird auf die Locale gesetzt.
+	return func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+		items, err := wordPressPostsSince(fetch, polyglotsFeedURL, "make polyglots", true, since)
+		if err != nil {
+			return nil, err
+		}
+		matching := []Item{}
+		for _, item := range items {
+			if locale := matchLocale(locales, item.Categories); locale != "" { // Ohne passende Locale: für diese Installation irrelevant.
+				item.Language = locale
+				matching = append(matching, item)
+			}
+		}
+		return matching, nil
+	}
+}
+
+func LatestMakeBlog(team string) func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert die neuen Beiträge des Make-Blogs von team (z.B. "core") mit Vollinhalt; das Team wird als erste Kategorie gesetzt.
+	return func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+		items, err := wordPressPostsSince(fetch, fmt.Sprintf(makeFeedURL, url.PathEscape(team)), "make "+team, true, since)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			items[i].Categories = append([]string{"make " + team}, items[i].Categories...)
+		}
+		return items, nil
+	}
+}
+
+func MattPosts(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+	// Liefert alle aktuellen Beiträge von ma.tt; welche davon in den Feed kommen, entscheidet der Keyword-Filter des Providers.
+	return wordPressPosts(fetch, mattFeedURL, "ma.tt", true)
+}
+
+func matchLocale(locales, tags []string) string { // Erste konfigurierte Locale, zu der ein Tag passt ("de_DE", "de-de" und "de" passen auf "de").
+	for _, locale := range locales {
+		want := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+		for _, tag := range tags {
+			have := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+			base, _, _ := strings.Cut(have, "-")
+			if want != "" && (have == want || base == want) {
+				return strings.TrimSpace(locale)
+			}
+		}
+	}
+	return ""
+}
+
+func latestWordPressPost(fetch func(url, source string) ([]byte, error), feedURL, source string, full bool) (Item, error) {
+	// Gemeinsamer Fetcher für WordPress-Blogs: neuestes Item (RSS ist absteigend sortiert).
+	items, err := wordPressPosts(fetch, feedURL, source, full)
+	if err != nil || len(items) == 0 {
+		return Item{}, err
+	}
+	return items[0], nil
+}
+
+func wordPressPosts(fetch func(url, source string) ([]byte, error), feedURL, source string, full bool) ([]Item, error) {
+	// Alle Items eines WordPress-Blogs, ohne Skripte und ohne "appeared first on"; full bevorzugt content:encoded.
+	return AllRSSItems(feedURL, source, wordPressHooks(full)...)(fetch)
+}
+
+func wordPressPostsSince(fetch func(url, source string) ([]byte, error), feedURL, source string, full bool, since time.Time) ([]Item, error) {
+	// Wie wordPressPosts, aber nur Items nach since (ohne since: das neueste).
+	return RSSItemsSince(feedURL, source, wordPressHooks(full)...)(fetch, since)
+}
+
+func wordPressHooks(full bool) []Transformer { // Standardbereinigung für WordPress-Blogs; ohne full nur der Auszug.
+	hooks := []Transformer{stripScripts, stripAppearedFirst}
+	if !full {
+		hooks = append([]Transformer{summaryOnly}, hooks...)
+	}
+	return hooks
+}
+
+func LatestRSS(feedURL string, hooks ...Transformer) func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert einen Fetcher für die neuen Items eines beliebigen RSS-2.0- oder Atom-Feeds (Typ "rss" in sources.json); hooks laufen nach der Standardbereinigung.
+	return RSSItemsSince(feedURL, "rss", append([]Transformer{stripScripts, stripAppearedFirst}, hooks...)...)
+}
@@ -0,0 +1,51 @@
+package feed // Paket "feed": do_action Charity-Hackathons als Event-Items.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"regexp"  // Datum im Text finden.
+	"strings" // Trimmen/Normalisieren.
+	"time"    // Datum parsen.
+)
+
+const doActionFeedURL = "https://doaction.org/feed/" // Ankündigungen der do_action Hackathons.
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]+>`) // HTML-Tags (für die Datumssuche im reinen Text).
+
+var eventDatePatterns = []struct { // Übliche Datumsschreibweisen in Ankündigungen (englisch), mit passendem Layout.
+	pattern *regexp.Regexp
+	layout  string
+}{
+	{regexp.MustCompile(`\b(\d{1,2})(?:st|nd|rd|th)? (January|February|March|April|May|June|July|August|September|October|November|December),? (\d{4})\b`), "2 January 2006"},
+	{regexp.MustCompile(`\b(January|February|March|April|May|June|July|August|September|October|November|December) (\d{1,2})(?:st|nd|rd|th)?,? (\d{4})\b`), "January 2 2006"},
+	{regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`), "2006-01-02"},
+}
+
+func LatestDoAction(fetch func(url, source string) ([]byte, error)) (Item, error) {
+	// Liefert die neueste do_action-Ankündigung als Event; das Datum wird aus Titel bzw. Text gelesen (falls vorhanden).
+	item, err := latestWordPressPost(fetch, doActionFeedURL, "do_action", true)
+	if err != nil || item.Title == "" {
+		return item, err
+	}
+	item.Type = "event"
+	item.Categories = append(item.Categories, "events", "do_action")
+	if date, ok := findEventDate(item.Title + "\n" + htmlTagPattern.ReplaceAllString(item.Content, " ")); ok {
+		item.StartsAt = date.Format(time.RFC3339)
+	}
+	return item, nil
+}
+
+func findEventDate(text string) (time.Time, bool) { // Erstes erkennbares Datum im Text (ganztägig, UTC).
+	for _, candidate := range eventDatePatterns {
+		match := candidate.pattern.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		value := strings.Join(match[1:], " ")
+		if candidate.layout == "2006-01-02" {
+			value = strings.Join(match[1:], "-")
+		}
+		if parsed, err := time.Parse(candidate.layout, value); err == nil {
+			return parsed.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
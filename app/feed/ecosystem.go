@@ -0,0 +1,41 @@
+package feed // Paket "feed": Release-Ankündigungen von Plugins aus dem WordPress-Ökosystem (BuddyPress, bbPress).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"regexp"  // Release-Titel erkennen.
+	"strings" // Trimmen.
+	"time"    // Watermark: nur Releases nach diesem Zeitpunkt.
+)
+
+const buddyPressFeedURL = "https://buddypress.org/feed/" // Blog von BuddyPress (Releases + Projekt-News).
+const bbPressFeedURL = "https://bbpress.org/feed/"       // Blog von bbPress.
+
+func LatestBuddyPressRelease(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert die BuddyPress-Release-Ankündigungen seit since (ohne since: die neueste).
+	return latestPluginReleases(fetch, buddyPressFeedURL, "BuddyPress", since)
+}
+
+func LatestBBPressRelease(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Liefert die bbPress-Release-Ankündigungen seit since (ohne since: die neueste).
+	return latestPluginReleases(fetch, bbPressFeedURL, "bbPress", since)
+}
+
+func latestPluginReleases(fetch func(url, source string) ([]byte, error), feedURL, product string, since time.Time) ([]Item, error) {
+	// Beiträge, deren Titel "<Produkt> <Version>" enthält; andere Blogposts (Meetings, Umfragen) werden übersprungen.
+	items, err := fetchItems(fetch, feedURL, strings.ToLower(product))
+	if err != nil {
+		return nil, err
+	}
+	release := regexp.MustCompile(`(?i)\b` + regexp.QuoteMeta(product) + `\s+v?\d+\.\d+`)
+	releases := []Item{}
+	for _, item := range items {
+		if release.MatchString(item.Title) {
+			releases = append(releases, item)
+		}
+	}
+	releases = NewerThan(releases, since) // Erst filtern: ohne since zählt die neueste *Release*, nicht der neueste Beitrag.
+	for i, item := range releases {
+		item.Categories = append([]string{"releases", strings.ToLower(product)}, item.Categories...)
+		releases[i] = transform(fetch, item, []Transformer{stripScripts, stripAppearedFirst})
+	}
+	return releases, nil
+}
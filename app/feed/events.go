@@ -0,0 +1,91 @@
+package feed // Paket "feed": kommende Meetups/WordCamps über die WordPress Events API.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // API-Antwort parsen.
+	"fmt"           // HTML + Fehlertexte.
+	"html"          // Texte escapen.
+	"net/url"       // Query-Parameter.
+	"strings"       // Trimmen.
+	"time"          // Start-Zeitpunkt.
+)
+
+const eventsAPI = "https://api.wordpress.org/events/1.0/" // WordPress.org Events API (Meetups + WordCamps).
+
+type eventsResponse struct { // Antwort der Events API (nur die benötigten Felder).
+	Events []struct {
+		Type      string `json:"type"`                 // "meetup" oder "wordcamp".
+		Title     string `json:"title"`                // Titel des Events.
+		URL       string `json:"url"`                  // Event-Seite.
+		Meetup    string `json:"meetup"`               // Name der Meetup-Gruppe (nur bei Meetups).
+		Date      string `json:"date"`                 // Lokale Startzeit "2006-01-02 15:04:05".
+		StartUnix int64  `json:"start_unix_timestamp"` // Startzeit als Unix-Timestamp (UTC).
+		Location  struct {
+			Location string `json:"location"` // "Berlin, Germany" bzw. "Online".
+		} `json:"location"`
+	} `json:"events"`
+}
+
+func UpcomingEvents(locations []string, number int) func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+	// Liefert einen Fetcher für kommende Events an den konfigurierten Orten (z.B. "Berlin", "Hamburg"); doppelte Events werden zusammengefasst.
+	if number <= 0 {
+		number = 10
+	}
+	return func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+		items := []Item{}
+		seen := map[string]bool{}
+		for _, location := range locations {
+			location = strings.TrimSpace(location)
+			if location == "" {
+				continue
+			}
+			query := url.Values{"location": {location}, "number": {fmt.Sprint(number)}}
+			body, err := fetch(eventsAPI+"?"+query.Encode(), "wordpress events")
+			if err != nil {
+				return nil, err
+			}
+			var response eventsResponse
+			if err := json.Unmarshal(body, &response); err != nil {
+				return nil, fmt.Errorf("wordpress events %s: %w", location, err)
+			}
+			for _, event := range response.Events {
+				if event.URL == "" || seen[event.URL] { // Orte können sich überschneiden (Online-Events, Nachbarstädte).
+					continue
+				}
+				seen[event.URL] = true
+				item := Item{
+					Title:      strings.TrimSpace(html.UnescapeString(event.Title)),
+					Link:       event.URL,
+					Content:    buildEventContent(event.Title, event.Date, event.Location.Location, event.Meetup),
+					Categories: []string{"events", event.Type},
+					Type:       "event",
+				}
+				if event.StartUnix > 0 {
+					item.StartsAt = time.Unix(event.StartUnix, 0).UTC().Format(time.RFC3339)
+				}
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+}
+
+func buildEventContent(title, date, location, meetup string) string { // Titel + Termin + Ort (+ Meetup-Gruppe) als HTML.
+	content := fmt.Sprintf("<p><strong>%s</strong></p>", html.EscapeString(html.UnescapeString(strings.TrimSpace(title))))
+	when := date
+	if parsed, err := time.Parse("2006-01-02 15:04:05", date); err == nil { // Lokale Zeit des Events, lesbar formatiert.
+		when = parsed.Format("Mon, 2 Jan 2006 15:04")
+	}
+	details := []string{}
+	for _, value := range []string{when, location} {
+		if value = strings.TrimSpace(value); value != "" {
+			details = append(details, html.EscapeString(value))
+		}
+	}
+	if len(details) > 0 {
+		content += "<p>" + strings.Join(details, " · ") + "</p>"
+	}
+	if meetup = strings.TrimSpace(meetup); meetup != "" {
+		content += fmt.Sprintf("<p>%s</p>", html.EscapeString(meetup))
+	}
+	return content
+}
@@ -0,0 +1,93 @@
+package feed // Paket "feed": Releases über die GitHub REST API (statt Atom-Feed scrapen).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // API-Antwort parsen.
+	"fmt"           // URL + Titel + Fehlertexte.
+	"path"          // Repo-Kurzname.
+	"strings"       // Trimmen.
+	"time"          // published_at → RFC1123Z.
+)
+
+const githubAPI = "https://api.github.com" // Basis-URL der GitHub REST API.
+
+type githubRelease struct { // Ein Release aus /repos/{owner}/{repo}/releases (nur die benötigten Felder).
+	Name        string `json:"name"`         // Anzeigename (kann leer sein).
+	TagName     string `json:"tag_name"`     // Tag, z.B. "v17.0.0".
+	HTMLURL     string `json:"html_url"`     // Link zur Release-Seite.
+	BodyHTML    string `json:"body_html"`    // Release Notes, von GitHub aus Markdown gerendert (Accept: …full+json).
+	Draft       bool   `json:"draft"`        // Entwürfe sind nicht öffentlich.
+	Prerelease  bool   `json:"prerelease"`   // Als Vorabversion markiert.
+	PublishedAt string `json:"published_at"` // RFC3339.
+}
+
+func GitHubReleases(repos []string, maxItems int) func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+	// Liefert einen Fetcher für die neuesten maxItems Releases jedes Repos ("owner/name"); blättert bei Bedarf über mehrere Seiten.
+	if maxItems <= 0 {
+		maxItems = 10 // Default: genug für verpasste Läufe, ohne beim ersten Lauf die ganze Historie zu übernehmen.
+	}
+	return func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+		items := []Item{}
+		for _, repo := range repos {
+			repo = strings.Trim(strings.TrimSpace(repo), "/")
+			if repo == "" {
+				continue
+			}
+			releases, err := githubRepoReleases(fetch, repo, maxItems)
+			if err != nil {
+				return nil, fmt.Errorf("github %s: %w", repo, err)
+			}
+			for _, release := range releases {
+				items = append(items, githubItem(repo, release))
+			}
+		}
+		return items, nil
+	}
+}
+
+func githubRepoReleases(fetch func(url, source string) ([]byte, error), repo string, maxItems int) ([]githubRelease, error) { // Sammelt Seite für Seite bis maxItems.
+	perPage := maxItems
+	if perPage > 100 { // API-Limit pro Seite.
+		perPage = 100
+	}
+	result := []githubRelease{}
+	for page := 1; len(result) < maxItems; page++ {
+		body, err := fetch(fmt.Sprintf("%s/repos/%s/releases?per_page=%d&page=%d", githubAPI, repo, perPage, page), "github")
+		if err != nil {
+			return nil, err
+		}
+		var releases []githubRelease
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, err
+		}
+		for _, release := range releases {
+			if !release.Draft && len(result) < maxItems {
+				result = append(result, release)
+			}
+		}
+		if len(releases) < perPage { // Letzte Seite erreicht.
+			break
+		}
+	}
+	return result, nil
+}
+
+func githubItem(repo string, release githubRelease) Item { // Mappt ein Release auf das interne Item-Format.
+	name := strings.TrimSpace(release.Name)
+	if name == "" {
+		name = release.TagName
+	}
+	project := path.Base(repo)
+	if !strings.Contains(strings.ToLower(name), strings.ToLower(project)) { // "17.0.0" → "gutenberg 17.0.0", damit der Titel allein verständlich ist.
+		name = project + " " + name
+	}
+	item := Item{
+		Title:      name,
+		Link:       release.HTMLURL,
+		Content:    scriptBlockPattern.ReplaceAllString(strings.TrimSpace(release.BodyHTML), ""),
+		Categories: []string{"releases", project},
+	}
+	if published, err := time.Parse(time.RFC3339, release.PublishedAt); err == nil {
+		item.PubDate = published.UTC().Format(time.RFC1123Z)
+	}
+	return item
+}
@@ -0,0 +1,17 @@
+package feed // Paket "feed": Beitragsbild aus dem HTML-Inhalt (Fallback, wenn die Quelle keins mitliefert).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"html"    // Attributwerte dekodieren (&amp; in URLs).
+	"regexp"  // <img> finden.
+	"strings" // Trimmen.
+)
+
+var imageSourcePattern = regexp.MustCompile(`(?is)<img\b[^>]*?\ssrc\s*=\s*["']([^"']+)["']`) // src des ersten <img>.
+
+func ContentImage(content string) string { // src des ersten <img> im Inhalt; leer, wenn es keins gibt.
+	match := imageSourcePattern.FindStringSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(match[1]))
+}
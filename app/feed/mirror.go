@@ -0,0 +1,10 @@
+package feed // Paket "feed": Mirror-Modus – übernimmt alle Items eines beliebigen RSS- oder Atom-Feeds.
+
+import "regexp" // Script/Style-Blöcke entfernen.
+
+var scriptBlockPattern = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)>`) // Komplette <script>/<style>-Blöcke.
+
+func MirrorFeed(url string) func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+	// Liefert einen Fetcher, der *alle* Items des Feeds unter url als bereinigte Items zurückgibt (Vollinhalt bevorzugt, ohne Skripte).
+	return AllRSSItems(url, "mirror", stripScripts)
+}
@@ -0,0 +1,65 @@
+package feed // Paket "feed": neue Block-Patterns aus dem Pattern Directory von WordPress.org (REST API).
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // REST-Antwort parsen.
+	"fmt"           // HTML + Fehlertexte.
+	"html"          // Titel/Attribute escapen.
+	"net/url"       // Vorschau-URL kodieren.
+	"strings"       // Trimmen.
+	"time"          // date_gmt → RFC1123Z.
+)
+
+const patternsAPI = "https://wordpress.org/patterns/wp-json/wp/v2/wporg-pattern?per_page=1&orderby=date&order=desc" // Neuestes veröffentlichtes Pattern.
+const patternPreviewURL = "https://s0.wp.com/mshots/v1/%s?vpw=1200&vph=900&w=600"                                   // Screenshot-Dienst, den auch das Directory für Vorschauen nutzt.
+
+type patternPost struct { // Ein Pattern aus der REST API (nur die benötigten Felder).
+	Link    string `json:"link"`     // Seite des Patterns im Directory.
+	DateGMT string `json:"date_gmt"` // Veröffentlichungszeit in UTC ohne Zeitzone.
+	Title   struct {
+		Rendered string `json:"rendered"` // Titel (HTML-escaped).
+	} `json:"title"`
+	Meta struct {
+		Description string `json:"wpop_description"` // Kurzbeschreibung des Autors.
+	} `json:"meta"`
+	Categories []string `json:"category_slugs"` // Pattern-Kategorien (z.B. "header").
+}
+
+func LatestPattern(fetch func(url, source string) ([]byte, error)) (Item, error) {
+	// Liefert das neueste Block-Pattern inklusive Vorschaubild.
+	body, err := fetch(patternsAPI, "pattern directory")
+	if err != nil {
+		return Item{}, err
+	}
+	var patterns []patternPost
+	if err := json.Unmarshal(body, &patterns); err != nil {
+		return Item{}, fmt.Errorf("pattern directory: %w", err)
+	}
+	if len(patterns) == 0 {
+		return Item{}, nil
+	}
+	pattern := patterns[0]
+	link := strings.TrimSpace(pattern.Link)
+	title := strings.TrimSpace(html.UnescapeString(pattern.Title.Rendered))
+	preview := ""
+	if link != "" { // Die "view"-Seite rendert nur das Pattern selbst.
+		preview = fmt.Sprintf(patternPreviewURL, url.QueryEscape(strings.TrimRight(link, "/")+"/view/"))
+	}
+	content := ""
+	if preview != "" {
+		content = fmt.Sprintf(`<p><img src="%s" alt="%s" /></p>`, html.EscapeString(preview), html.EscapeString(title))
+	}
+	if description := strings.TrimSpace(pattern.Meta.Description); description != "" {
+		content += "<p>" + html.EscapeString(description) + "</p>"
+	}
+	item := Item{
+		Title:      title,
+		Link:       link,
+		Content:    content,
+		Categories: append([]string{"patterns"}, pattern.Categories...),
+		Thumbnail:  preview,
+	}
+	if published, err := time.Parse("2006-01-02T15:04:05", pattern.DateGMT); err == nil {
+		item.PubDate = published.UTC().Format(time.RFC1123Z)
+	}
+	return item, nil
+}
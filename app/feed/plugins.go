@@ -0,0 +1,118 @@
+package feed // Paket "feed": neue Versionen eines Plugins im Plugin-Verzeichnis von WordPress.org (Plugins API) samt Changelog.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // API-Antwort parsen.
+	"fmt"           // Titel + Fehlertexte.
+	"html"          // Namen enthalten HTML-Entities.
+	"net/url"       // Query-Parameter.
+	"regexp"        // Überschriften im Changelog finden.
+	"strings"       // Trimmen.
+)
+
+const pluginsAPI = "https://api.wordpress.org/plugins/info/1.2/" // WordPress.org Plugins API (plugin_information).
+
+const DefaultPlugin = "wapuugotchi" // Slug des WapuuGotchi-Plugins (Default für die Quelle "wapuugotchi-plugin").
+
+var headingPattern = regexp.MustCompile(`(?is)<h([1-6])\b[^>]*>(.*?)</h[1-6]>`) // Überschriften (= Versionen) im gerenderten Changelog.
+var markupPattern = regexp.MustCompile(`<[^>]*>`)                               // Tags innerhalb einer Überschrift.
+
+type pluginInfo struct { // Antwort von plugin_information (nur die benötigten Felder).
+	Name     string `json:"name"`    // Anzeigename (HTML-escaped).
+	Slug     string `json:"slug"`    // Slug im Verzeichnis.
+	Version  string `json:"version"` // Aktuelle stabile Version (Stable tag).
+	Error    string `json:"error"`   // z.B. "Plugin not found."
+	Sections struct {
+		Changelog string `json:"changelog"` // readme.txt "== Changelog ==" als HTML.
+	} `json:"sections"`
+	Banners json.RawMessage `json:"banners"` // {"low": …, "high": …}; ohne Banner ein leeres Array.
+}
+
+func LatestPluginVersion(slug string) func(fetch func(url, source string) ([]byte, error)) (Item, error) {
+	// Liefert einen Fetcher für die aktuelle Version eines Plugins; Inhalt ist der Changelog-Abschnitt dieser Version.
+	if slug = strings.Trim(strings.TrimSpace(slug), "/"); slug == "" {
+		slug = DefaultPlugin
+	}
+	return func(fetch func(url, source string) ([]byte, error)) (Item, error) {
+		query := url.Values{
+			"action":                       {"plugin_information"},
+			"request[slug]":                {slug},
+			"request[fields][versions]":    {"0"}, // Download-Links aller Versionen: groß und hier unnötig.
+			"request[fields][reviews]":     {"0"},
+			"request[fields][screenshots]": {"0"},
+		}
+		body, err := fetch(pluginsAPI+"?"+query.Encode(), "plugin "+slug)
+		if err != nil {
+			return Item{}, err
+		}
+		var info pluginInfo
+		if err := json.Unmarshal(body, &info); err != nil {
+			return Item{}, fmt.Errorf("plugin %s: %w", slug, err)
+		}
+		if info.Error != "" {
+			return Item{}, fmt.Errorf("plugin %s: %s", slug, info.Error)
+		}
+		version := strings.TrimSpace(info.Version)
+		if version == "" {
+			return Item{}, nil
+		}
+		name := strings.TrimSpace(html.UnescapeString(info.Name))
+		if name == "" {
+			name = slug
+		}
+		content := changelogSection(info.Sections.Changelog, version)
+		if content == "" {
+			content = fmt.Sprintf("<p>%s %s is available.</p>", html.EscapeString(name), html.EscapeString(version))
+		}
+		return Item{
+			Title:      name + " " + version,
+			Link:       fmt.Sprintf("https://wordpress.org/plugins/%s/#version-%s", slug, url.PathEscape(version)), // Ohne PubDate bildet der Link die Entry-ID: das Fragment macht sie pro Version eindeutig.
+			Content:    scriptBlockPattern.ReplaceAllString(content, ""),
+			Categories: []string{"releases", slug},
+			Image:      pluginBanner(info.Banners),
+		}, nil
+	}
+}
+
+func changelogSection(changelog, version string) string { // HTML unter der Überschrift der Version bis zur nächsten Überschrift gleicher Ebene; leer, wenn die Version fehlt.
+	headings := headingPattern.FindAllStringSubmatchIndex(changelog, -1)
+	for i, heading := range headings {
+		if !headingMentions(changelog[heading[4]:heading[5]], version) {
+			continue
+		}
+		end := len(changelog)
+		for _, next := range headings[i+1:] {
+			if changelog[next[2]:next[3]] <= changelog[heading[2]:heading[3]] { // Gleiche oder höhere Ebene beendet den Abschnitt.
+				end = next[0]
+				break
+			}
+		}
+		return strings.TrimSpace(changelog[heading[1]:end])
+	}
+	return ""
+}
+
+func headingMentions(heading, version string) bool { // "1.2.0", "= 1.2.0 =", "Version 1.2.0 (2025-01-01)", "v1.2.0:" …
+	heading = html.UnescapeString(markupPattern.ReplaceAllString(heading, ""))
+	for _, word := range strings.Fields(heading) {
+		if strings.TrimPrefix(strings.Trim(word, "=:,()[]"), "v") == version {
+			return true
+		}
+	}
+	return false
+}
+
+func pluginBanner(raw json.RawMessage) string { // Großes Banner bevorzugen, sonst das kleine.
+	var banners struct {
+		Low  any `json:"low"`
+		High any `json:"high"`
+	}
+	if json.Unmarshal(raw, &banners) != nil { // Ohne Banner liefert die API [] statt eines Objekts.
+		return ""
+	}
+	for _, banner := range []any{banners.High, banners.Low} {
+		if value, ok := banner.(string); ok && strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
@@ -1,8 +1,8 @@
 package feed // Definiert das Paket "feed"; enthält Logik zum Abrufen/Transformieren von RSS-Feed-Inhalten.
 
 import ( // Import-Block: Abhängigkeiten dieser Datei.
-	"encoding/xml" // Wird gebraucht, um RSS-XML (WordPress Releases Feed) in Go-Structs zu unmarshallen.
-	"strings"      // Wird verwendet, um Whitespace zu trimmen und leere Inhalte sauber zu erkennen.
+	"strings" // Wird verwendet, um Whitespace zu trimmen und leere Inhalte sauber zu erkennen.
+	"time"    // Watermark: nur Posts nach diesem Zeitpunkt.
 
 	"wapuugotchi/feed/app/ai" // Eigenes KI-Paket: transformiert Rohtext mit einem Prompt in gewünschtes Ausgabeformat.
 )
@@ -16,71 +16,43 @@ const releasesPattern = "Extract key highlights from the text below. Output RAW
 // und du vermutlich wirklich HTML im RSS <description> ausliefern willst, nicht escaped Entities.
 
 type Item struct { // Internes, vereinheitlichtes Item-Format für dein Aggregationssystem (wird von mehreren Quellen genutzt).
-	Title      string   // Titel der Nachricht (z.B. "WordPress 6.x released").
-	Link       string   // Link zur Originalquelle.
-	PubDate    string   // Veröffentlichungsdatum als String (RSS-Format), später anderswo geparsed/normalisiert.
-	Content    string   // Inhalt/Description, hier typischerweise HTML (entweder KI-rendered oder Fallback-Text).
-	Categories []string // Kategorien/Tags aus dem Feed (optional).
+	Title          string   // Titel der Nachricht (z.B. "WordPress 6.x released").
+	Link           string   // Link zur Originalquelle.
+	PubDate        string   // Veröffentlichungsdatum als String (RSS-Format), später anderswo geparsed/normalisiert.
+	Content        string   // Inhalt/Description, hier typischerweise HTML (entweder KI-rendered oder Fallback-Text).
+	Categories     []string // Kategorien/Tags aus dem Feed (optional).
+	PublishAt      string   // Optionales Embargo (beliebiges von parsePubDate/RFC3339 lesbares Format); leer = sofort.
+	Thumbnail      string   // Optionales Vorschaubild (URL), z.B. Poster eines Videos.
+	Image          string   // Optionales Beitragsbild (URL), z.B. aus media:content oder <enclosure>.
+	Duration       int      // Optionale Laufzeit in Sekunden (Video/Audio).
+	Transcript     string   // Optionaler Link zu Untertiteln/Transkript (WebVTT/SRT).
+	Type           string   // Optionaler Typ des Items (z.B. "event"); leer = normaler Beitrag.
+	StartsAt       string   // Events: Startzeit (RFC3339, UTC).
+	Language       string   // Optionale Sprache des Contents (z.B. "de"); leer = Sprache der Site.
+	SourceLanguage string   // Übersetzte Items: Sprache des Originals ("und" = unbekannt); leer = nicht übersetzt.
+	Summary        string   // Optionaler Auszug (RSS <description>); Content bevorzugt den Vollinhalt (content:encoded).
+	Author         string   // Optionale Autor:in (dc:creator bzw. Atom <author><name>).
 }
 
-type wordPressFeed struct { // Repräsentiert das Root-Level des RSS-Dokuments (vereinfacht auf das, was du brauchst).
-	Channel wordPressChannel `xml:"channel"` // Mappt das <channel>-Element auf dieses Feld.
+func init() { // Registriert die Release-Aufbereitung als Transformer (auch für eigene "rss"-Quellen nutzbar).
+	RegisterTransformer("wordpress-releases", releasesTransformer)
 }
 
-type wordPressChannel struct { // Repräsentiert <channel>, in dem die <item>-Elemente liegen.
-	Items []wordPressItem `xml:"item"` // Alle einzelnen Feed-Items (Posts) aus dem RSS.
-}
-
-type wordPressItem struct { // Repräsentiert ein einzelnes <item> im WordPress Releases Feed.
-	Title       string   `xml:"title"`       // Mappt <title> → Titel des Posts.
-	Link        string   `xml:"link"`        // Mappt <link> → URL zum Post.
-	PubDate     string   `xml:"pubDate"`     // Mappt <pubDate> → Veröffentlichungsdatum (RSS-String).
-	Description string   `xml:"description"` // Mappt <description> → Inhalt (oft HTML/CDATAsnippet).
-	Categories  []string `xml:"category"`    // Mappt <category> (mehrfach) → Slice von Kategorien/Tags.
-}
-
-func LatestReleases(fetch func(url, source string) ([]byte, error)) (Item, error) {
-	// Exportierte Funktion: holt den neuesten WordPress Release-Post und gibt ihn als internes Item zurück.
+func LatestReleases(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Exportierte Funktion: holt alle WordPress Release-Posts seit since (ohne since: nur den neuesten) als interne Items.
 	// fetch wird injiziert (Dependency Injection), damit HTTP-Handling/Retry/Headers zentral bleibt und testbar ist.
 
-	body, err := fetch(releasesFeedURL, "wordpress releases")
-	// Ruft den Feed per HTTP ab; "wordpress releases" dient typischerweise für Fehlermeldungen/Logging im fetch.
-
-	if err != nil {
-		// Wenn HTTP-Fetch scheitert (Timeout, non-2xx, Netzwerk)…
-		return Item{}, err
-		// …weiterreichen: hier kann man ohne Body nichts sinnvoll machen.
-	}
-
-	var feed wordPressFeed
-	// Zielvariable für das XML-Unmarshal: danach steht feed.Channel.Items gefüllt da (oder leer).
-
-	if err := xml.Unmarshal(body, &feed); err != nil {
-		// Parst das RSS-XML in die Structs; scheitert bei ungültigem XML oder Strukturabweichungen.
-		return Item{}, err
-		// Fehler weitergeben: ohne valide Struktur weißt du nicht, was "latest" ist.
-	}
-
-	if len(feed.Channel.Items) == 0 {
-		// Falls der Feed keine Items enthält (z.B. temporär leer oder parse lieferte nichts)…
-		return Item{}, nil
-		// …kein Fehler: bedeutet schlicht "kein neuer Content verfügbar".
-	}
+	return RSSItemsSince(releasesFeedURL, "wordpress releases", releasesTransformer)(fetch, since)
+	// Generischer RSS-Parser liefert die neuen Items; der Transformer baut für jedes den Content.
+}
 
-	item := feed.Channel.Items[0]
-	// Nimmt das erste Item als "latest"; setzt voraus, dass der RSS-Feed absteigend sortiert ist (üblich bei RSS).
+func releasesTransformer(_ func(url, source string) ([]byte, error), item Item) Item {
+	// Transformer: ersetzt den Content durch die KI-Highlights der Description (Fallback: Original-Description).
 
-	content := buildReleasesContent(item.Description)
-	// Baut den Content: entweder KI-formatiertes RAW-HTML oder Fallback auf Original-Description.
+	item.Content = buildReleasesContent(item.Summary)
+	// Basis ist bewusst die Description, nicht content:encoded: kürzer und damit günstiger für die KI.
 
-	return Item{
-		Title:      item.Title,      // Übernimmt Titel aus dem Feed.
-		Link:       item.Link,       // Übernimmt Link aus dem Feed.
-		PubDate:    item.PubDate,    // Übernimmt PubDate-String unverändert (wird später normalisiert).
-		Content:    content,         // Setzt erzeugten Content (KI oder Fallback).
-		Categories: item.Categories, // Übernimmt Kategorien aus dem Feed.
-	}, nil
-	// Erfolgreiche Rückgabe: ein "standardisiertes" Item für den Aggregator.
+	return item
 }
 
 func buildReleasesContent(description string) string {
@@ -0,0 +1,230 @@
+package feed // Paket "feed": generischer RSS-2.0-Parser + Transformer-Hooks für quellenspezifische Nachbearbeitung.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Fehlertexte.
+	"sort"    // Transformer-Namen auflisten.
+	"strconv" // Laufzeit (Sekunden) parsen.
+	"strings" // Trimmen.
+	"time"    // Watermark-Vergleich.
+)
+
+type rssDocument struct { // RSS 2.0 mit den Erweiterungen, die unsere Quellen nutzen (content:encoded, Media RSS).
+	Channel struct {
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type rssItem struct { // Ein <item>; Felder, die eine Quelle nicht liefert, bleiben leer.
+	Title          string           `xml:"title"`                                    // Titel.
+	Link           string           `xml:"link"`                                     // Link zum Original.
+	PubDate        string           `xml:"pubDate"`                                  // Veröffentlichungsdatum (RSS-String).
+	Description    string           `xml:"description"`                              // Kurzbeschreibung/Auszug (oft HTML).
+	ContentEncoded string           `xml:"encoded"`                                  // Vollinhalt (content:encoded).
+	Creator        string           `xml:"http://purl.org/dc/elements/1.1/ creator"` // Autor:in (dc:creator, z.B. WordPress).
+	Author         string           `xml:"author"`                                   // RSS-<author> (meist "mail@example.com (Name)").
+	Categories     []string         `xml:"category"`                                 // Kategorien/Tags.
+	Thumbnails     []mediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`  // <media:thumbnail> direkt am Item.
+	Enclosures     []rssEnclosure   `xml:"enclosure"`                                // <enclosure> (Podcasts, Beitragsbilder).
+	Media          []struct {
+		URL        string           `xml:"url,attr"`                                // Datei-URL.
+		Medium     string           `xml:"medium,attr"`                             // "image", "video", …
+		Type       string           `xml:"type,attr"`                               // MIME-Type.
+		Duration   string           `xml:"duration,attr"`                           // Laufzeit in Sekunden.
+		Thumbnails []mediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"` // <media:thumbnail> innerhalb von <media:content>.
+	} `xml:"http://search.yahoo.com/mrss/ content"` // <media:content> (Datei + Vorschaubild + Dauer).
+}
+
+type mediaThumbnail struct { // Media RSS Vorschaubild.
+	URL string `xml:"url,attr"` // Bild-URL.
+}
+
+type rssEnclosure struct { // RSS-Anhang (Datei-URL + MIME-Type).
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type Transformer func(fetch func(url, source string) ([]byte, error), item Item) Item // Quellenspezifische Nachbearbeitung eines geparsten Items (z.B. iframe normalisieren, KI-Zusammenfassung).
+
+var transformers = map[string]Transformer{} // Registrierte Transformer; per Name auch aus sources.json nutzbar.
+
+func RegisterTransformer(name string, transformer Transformer) { // Macht einen Transformer unter name verfügbar (überschreibt gleiche Namen).
+	transformers[name] = transformer
+}
+
+func LookupTransformer(name string) (Transformer, bool) { // Registrierter Transformer zu name.
+	transformer, ok := transformers[name]
+	return transformer, ok
+}
+
+func TransformerNames() []string { // Alle registrierten Namen, sortiert (für Fehlermeldungen).
+	names := make([]string, 0, len(transformers))
+	for name := range transformers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() { // Eingebaute Transformer, die für beliebige Quellen taugen.
+	RegisterTransformer("strip-scripts", stripScripts)
+	RegisterTransformer("strip-appeared-first", stripAppearedFirst)
+	RegisterTransformer("summary-only", summaryOnly)
+}
+
+func stripScripts(_ func(url, source string) ([]byte, error), item Item) Item { // Skripte/Styles nie weiterreichen.
+	item.Content = scriptBlockPattern.ReplaceAllString(item.Content, "")
+	return item
+}
+
+func stripAppearedFirst(_ func(url, source string) ([]byte, error), item Item) Item { // Entfernt WordPress' "The post … appeared first on …".
+	item.Content = strings.TrimSpace(appearedFirstPattern.ReplaceAllString(item.Content, ""))
+	return item
+}
+
+func summaryOnly(_ func(url, source string) ([]byte, error), item Item) Item { // Auszug statt Vollinhalt (falls der Feed einen liefert).
+	if item.Summary != "" {
+		item.Content = item.Summary
+	}
+	return item
+}
+
+func ParseRSS(body []byte) ([]Item, error) { // Parst ein RSS-2.0-Dokument in Items (Reihenfolge wie im Feed, meist neueste zuerst).
+	var document rssDocument
+	if err := decodeXML(body, &document); err != nil {
+		return nil, fmt.Errorf("parse rss: %w", err)
+	}
+	items := make([]Item, 0, len(document.Channel.Items))
+	for _, raw := range document.Channel.Items {
+		content := strings.TrimSpace(raw.ContentEncoded) // Vollinhalt bevorzugen…
+		if content == "" {
+			content = strings.TrimSpace(raw.Description) // …sonst die Kurzbeschreibung.
+		}
+		item := Item{
+			Title:      strings.TrimSpace(raw.Title),
+			Link:       strings.TrimSpace(raw.Link),
+			PubDate:    strings.TrimSpace(raw.PubDate),
+			Summary:    strings.TrimSpace(raw.Description),
+			Content:    content,
+			Categories: raw.Categories,
+			Thumbnail:  rssThumbnail(raw),
+			Image:      rssImage(raw),
+			Author:     rssAuthor(raw),
+		}
+		for _, media := range raw.Media {
+			if seconds, err := strconv.Atoi(strings.TrimSpace(media.Duration)); err == nil && seconds > 0 {
+				item.Duration = seconds
+				break
+			}
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func rssThumbnail(raw rssItem) string { // Erstes Vorschaubild: am Item, sonst in einer <media:content>-Variante.
+	lists := [][]mediaThumbnail{raw.Thumbnails}
+	for _, media := range raw.Media {
+		lists = append(lists, media.Thumbnails)
+	}
+	return firstThumbnail(lists...)
+}
+
+func rssImage(raw rssItem) string { // Beitragsbild: <media:content medium="image">, sonst ein Bild-<enclosure> (WordPress-Plugins nutzen beides).
+	for _, media := range raw.Media {
+		if url := strings.TrimSpace(media.URL); url != "" && (media.Medium == "image" || strings.HasPrefix(media.Type, "image/")) {
+			return url
+		}
+	}
+	for _, enclosure := range raw.Enclosures {
+		if url := strings.TrimSpace(enclosure.URL); url != "" && strings.HasPrefix(enclosure.Type, "image/") {
+			return url
+		}
+	}
+	return ""
+}
+
+func fetchItems(fetch func(url, source string) ([]byte, error), feedURL, source string) ([]Item, error) { // Lädt und parst einen Feed (RSS oder Atom).
+	body, err := fetch(feedURL, source)
+	if err != nil {
+		return nil, err
+	}
+	items, err := ParseFeed(body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return items, nil
+}
+
+func RSSItemsSince(feedURL, source string, hooks ...Transformer) func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Fetcher für alle Items eines Feeds (RSS oder Atom), die nach since erschienen sind; hooks laufen nur für diese (KI-Kosten!).
+	return func(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+		items, err := fetchItems(fetch, feedURL, source)
+		if err != nil {
+			return nil, err
+		}
+		items = NewerThan(items, since)
+		for i := range items {
+			items[i] = transform(fetch, items[i], hooks)
+		}
+		return items, nil
+	}
+}
+
+func NewerThan(items []Item, since time.Time) []Item { // Items nach der Watermark since; ohne Watermark (erster Lauf) nur das neueste (RSS ist absteigend sortiert).
+	if since.IsZero() {
+		if len(items) > 1 {
+			return items[:1]
+		}
+		return items
+	}
+	newer := []Item{}
+	for i, item := range items {
+		published, ok := ItemTime(item)
+		if ok && published.After(since) || !ok && i == 0 { // Ohne lesbares Datum nur das oberste Item (sonst liefe die KI bei jedem Lauf für alte Items).
+			newer = append(newer, item)
+		}
+	}
+	return newer
+}
+
+func ItemTime(item Item) (time.Time, bool) { // PubDate als Zeit (RFC1123Z, RFC1123 oder RFC3339).
+	value := strings.TrimSpace(item.PubDate)
+	for _, layout := range []string{time.RFC1123Z, time.RFC1123, time.RFC3339} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func AllRSSItems(feedURL, source string, hooks ...Transformer) func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+	// Fetcher für alle Items eines Feeds (RSS oder Atom); hooks laufen für jedes Item.
+	return func(fetch func(url, source string) ([]byte, error)) ([]Item, error) {
+		items, err := fetchItems(fetch, feedURL, source)
+		if err != nil {
+			return nil, err
+		}
+		for i := range items {
+			items[i] = transform(fetch, items[i], hooks)
+		}
+		return items, nil
+	}
+}
+
+func transform(fetch func(url, source string) ([]byte, error), item Item, hooks []Transformer) Item { // Wendet hooks der Reihe nach an.
+	for _, hook := range hooks {
+		item = hook(fetch, item)
+	}
+	return item
+}
+
+func rssAuthor(raw rssItem) string { // dc:creator, sonst der Name aus <author> ("mail@example.com (Name)").
+	if creator := strings.TrimSpace(raw.Creator); creator != "" {
+		return creator
+	}
+	author := strings.TrimSpace(raw.Author)
+	if open := strings.Index(author, "("); open != -1 && strings.HasSuffix(author, ")") {
+		return strings.TrimSpace(author[open+1 : len(author)-1])
+	}
+	return author
+}
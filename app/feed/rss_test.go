@@ -0,0 +1,62 @@
+package feed // Tests für ParseRSS: Felder, Erweiterungen (content:encoded, dc:creator, Media RSS) und Fehler.
+
+import (
+	"reflect"
+	"testing"
+)
+
+const rssHeader = `<?xml version="1.0" encoding="UTF-8"?><rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:media="http://search.yahoo.com/mrss/"><channel><title>T</title>`
+
+func TestParseRSS(t *testing.T) {
+	tests := []struct {
+		name  string
+		items string
+		want  []Item
+	}{
+		{"leerer Channel", ``, []Item{}},
+		{
+			"content:encoded vor description",
+			`<item><title> Titel </title><link>https://example.com/a</link><pubDate>Wed, 01 May 2024 12:00:00 +0000</pubDate><description>Kurz</description><content:encoded><![CDATA[<p>Lang</p>]]></content:encoded><category>News</category><category>Release</category><dc:creator>Ada</dc:creator></item>`,
+			[]Item{{Title: "Titel", Link: "https://example.com/a", PubDate: "Wed, 01 May 2024 12:00:00 +0000", Summary: "Kurz", Content: "<p>Lang</p>", Categories: []string{"News", "Release"}, Author: "Ada"}},
+		},
+		{
+			"description als Content, Autor aus <author>",
+			`<item><title>B</title><description>&lt;p&gt;Text&lt;/p&gt;</description><author>ada@example.com (Ada L.)</author></item>`,
+			[]Item{{Title: "B", Summary: "<p>Text</p>", Content: "<p>Text</p>", Author: "Ada L."}},
+		},
+		{
+			"Media RSS",
+			`<item><title>V</title><media:content url="https://example.com/v.mp4" type="video/mp4" duration="93"><media:thumbnail url="https://example.com/v.jpg"/></media:content><media:content url="https://example.com/b.jpg" medium="image"/></item>`,
+			[]Item{{Title: "V", Thumbnail: "https://example.com/v.jpg", Image: "https://example.com/b.jpg", Duration: 93}},
+		},
+		{
+			"Bild-Enclosure + Thumbnail am Item",
+			`<item><title>E</title><media:thumbnail url="https://example.com/t.jpg"/><enclosure url="https://example.com/a.mp3" type="audio/mpeg"/><enclosure url="https://example.com/e.png" type="image/png"/></item>`,
+			[]Item{{Title: "E", Thumbnail: "https://example.com/t.jpg", Image: "https://example.com/e.png"}},
+		},
+		{
+			"Reihenfolge bleibt",
+			`<item><title>1</title></item><item><title>2</title></item>`,
+			[]Item{{Title: "1"}, {Title: "2"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			items, err := ParseRSS([]byte(rssHeader + test.items + `</channel></rss>`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(items, test.want) {
+				t.Errorf("ParseRSS = %+v, want %+v", items, test.want)
+			}
+		})
+	}
+}
+
+func TestParseRSSInvalid(t *testing.T) {
+	for _, body := range []string{``, `<rss><channel><item><title>offen`, `<rss><channel><item><title>a &nbsp; b</title></item></channel></rss>`} {
+		if _, err := ParseRSS([]byte(body)); err == nil {
+			t.Errorf("ParseRSS(%q) succeeded, want error", body)
+		}
+	}
+}
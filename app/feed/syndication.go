@@ -0,0 +1,50 @@
+package feed // Paket "feed": Aktualisierungsrhythmus eines Feeds aus dem RSS-Syndication-Modul (sy:updatePeriod/sy:updateFrequency).
+
+import ( // Import-Block: Standardbibliothek.
+	"bytes"   // Schneller Vorab-Check.
+	"strconv" // sy:updateFrequency.
+	"strings" // Werte normalisieren.
+	"time"    // Dauer.
+)
+
+const syndicationNamespace = "http://purl.org/rss/1.0/modules/syndication/"
+
+var updatePeriods = map[string]time.Duration{ // Erlaubte Werte für sy:updatePeriod.
+	"hourly":  time.Hour,
+	"daily":   24 * time.Hour,
+	"weekly":  7 * 24 * time.Hour,
+	"monthly": 30 * 24 * time.Hour,
+	"yearly":  365 * 24 * time.Hour,
+}
+
+type syndicationInfo struct { // Angaben auf Kanal- (RSS) bzw. Feed-Ebene (Atom).
+	Period    string `xml:"http://purl.org/rss/1.0/modules/syndication/ updatePeriod"`
+	Frequency string `xml:"http://purl.org/rss/1.0/modules/syndication/ updateFrequency"`
+}
+
+type syndicationDocument struct { // <rss><channel> oder <feed>.
+	syndicationInfo
+	Channel syndicationInfo `xml:"channel"`
+}
+
+func UpdatePeriod(body []byte) (time.Duration, bool) { // Abstand laut Feed (z.B. "hourly" mit Frequenz 2 = 30m); false = keine (gültige) Angabe oder kein XML.
+	if !bytes.Contains(body, []byte(syndicationNamespace)) {
+		return 0, false
+	}
+	var document syndicationDocument
+	if err := decodeXML(body, &document); err != nil {
+		return 0, false
+	}
+	info := document.Channel
+	if info.Period == "" {
+		info = document.syndicationInfo
+	}
+	period, ok := updatePeriods[strings.ToLower(strings.TrimSpace(info.Period))]
+	if !ok {
+		return 0, false
+	}
+	if frequency, err := strconv.Atoi(strings.TrimSpace(info.Frequency)); err == nil && frequency > 1 { // Fehlt sie, gilt 1.
+		period /= time.Duration(frequency)
+	}
+	return period, true
+}
@@ -0,0 +1,60 @@
+package feed // Paket "feed": Wochen-Digest der gefixten Tickets eines Core-Trac-Milestones.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"fmt"     // Titel + HTML.
+	"html"    // Tickettitel escapen.
+	"net/url" // Query-Parameter.
+	"strings" // Trimmen + HTML bauen.
+	"time"    // Wochen-Grenzen.
+)
+
+const coreTracURL = "https://core.trac.wordpress.org" // Default-Trac (WordPress Core).
+
+var notableTicketTypes = []string{"defect (bug)", "enhancement", "feature request"} // Interne Tasks ("task (blessed)") tauchen im Digest nicht auf.
+
+func TracMilestoneDigest(base, milestone string) func(fetch func(url, source string) ([]byte, error)) (Item, error) {
+	// Liefert einen Fetcher, der die in der letzten abgeschlossenen Woche (Mo–So, UTC) gefixten Tickets eines Milestones als ein Digest-Item zusammenfasst.
+	if base = strings.TrimRight(strings.TrimSpace(base), "/"); base == "" {
+		base = coreTracURL
+	}
+	milestone = strings.TrimSpace(milestone)
+	return func(fetch func(url, source string) ([]byte, error)) (Item, error) {
+		if milestone == "" {
+			return Item{}, fmt.Errorf("trac: missing milestone")
+		}
+		now := time.Now().UTC()
+		end := now.Truncate(24*time.Hour).AddDate(0, 0, -((int(now.Weekday()) + 6) % 7)) // Montag 00:00 dieser Woche = Ende des Digest-Zeitraums.
+		start := end.AddDate(0, 0, -7)
+		query := url.Values{
+			"status":     {"closed"},
+			"resolution": {"fixed"},
+			"milestone":  {milestone},
+			"type":       notableTicketTypes, // Mehrfachwerte sind in Trac ODER-verknüpft.
+			"changetime": {start.Format("2006-01-02") + ".." + end.Format("2006-01-02")},
+			"format":     {"rss"},
+			"max":        {"200"},
+			"order":      {"id"},
+		}
+		tickets, err := fetchItems(fetch, base+"/query?"+query.Encode(), "trac "+milestone)
+		if err != nil {
+			return Item{}, err
+		}
+		if len(tickets) == 0 { // Ruhige Woche: kein leerer Digest.
+			return Item{}, nil
+		}
+		var content strings.Builder
+		content.WriteString("<ul>")
+		for _, ticket := range tickets {
+			fmt.Fprintf(&content, "<li><a href=\"%s\">%s</a></li>", html.EscapeString(ticket.Link), html.EscapeString(ticket.Title))
+		}
+		content.WriteString("</ul>")
+		week := end.AddDate(0, 0, -1) // Sonntag: letzter Tag des Digest-Zeitraums.
+		return Item{
+			Title:      fmt.Sprintf("WordPress %s: %d tickets fixed (%s – %s)", milestone, len(tickets), start.Format("2 Jan"), week.Format("2 Jan 2006")),
+			Link:       base + "/query?" + url.Values{"status": {"closed"}, "resolution": {"fixed"}, "milestone": {milestone}, "changetime": query["changetime"]}.Encode(), // Mit Zeitraum: jeder Digest hat seinen eigenen Link.
+			PubDate:    end.Format(time.RFC1123Z),                                                                                                                          // Stabil pro Woche → stabile Entry-ID.
+			Content:    content.String(),
+			Categories: []string{"trac", "milestone " + milestone},
+		}, nil
+	}
+}
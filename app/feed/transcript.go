@@ -0,0 +1,105 @@
+package feed // Paket "feed": Untertitel/Transkripte von Videoseiten finden und für die KI aufbereiten.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"html"    // Entities in Attributen/Cues auflösen.
+	"net/url" // Relative Untertitel-URLs auflösen.
+	"regexp"  // <track>/<a> finden, Cue-Tags entfernen.
+	"strings" // Zeilenweise verarbeiten.
+
+	"wapuugotchi/feed/app/ai"
+	"wapuugotchi/feed/app/env"
+)
+
+const transcriptPattern = "Summarize this video transcript in 2-3 sentences for a short feed description. Respond without HTML or Markdown. Transcript:\n\n%s" // Prompt für die Zusammenfassung.
+
+const maxTranscriptChars = 12000 // Obergrenze für den Prompt: lange Talks sprengen sonst das Kontextfenster.
+
+var ( // Vorcompilierte Regexe für Untertitel.
+	trackPattern        = regexp.MustCompile(`(?is)<track\b[^>]*?\ssrc\s*=\s*["']([^"']+)["'][^>]*>`)                  // <track src="…">: Untertitel des Players.
+	subtitleLinkPattern = regexp.MustCompile(`(?is)<a\b[^>]*?\shref\s*=\s*["']([^"']+\.(?:vtt|srt)(?:\?[^"']*)?)["']`) // Download-Link auf .vtt/.srt.
+	cueTagPattern       = regexp.MustCompile(`<[^>]+>`)                                                                // <v Speaker>, <i>, Zeitmarken in Cues.
+)
+
+func wordPressTVTranscript(page []byte, link string) string {
+	// Liefert die URL der Untertitel (englische/erste <track>, sonst Download-Link); leer, wenn keine gefunden wurden.
+	candidates := []string{}
+	for _, match := range trackPattern.FindAllSubmatch(page, -1) {
+		src := string(match[1])
+		if tag := strings.ToLower(string(match[0])); strings.Contains(tag, `srclang="en`) || strings.Contains(tag, `srclang='en`) {
+			candidates = append([]string{src}, candidates...) // Englisch bevorzugen (Ausgangssprache der meisten Talks).
+			continue
+		}
+		candidates = append(candidates, src)
+	}
+	if match := subtitleLinkPattern.FindSubmatch(page); match != nil {
+		candidates = append(candidates, string(match[1]))
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	base, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	ref, err := url.Parse(html.UnescapeString(strings.TrimSpace(candidates[0])))
+	if err != nil {
+		return ""
+	}
+	return base.ResolveReference(ref).String()
+}
+
+func summarizeTranscript(fetch func(url, source string) ([]byte, error), transcript string) string {
+	// KI-Zusammenfassung des Transkripts, wenn WORDPRESS_TV_TRANSCRIPT_SUMMARY aktiv ist (sonst oder bei Fehlern leer).
+	if transcript == "" {
+		return ""
+	}
+	_ = env.LoadDotEnv()
+	switch strings.ToLower(env.ReadEnv("WORDPRESS_TV_TRANSCRIPT_SUMMARY")) {
+	case "1", "true", "yes", "on":
+	default:
+		return ""
+	}
+	body, err := fetch(transcript, "wordpress tv transcript")
+	if err != nil {
+		return ""
+	}
+	text := subtitleText(string(body))
+	if text == "" {
+		return ""
+	}
+	if len(text) > maxTranscriptChars {
+		text = text[:strings.LastIndex(text[:maxTranscriptChars], " ")+1]
+	}
+	summary, err := ai.TransformText(transcriptPattern, text)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(summary)
+}
+
+func subtitleText(subtitles string) string { // WebVTT/SRT → Fließtext (ohne Header, Nummern, Zeitstempel, Tags, Wiederholungen).
+	lines := []string{}
+	skipBlock := false // NOTE/STYLE-Blöcke in WebVTT bis zur nächsten Leerzeile überspringen.
+	for _, line := range strings.Split(strings.ReplaceAll(subtitles, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			skipBlock = false
+			continue
+		case skipBlock:
+			continue
+		case strings.HasPrefix(line, "WEBVTT"), strings.Contains(line, "-->"):
+			continue
+		case strings.HasPrefix(line, "NOTE"), strings.HasPrefix(line, "STYLE"), strings.HasPrefix(line, "REGION"):
+			skipBlock = true
+			continue
+		case strings.Trim(line, "0123456789") == "": // SRT-Cue-Nummer.
+			continue
+		}
+		line = strings.TrimSpace(html.UnescapeString(cueTagPattern.ReplaceAllString(line, "")))
+		if line != "" && (len(lines) == 0 || lines[len(lines)-1] != line) { // Rollende Untertitel wiederholen Zeilen.
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
@@ -1,10 +1,11 @@
 package feed // Definiert das Paket "feed"; hier liegt die WordPress-TV-Feed-Logik.
 
 import ( // Import-Block: Abhängigkeiten dieser Datei.
-	"encoding/xml" // RSS-XML wird damit in Go-Structs unmarshalled.
-	"fmt"          // Wird für HTML-String-Zusammenbau (Sprintf) genutzt.
-	"regexp"       // Wird genutzt, um HTML-Teile (iframe/a) per Regex zu finden/ersetzen.
-	"strings"      // Trimmen, Suchen, Ersetzen; robustes String-Handling.
+	"fmt"     // Wird für HTML-String-Zusammenbau (Sprintf) genutzt.
+	"regexp"  // Wird genutzt, um HTML-Teile (iframe/a) per Regex zu finden/ersetzen.
+	"strconv" // Laufzeit (Sekunden) parsen.
+	"strings" // Trimmen, Suchen, Ersetzen; robustes String-Handling.
+	"time"    // Watermark: nur Videos nach diesem Zeitpunkt.
 )
 
 const wordpressTVFeedURL = "https://wordpress.tv/feed/" // URL des WordPress.tv RSS-Feeds (Quelle für neueste Videos).
@@ -27,65 +28,123 @@ var ( // Globale, vorcompilierte Regexe: einmalig bauen (effizient) und mehrfach
 
 	anchorTagPattern    = regexp.MustCompile(`(?is)</?a\b[^>]*>`)
 	// Findet nur die <a ...> und </a> Tags (ohne Inhalt), um "nur Tags" zu strippen.
+
+	durationMetaPattern = regexp.MustCompile(`(?is)<meta\b[^>]*?(?:property|itemprop)\s*=\s*["'](?:video:duration|duration)["'][^>]*?\scontent\s*=\s*["']([^"']+)["']`)
+	// Findet die Dauer auf der Videoseite: <meta property="video:duration" content="183"> oder itemprop="duration" (ISO 8601).
+
+	isoDurationPattern  = regexp.MustCompile(`^PT(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?$`)
+	// ISO-8601-Dauer wie "PT3M12S" (nur Stunden/Minuten/Sekunden).
+
+	posterPattern       = regexp.MustCompile(`(?is)<video\b[^>]*?\sposter\s*=\s*["']([^"']+)["']|<img\b[^>]*?\ssrc\s*=\s*["']([^"']+)["']`)
+	// Findet das Poster eines <video> bzw. das erste <img> im Embed (Fallback für das Vorschaubild).
 )
 
-type wordPressTVFeed struct { // Root-Struktur für das RSS-XML (minimaler Ausschnitt).
-	Channel wordPressTVChannel `xml:"channel"` // Mappt <channel>...</channel> aus dem RSS.
+func init() { // Registriert die WordPress.tv-Aufbereitung (iframe normalisieren, Laufzeit, Transkript) als Transformer.
+	RegisterTransformer("wordpress-tv", wordPressTVTransformer)
+}
+
+func LatestWordPressTV(fetch func(url, source string) ([]byte, error), since time.Time) ([]Item, error) {
+	// Exportierte Funktion: holt alle WordPress.tv Einträge seit since (ohne since: den neuesten) im internen Item-Format.
+	// fetch wird injiziert, damit HTTP-Details zentral bleiben und Tests leicht sind.
+
+	return RSSItemsSince(wordpressTVFeedURL, "wordpress tv", wordPressTVTransformer)(fetch, since)
+	// Generischer RSS-Parser (inkl. Media RSS) + WordPress.tv-Transformer für jedes neue Item.
 }
 
-type wordPressTVChannel struct { // Channel enthält die Items.
-	Items []wordPressTVItem `xml:"item"` // Mappt alle <item>-Elemente in einen Slice.
+func wordPressTVTransformer(fetch func(url, source string) ([]byte, error), item Item) Item {
+	// Transformer: ergänzt Laufzeit/Transkript/Vorschaubild und baut den Content aus Beschreibung + Embed.
+
+	encoded := item.Content
+	if encoded == item.Summary {
+		// ParseRSS fällt ohne content:encoded auf die Description zurück; dann gibt es kein Embed.
+		encoded = ""
+	}
+
+	page := wordPressTVPage(fetch, item.Link)
+	// Videoseite einmal laden: liefert Laufzeit und Untertitel, wenn der Feed sie nicht enthält.
+
+	item.Transcript = wordPressTVTranscript(page, item.Link)
+	// Link zu Untertiteln/Transkript (WebVTT/SRT), falls WordPress.tv welche anbietet.
+
+	description := item.Summary
+	if summary := summarizeTranscript(fetch, item.Transcript); summary != "" {
+		// Optional (WORDPRESS_TV_TRANSCRIPT_SUMMARY): KI-Zusammenfassung des Transkripts statt der oft leeren Beschreibung.
+		description = summary
+	}
+
+	item.Content = buildWordPressTVContent(item.Title, description, encoded)
+	// Baut den HTML-Content: Header (Titel/Beschreibung) + normalisiertes iframe + Entfernen von <a>-Tags.
+
+	item.Thumbnail = wordPressTVThumbnail(item.Thumbnail, encoded)
+	// Vorschaubild (Media RSS oder Embed).
+
+	item.Duration = wordPressTVDuration(item.Duration, page)
+	// Laufzeit (Media RSS oder Videoseite).
+
+	return item
 }
 
-type wordPressTVItem struct { // Struktur eines einzelnen WordPress.tv RSS-Items.
-	Title          string   `xml:"title"`       // Titel des Videos/Posts.
-	Link           string   `xml:"link"`        // Link zur WordPress.tv Seite.
-	PubDate        string   `xml:"pubDate"`     // Veröffentlichungsdatum als RSS-String.
-	Description    string   `xml:"description"` // Kurzbeschreibung (oft HTML, oft mit Links).
-	ContentEncoded string   `xml:"encoded"`     // Vollcontent (häufig inkl. iframe embed).
-	Categories     []string `xml:"category"`    // Kategorien/Tags.
+func wordPressTVThumbnail(thumbnail, encoded string) string {
+	// Liefert das Vorschaubild eines Videos: erst Media RSS (von ParseRSS übernommen), dann Poster/Bild aus dem Embed.
+
+	if thumbnail != "" {
+		// <media:thumbnail> ist die zuverlässigste Quelle.
+		return thumbnail
+	}
+
+	if match := posterPattern.FindStringSubmatch(encoded); match != nil {
+		// Letzter Versuch: poster-Attribut bzw. erstes Bild im Embed.
+		return strings.TrimSpace(match[1] + match[2])
+	}
+
+	return ""
+	// Kein Vorschaubild gefunden.
 }
 
-func LatestWordPressTV(fetch func(url, source string) ([]byte, error)) (Item, error) {
-	// Exportierte Funktion: holt den neuesten WordPress.tv Eintrag und mappt ihn ins interne Item-Format.
-	// fetch wird injiziert, damit HTTP-Details zentral bleiben und Tests leicht sind.
+func wordPressTVPage(fetch func(url, source string) ([]byte, error), link string) []byte {
+	// Lädt die Videoseite (Dauer, Untertitel); Fehler sind nicht fatal, dann fehlen nur die Zusatzinfos.
 
-	body, err := fetch(wordpressTVFeedURL, "wordpress tv")
-	// Holt den RSS-Feed (Bytes). "wordpress tv" dient als Source-Label für Fehlertexte/Logging.
+	if strings.TrimSpace(link) == "" {
+		return nil
+	}
 
+	page, err := fetch(link, "wordpress tv page")
 	if err != nil {
-		// Wenn Fetch fehlschlägt (Netzwerk, Timeout, non-2xx)…
-		return Item{}, err
-		// …gibt leeres Item + Fehler zurück.
+		return nil
 	}
+	return page
+}
 
-	var feed wordPressTVFeed
-	// Zielvariable für das XML-Unmarshal.
+func wordPressTVDuration(duration int, page []byte) int {
+	// Liefert die Laufzeit in Sekunden: erst aus Media RSS (von ParseRSS übernommen), sonst aus den Meta-Tags der Videoseite (0 = unbekannt).
 
-	if err := xml.Unmarshal(body, &feed); err != nil {
-		// XML parsen; Fehler bei invalidem XML oder abweichender Struktur.
-		return Item{}, err
+	if duration > 0 {
+		return duration
 	}
 
-	if len(feed.Channel.Items) == 0 {
-		// Feed hat keine Items → nichts zu liefern.
-		return Item{}, nil
+	match := durationMetaPattern.FindSubmatch(page)
+	if match == nil {
+		return 0
 	}
+	return parseDuration(string(match[1]))
+}
 
-	item := feed.Channel.Items[0]
-	// Nimmt das erste Item als "latest" (Annahme: Feed ist absteigend sortiert, typisch für RSS).
+func parseDuration(value string) int {
+	// Parst "183" (Sekunden) oder ISO 8601 "PT3M3S" in Sekunden (0 = unbekannt).
 
-	content := buildWordPressTVContent(item.Title, item.Description, item.ContentEncoded)
-	// Baut den HTML-Content: Header (Titel/Beschreibung) + normalisiertes iframe + Entfernen von <a>-Tags.
+	value = strings.TrimSpace(value)
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return seconds
+	}
 
-	return Item{
-		Title:      item.Title,      // Titel übernehmen.
-		Link:       item.Link,       // Link übernehmen.
-		PubDate:    item.PubDate,    // PubDate übernehmen (wird später normalisiert).
-		Content:    content,         // Finaler HTML-Content.
-		Categories: item.Categories, // Kategorien übernehmen.
-	}, nil
-	// Erfolgreich: standardisiertes Item zurück.
+	match := isoDurationPattern.FindStringSubmatch(strings.ToUpper(value))
+	if match == nil {
+		return 0
+	}
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.ParseFloat(match[3], 64)
+	return hours*3600 + minutes*60 + int(seconds)
 }
 
 func buildWordPressTVContent(title, description, encoded string) string {
@@ -0,0 +1,81 @@
+package feed // Paket "feed": Quelle über die WordPress REST API (wp-json/wp/v2/posts) statt RSS.
+
+import ( // Import-Block: Abhängigkeiten dieser Datei.
+	"encoding/json" // REST-Antwort parsen.
+	"fmt"           // Fehlertexte.
+	"html"          // Titel enthalten HTML-Entities; Bild-Attribute escapen.
+	"strings"       // URL bauen + Trimmen.
+	"time"          // date_gmt → RFC1123Z.
+)
+
+type restPost struct { // Ein Post aus /wp-json/wp/v2/posts (nur die benötigten Felder).
+	Link    string `json:"link"`     // Permalink.
+	DateGMT string `json:"date_gmt"` // Veröffentlichungszeit in UTC ohne Zeitzone ("2006-01-02T15:04:05").
+	Title   struct {
+		Rendered string `json:"rendered"` // Titel (HTML-escaped).
+	} `json:"title"`
+	Content struct {
+		Rendered string `json:"rendered"` // Vollinhalt als HTML.
+	} `json:"content"`
+	Excerpt struct {
+		Rendered string `json:"rendered"` // Auszug als HTML (Fallback, wenn content leer/geschützt ist).
+	} `json:"excerpt"`
+	Embedded struct {
+		FeaturedMedia []struct {
+			SourceURL string `json:"source_url"` // Bild-URL in Originalgröße.
+			AltText   string `json:"alt_text"`   // Alternativtext.
+		} `json:"wp:featuredmedia"` // Beitragsbild (nur mit _embed).
+		Terms [][]struct {
+			Name     string `json:"name"`     // Anzeigename des Terms.
+			Taxonomy string `json:"taxonomy"` // "category" oder "post_tag".
+		} `json:"wp:term"` // Kategorien + Schlagwörter (nur mit _embed).
+	} `json:"_embedded"`
+}
+
+func LatestWordPressREST(site string) func(fetch func(url, source string) ([]byte, error)) (Item, error) {
+	// Liefert einen Fetcher für den neuesten Post einer WordPress-Site (site = Basis-URL, z.B. "https://wordpress.org/news").
+	endpoint := strings.TrimRight(site, "/") + "/wp-json/wp/v2/posts?_embed=1&per_page=1"
+	return func(fetch func(url, source string) ([]byte, error)) (Item, error) {
+		body, err := fetch(endpoint, "wordpress rest")
+		if err != nil {
+			return Item{}, err
+		}
+		var posts []restPost
+		if err := json.Unmarshal(body, &posts); err != nil {
+			return Item{}, fmt.Errorf("wordpress rest: %w", err)
+		}
+		if len(posts) == 0 {
+			return Item{}, nil // Leere Site: kein Item (wird vom Aufrufer ignoriert).
+		}
+		return restItem(posts[0]), nil
+	}
+}
+
+func restItem(post restPost) Item { // Mappt einen REST-Post auf das interne Item-Format.
+	content := strings.TrimSpace(post.Content.Rendered)
+	if content == "" {
+		content = strings.TrimSpace(post.Excerpt.Rendered)
+	}
+	if media := post.Embedded.FeaturedMedia; len(media) > 0 && media[0].SourceURL != "" { // Beitragsbild vor den Inhalt setzen.
+		content = fmt.Sprintf(`<p><img src="%s" alt="%s" /></p>`, html.EscapeString(media[0].SourceURL), html.EscapeString(media[0].AltText)) + content
+	}
+	item := Item{
+		Title:   strings.TrimSpace(html.UnescapeString(post.Title.Rendered)),
+		Link:    strings.TrimSpace(post.Link),
+		Content: scriptBlockPattern.ReplaceAllString(content, ""),
+	}
+	if media := post.Embedded.FeaturedMedia; len(media) > 0 {
+		item.Image = strings.TrimSpace(media[0].SourceURL)
+	}
+	if published, err := time.Parse("2006-01-02T15:04:05", post.DateGMT); err == nil { // Echte UTC-Zeit statt RSS-String.
+		item.PubDate = published.UTC().Format(time.RFC1123Z)
+	}
+	for _, group := range post.Embedded.Terms {
+		for _, term := range group {
+			if term.Taxonomy == "category" || term.Taxonomy == "post_tag" {
+				item.Categories = append(item.Categories, html.UnescapeString(term.Name))
+			}
+		}
+	}
+	return item
+}
@@ -0,0 +1,137 @@
+package mail // Paket "mail": verschickt HTML-Mails (mit Text-Alternative) per SMTP oder SendGrid API.
+
+import ( // Import-Block: Standardbibliothek für MIME, SMTP und HTTP.
+	"bytes"          // MIME-Body aufbauen.
+	"context"        // Timeout für API-Calls.
+	"encoding/json"  // SendGrid Payload.
+	"fmt"            // Header + Fehlertexte.
+	"io"             // Response-Body lesen.
+	"mime"           // RFC 2047 Kodierung des Betreffs.
+	"mime/multipart" // multipart/alternative (Text + HTML).
+	"net"            // Host/Port trennen.
+	"net/http"       // SendGrid API.
+	"net/smtp"       // SMTP-Versand.
+	"net/textproto"  // MIME-Header der Teile.
+	"strings"        // Trim/Join.
+	"time"           // Date-Header + Timeout.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send" // SendGrid v3 Send-Endpoint.
+
+type Message struct { // Eine Mail an eine Liste von Empfängern.
+	From    string   // Absender (NEWSLETTER_FROM).
+	To      []string // Empfänger.
+	Subject string   // Betreff.
+	HTML    string   // HTML-Teil.
+	Text    string   // Plain-Text-Alternative.
+}
+
+func Configured() bool { // Ob ein Versandweg (SMTP oder SendGrid) konfiguriert ist.
+	_ = env.LoadDotEnv()
+	return env.ReadEnv("SMTP_HOST") != "" || env.ReadEnv("SENDGRID_API_KEY") != ""
+}
+
+func Send(msg Message) error { // Versendet über SendGrid (falls Key gesetzt), sonst SMTP.
+	if len(msg.To) == 0 {
+		return fmt.Errorf("mail: no recipients")
+	}
+	if key := env.ReadEnv("SENDGRID_API_KEY"); key != "" {
+		return sendGrid(key, msg)
+	}
+	if env.ReadEnv("SMTP_HOST") != "" {
+		return sendSMTP(msg)
+	}
+	return fmt.Errorf("mail: set SMTP_HOST or SENDGRID_API_KEY")
+}
+
+func sendSMTP(msg Message) error { // SMTP mit STARTTLS (net/smtp erzwingt TLS für PLAIN-Auth außer bei localhost).
+	host := env.ReadEnv("SMTP_HOST")
+	port := env.ReadEnv("SMTP_PORT")
+	if port == "" {
+		port = "587" // Submission-Port.
+	}
+	var auth smtp.Auth
+	if user := env.ReadEnv("SMTP_USER"); user != "" {
+		auth = smtp.PlainAuth("", user, env.ReadEnv("SMTP_PASSWORD"), host)
+	}
+	body, err := buildMIME(msg)
+	if err != nil {
+		return err
+	}
+	return smtp.SendMail(net.JoinHostPort(host, port), auth, msg.From, msg.To, body)
+}
+
+func buildMIME(msg Message) ([]byte, error) { // Baut eine multipart/alternative Mail (Text zuerst, HTML bevorzugt).
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct{ ctype, content string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.ctype},
+			"Content-Transfer-Encoding": {"8bit"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "From: %s\r\n", msg.From)
+	fmt.Fprintf(&out, "To: %s\r\n", strings.Join(msg.To, ", "))
+	fmt.Fprintf(&out, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&out, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&out, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&out, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", mw.Boundary())
+	out.Write(body.Bytes())
+	return out.Bytes(), nil
+}
+
+func sendGrid(key string, msg Message) error { // POST /v3/mail/send; Empfänger als BCC-artige Einzel-Personalizations.
+	to := make([]map[string]string, 0, len(msg.To))
+	for _, addr := range msg.To {
+		to = append(to, map[string]string{"email": addr})
+	}
+	payload := map[string]any{
+		"personalizations": []map[string]any{{"to": to}},
+		"from":             map[string]string{"email": msg.From},
+		"subject":          msg.Subject,
+		"content": []map[string]string{ // Reihenfolge laut API: text/plain vor text/html.
+			{"type": "text/plain", "value": msg.Text},
+			{"type": "text/html", "value": msg.HTML},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+key)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("sendgrid api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -5,16 +5,69 @@ import ( // Import-Block: Standardlib + internes cmd-Paket.
 	"os"     // Zugriff auf Args, Stdin/Stdout/Stderr, Exit-Codes.
 	"wapuugotchi/feed/app/cmd" // Internes cmd-Paket: enthält RunFeedUpdate() und AI-Wrapper für CLI.
 	"flag"
+	"strings"
 )
 
 func main() {
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") { // Subcommand (feed update, feed build, …); sonst die bisherigen Flags.
+		runCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	list := flag.Bool("list", false, "Show list of feed items")
 	delete := flag.Int("delete", -1, "Delete item number (use with -list to see numbers)")
+	publish := flag.Bool("publish", false, "Upload the generated artifacts to PUBLISH_TARGET without updating")
+	pending := flag.Bool("pending", false, "Show entries waiting for approval (FEED_MODERATION)")
+	approve := flag.String("approve", "", "Approve a pending entry by number or ID")
+	reject := flag.String("reject", "", "Reject a pending entry by number or ID")
+	schedule := flag.String("schedule", "", "Hold an entry back until a time: id=2025-01-02T10:00:00Z (empty time clears)")
+	pin := flag.String("pin", "", "Pin an entry (by ID) to the top of the feed")
+	unpin := flag.String("unpin", "", "Unpin an entry (by ID)")
+	keygen := flag.Bool("keygen", false, "Generate a feed signing key pair (FEED_SIGNING_KEY)")
+	newsletter := flag.Bool("newsletter", false, "Render (and send, if configured) a newsletter with entries since the last send")
+	checkLinks := flag.Bool("check-links", false, "Check entry links, flag dead ones and expire entries that stay dead (FEED_LINK_EXPIRE_DAYS)")
+	merge := flag.Bool("merge", false, "Three-way merge entries.json, entries-YYYY.json or state.json: -merge BASE OURS THEIRS (result is written to OURS; git merge driver, see .gitattributes)")
+	preview := flag.Bool("preview", false, "Serve the generated feeds locally and rebuild on data/config changes (FEED_PREVIEW_ADDR, default 127.0.0.1:8080)")
+	debugHTTP := flag.Bool("debug-http", false, "Dump HTTP requests/responses with secrets redacted to FEED_DEBUG_HTTP_DIR (default debug-http/)")
+	atom := flag.Bool("atom", false, "Also write Atom 1.0 feeds (atom.xml next to feed.xml); same as FEED_ATOM=true")
+	dryRun := flag.Bool("dry-run", false, "Fetch and process all providers, print what would change, but write no files (state.json, entries.json, feed.xml)")
+	report := flag.String("report", "", "Write a JSON summary of the update (providers, new entry IDs, durations, errors) to this file")
+	changedExitCode := flag.Bool("changed-exit-code", false, "Exit with code 3 when nothing changed (0 = updated, 1 = error), so CI can skip the commit step")
 
 
+	pathFlags(flag.CommandLine) // -data-dir, -output.
+	flag.Usage = func() { // Hilfe: Subcommands zuerst, dann die bisherigen Flags.
+		printCommands()
+		fmt.Fprintln(os.Stderr, "\nFlags (without command: update):")
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
+	if *atom {
+		cmd.EnableAtom()
+	}
+
+	if *debugHTTP {
+		if err := cmd.EnableDebugHTTP(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	if *merge {
+		args := flag.Args()
+		if len(args) != 3 {
+			fmt.Fprintln(os.Stderr, "usage: -merge BASE OURS THEIRS")
+			os.Exit(2)
+		}
+		if err := cmd.RunMerge(args[0], args[1], args[2]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if *list {
 		cmd.RunListItems()
 		return
@@ -25,8 +78,94 @@ func main() {
 		return
 	}
 
-	if err := cmd.RunFeedUpdate(*verbose); err != nil { // Standardpfad: Feed aktualisieren und feed.xml schreiben.
+	if *pending || *approve != "" || *reject != "" {
+		var err error
+		switch {
+		case *approve != "":
+			err = cmd.RunApprove(*approve)
+		case *reject != "":
+			err = cmd.RunReject(*reject)
+		default:
+			err = cmd.RunListPending()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *schedule != "" {
+		if err := cmd.RunSchedule(*schedule); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *pin != "" || *unpin != "" {
+		var err error
+		if *pin != "" {
+			err = cmd.RunPin(*pin, true)
+		} else {
+			err = cmd.RunPin(*unpin, false)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *keygen {
+		if err := cmd.RunKeygen(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *newsletter {
+		if err := cmd.RunNewsletter(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *checkLinks {
+		if err := cmd.RunCheckLinks(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *preview {
+		if err := cmd.RunPreview(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *publish {
+		if err := cmd.RunPublish(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	cmd.SetReport(*report)
+	ctx, stop := signalContext() // Ctrl-C / CI-Abbruch: sauber aufhören statt mitten im Schreiben.
+	changed, err := cmd.RunFeedUpdate(ctx, *verbose, *dryRun) // Standardpfad: Feed aktualisieren und feed.xml schreiben.
+	stop()
+	if err != nil {
 		fmt.Fprintln(os.Stderr, err) // Fehler auf stderr ausgeben (CLI-Konvention).
 		os.Exit(1) // Exit-Code 1 für generischen Fehler.
 	}
+	if !changed && *changedExitCode { // CI: "nichts geändert" vom Erfolg unterscheiden.
+		os.Exit(exitNoUpdate)
+	}
 }
@@ -0,0 +1,118 @@
+package notify // Paket "notify": Bluesky-Kanal über die AT-Protocol-XRPC-API (createSession + createRecord).
+
+import ( // Import-Block: Standardbibliothek für HTTP/JSON.
+	"bytes"         // Request-Body.
+	"context"       // Timeout.
+	"encoding/json" // XRPC-Bodies.
+	"fmt"           // Fehlertexte.
+	"io"            // Response-Body lesen.
+	"net/http"      // API-Calls.
+	"strings"       // Trim + Link-Position.
+	"time"          // Timeout + createdAt.
+	"unicode/utf8"  // 300-Zeichen-Limit.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const blueskyMaxLength = 300 // Bluesky erlaubt 300 Grapheme pro Post; Runen sind eine sichere Näherung.
+
+type bluesky struct { // Konfiguration eines Bluesky-Accounts.
+	service  string // PDS, Default https://bsky.social (BLUESKY_SERVICE).
+	handle   string // Handle oder DID, z.B. wapuugotchi.bsky.social (BLUESKY_HANDLE).
+	password string // App-Passwort, nicht das Konto-Passwort (BLUESKY_APP_PASSWORD).
+	template string // Post-Template (BLUESKY_TEMPLATE).
+	did      string // Nach createSession: DID des Accounts.
+	jwt      string // Nach createSession: Access Token (gilt für alle Posts dieses Laufs).
+}
+
+func newBluesky() (*bluesky, bool) { // Aktiv, sobald Handle und App-Passwort gesetzt sind.
+	b := &bluesky{
+		service:  strings.TrimRight(env.ReadEnv("BLUESKY_SERVICE"), "/"),
+		handle:   env.ReadEnv("BLUESKY_HANDLE"),
+		password: env.ReadEnv("BLUESKY_APP_PASSWORD"),
+		template: env.ReadEnv("BLUESKY_TEMPLATE"),
+	}
+	if b.service == "" {
+		b.service = "https://bsky.social"
+	}
+	return b, b.handle != "" && b.password != ""
+}
+
+func (b *bluesky) Name() string { return "bluesky" }
+
+func (b *bluesky) Notify(notice Notice) error { // Postet den gerenderten Text mit klickbarem Link und Link-Karte.
+	text, err := render(b.template, notice)
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(text) > blueskyMaxLength {
+		text = string([]rune(text)[:blueskyMaxLength-1]) + "…"
+	}
+	if b.jwt == "" {
+		if err := b.login(); err != nil {
+			return err
+		}
+	}
+	post := map[string]any{
+		"$type":     "app.bsky.feed.post",
+		"text":      text,
+		"createdAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	if start := strings.Index(text, notice.Link); notice.Link != "" && start != -1 { // Facet: Bluesky verlinkt URLs im Text nicht von selbst (Offsets in Bytes).
+		post["facets"] = []map[string]any{{
+			"index":    map[string]int{"byteStart": start, "byteEnd": start + len(notice.Link)},
+			"features": []map[string]string{{"$type": "app.bsky.richtext.facet#link", "uri": notice.Link}},
+		}}
+	}
+	if notice.Link != "" {
+		post["embed"] = map[string]any{
+			"$type":    "app.bsky.embed.external",
+			"external": map[string]string{"uri": notice.Link, "title": notice.Title, "description": ""},
+		}
+	}
+	return b.call("com.atproto.repo.createRecord", map[string]any{"repo": b.did, "collection": "app.bsky.feed.post", "record": post}, nil)
+}
+
+func (b *bluesky) login() error { // Session mit App-Passwort öffnen.
+	var session struct {
+		DID       string `json:"did"`
+		AccessJwt string `json:"accessJwt"`
+	}
+	if err := b.call("com.atproto.server.createSession", map[string]string{"identifier": b.handle, "password": b.password}, &session); err != nil {
+		return err
+	}
+	b.did, b.jwt = session.DID, session.AccessJwt
+	return nil
+}
+
+func (b *bluesky) call(method string, input, output any) error { // XRPC-Procedure (POST) mit JSON rein/raus.
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.service+"/xrpc/"+method, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if b.jwt != "" {
+		req.Header.Set("Authorization", "Bearer "+b.jwt)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("bluesky %s status: %s: %s", method, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if output == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, output)
+}
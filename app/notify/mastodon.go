@@ -0,0 +1,65 @@
+package notify // Paket "notify": Mastodon-Kanal (POST /api/v1/statuses).
+
+import ( // Import-Block: Standardbibliothek für HTTP.
+	"context"  // Timeout.
+	"fmt"      // Fehlertexte.
+	"io"       // Response-Body lesen.
+	"net/http" // API-Call.
+	"net/url"  // Form-Encoding des Status.
+	"strings"  // Trim.
+	"time"     // Timeout-Dauer.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type mastodon struct { // Konfiguration eines Mastodon-Accounts.
+	server     string // Instanz-URL, z.B. https://mastodon.social (MASTODON_SERVER).
+	token      string // Access Token mit write:statuses (MASTODON_TOKEN).
+	template   string // Status-Template (MASTODON_TEMPLATE).
+	visibility string // public, unlisted, private (MASTODON_VISIBILITY).
+}
+
+func newMastodon() (*mastodon, bool) { // Aktiv, sobald Server und Token gesetzt sind.
+	m := &mastodon{
+		server:     strings.TrimRight(env.ReadEnv("MASTODON_SERVER"), "/"),
+		token:      env.ReadEnv("MASTODON_TOKEN"),
+		template:   env.ReadEnv("MASTODON_TEMPLATE"),
+		visibility: env.ReadEnv("MASTODON_VISIBILITY"),
+	}
+	return m, m.server != "" && m.token != ""
+}
+
+func (m *mastodon) Name() string { return "mastodon" }
+
+func (m *mastodon) Notify(notice Notice) error { // Tootet den gerenderten Text.
+	status, err := render(m.template, notice)
+	if err != nil {
+		return err
+	}
+	form := url.Values{"status": {status}}
+	if m.visibility != "" {
+		form.Set("visibility", m.visibility)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.server+"/api/v1/statuses", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Authorization", "Bearer "+m.token)
+	req.Header.Set("Idempotency-Key", notice.ID) // Mastodon verwirft doppelte Posts mit gleichem Key (z.B. bei Retries).
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("mastodon api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package notify // Paket "notify": Matrix-Kanal über die Client-Server API.
+
+import ( // Import-Block: Standardbibliothek für HTTP/JSON.
+	"bytes"         // Request-Body.
+	"context"       // Timeout.
+	"encoding/json" // Event-Content.
+	"fmt"           // Fehlertexte.
+	"html"          // Escaping für formatted_body.
+	"io"            // Response-Body lesen.
+	"net/http"      // API-Call.
+	"net/url"       // Room-ID/Txn-ID im Pfad escapen.
+	"strings"       // Trim.
+	"time"          // Timeout-Dauer.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type matrix struct { // Konfiguration eines Matrix-Bots + Raums.
+	homeserver string // z.B. https://matrix.org (MATRIX_HOMESERVER).
+	token      string // Access Token des Bot-Users (MATRIX_ACCESS_TOKEN).
+	room       string // Raum-ID, z.B. !abc:matrix.org (MATRIX_ROOM_ID).
+	template   string // Text-Template (MATRIX_TEMPLATE).
+}
+
+func newMatrix() (*matrix, bool) { // Aktiv, sobald Homeserver, Token und Raum gesetzt sind.
+	m := &matrix{
+		homeserver: strings.TrimRight(env.ReadEnv("MATRIX_HOMESERVER"), "/"),
+		token:      env.ReadEnv("MATRIX_ACCESS_TOKEN"),
+		room:       env.ReadEnv("MATRIX_ROOM_ID"),
+		template:   env.ReadEnv("MATRIX_TEMPLATE"),
+	}
+	return m, m.homeserver != "" && m.token != "" && m.room != ""
+}
+
+func (m *matrix) Name() string { return "matrix" }
+
+func (m *matrix) Notify(notice Notice) error { // Sendet ein m.room.message (m.notice) mit Text- und HTML-Body.
+	text, err := render(m.template, notice)
+	if err != nil {
+		return err
+	}
+	content := map[string]string{
+		"msgtype":        "m.notice", // m.notice: Bots sollen nicht auf Notices reagieren (keine Bot-Schleifen).
+		"body":           text,
+		"format":         "org.matrix.custom.html",
+		"formatted_body": fmt.Sprintf(`<a href="%s"><strong>%s</strong></a>`, html.EscapeString(notice.Link), html.EscapeString(notice.Title)),
+	}
+	body, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	// Die Entry-ID dient als Transaktions-ID: wiederholte Sends desselben Entries werden vom Server dedupliziert.
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s", m.homeserver, url.PathEscape(m.room), url.PathEscape(notice.ID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+m.token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("matrix api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
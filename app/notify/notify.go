@@ -0,0 +1,144 @@
+package notify // Paket "notify": verteilt neu hinzugefügte Entries an optionale Kanäle (Mastodon, Bluesky, …).
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"           // Fehler mit Kanalnamen.
+	"strings"       // Template-Ausgabe trimmen.
+	"text/template" // Nachrichtentext ist pro Kanal als Go-Template konfigurierbar.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type Notice struct { // Was ein Kanal über einen neuen Entry erfährt.
+	ID         string   // Entry-ID.
+	Title      string   // Titel.
+	Link       string   // Link zum Original.
+	Content    string   // HTML-Content (für Kanäle, die mehr als Titel+Link zeigen).
+	Image      string   // Beitrags- bzw. Vorschaubild (URL), leer wenn der Entry keins hat.
+	Categories []string // Kategorien (z.B. für Hashtags).
+	Provider   string   // Name der Quelle.
+}
+
+type Notifier interface { // Ein Kanal, der über neue Entries informiert.
+	Name() string               // Kurzname für Logs/Fehler.
+	Notify(notice Notice) error // Sendet genau eine Nachricht.
+}
+
+type channeled interface { // Optional: eigener Schlüssel in Sent, wenn ein Name mehrere Ziele hat (z.B. je Webhook-URL).
+	Channel() string
+}
+
+func channel(n Notifier) string { // Schlüssel in Sent; Default ist der Name.
+	if c, ok := n.(channeled); ok {
+		return c.Channel()
+	}
+	return n.Name()
+}
+
+const defaultTemplate = "{{.Title}}\n\n{{.Link}}" // Default-Text: Titel + Link.
+
+func Enabled() []Notifier { // Liefert alle Kanäle, deren Zugangsdaten gesetzt sind.
+	_ = env.LoadDotEnv() // Best-effort: .env laden.
+	notifiers := []Notifier{}
+	if n, ok := newMastodon(); ok {
+		notifiers = append(notifiers, n)
+	}
+	if n, ok := newTelegram(); ok {
+		notifiers = append(notifiers, n)
+	}
+	if n, ok := newMatrix(); ok {
+		notifiers = append(notifiers, n)
+	}
+	if n, ok := newX(); ok {
+		notifiers = append(notifiers, n)
+	}
+	if n, ok := newBluesky(); ok {
+		notifiers = append(notifiers, n)
+	}
+	notifiers = append(notifiers, newWebhooks()...)
+	return notifiers
+}
+
+type Sent map[string][]string // Kanal → IDs bereits angekündigter Entries (state.json): ein wiederholter Lauf postet nichts doppelt.
+
+func (sent Sent) has(channel, id string) bool {
+	for _, candidate := range sent[channel] {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+func Dispatch(notices []Notice, sent Sent) ([]string, []error) { // Schickt jede Notice an jeden Kanal (außer schon gesendete) und vermerkt Erfolge in sent; liefert die IDs mit mindestens einem Fehlschlag. Fehler einzelner Kanäle blockieren die anderen nicht.
+	failed := []string{}
+	errs := []error{}
+	notifiers := Enabled()
+	for _, notice := range notices {
+		ok := true
+		for _, n := range notifiers {
+			if !optedIn(n, notice) || !providerEnabled(n, notice) || sent.has(channel(n), notice.ID) { // Opt-in pro Kanal (z.B. X_CATEGORIES=Releases, BLUESKY_PROVIDERS=wordpress-releases).
+				continue
+			}
+			if err := n.Notify(notice); err != nil {
+				errs = append(errs, fmt.Errorf("notify %s: %w", n.Name(), err))
+				ok = false
+				continue // Nicht vermerken: ein erneuter Dispatch versucht nur diesen Kanal nochmal.
+			}
+			if sent != nil {
+				sent[channel(n)] = append(sent[channel(n)], notice.ID)
+			}
+		}
+		if !ok {
+			failed = append(failed, notice.ID)
+		}
+	}
+	return failed, errs
+}
+
+func providerEnabled(n Notifier, notice Notice) bool { // Leere <NAME>_PROVIDERS → alle Quellen; sonst nur die genannten.
+	filtered := false
+	for _, provider := range strings.Split(env.ReadEnv(strings.ToUpper(n.Name())+"_PROVIDERS"), ",") {
+		if provider = strings.TrimSpace(provider); provider == "" {
+			continue
+		}
+		filtered = true
+		if strings.EqualFold(provider, notice.Provider) {
+			return true
+		}
+	}
+	return !filtered
+}
+
+func optedIn(n Notifier, notice Notice) bool { // Leere <NAME>_CATEGORIES → alles; sonst mindestens eine passende Kategorie.
+	wanted := strings.Split(env.ReadEnv(strings.ToUpper(n.Name())+"_CATEGORIES"), ",")
+	filtered := false
+	for _, category := range wanted {
+		category = strings.TrimSpace(category)
+		if category == "" {
+			continue
+		}
+		filtered = true
+		for _, have := range notice.Categories {
+			if strings.EqualFold(have, category) {
+				return true
+			}
+		}
+	}
+	return !filtered
+}
+
+func render(pattern string, notice Notice) (string, error) { // Rendert das Kanal-Template; leeres Pattern → Default.
+	if strings.TrimSpace(pattern) == "" {
+		pattern = defaultTemplate
+	}
+	pattern = strings.ReplaceAll(pattern, `\n`, "\n") // Erlaubt "\n" in ENV-Werten (GitHub Variables sind einzeilig).
+	tpl, err := template.New("notice").Parse(pattern)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tpl.Execute(&b, notice); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
@@ -0,0 +1,111 @@
+package notify // Paket "notify": Telegram-Kanal über die Bot API (sendPhoto/sendMessage).
+
+import ( // Import-Block: Standardbibliothek für HTTP/JSON/HTML.
+	"bytes"         // Request-Body.
+	"context"       // Timeout.
+	"encoding/json" // Bot API erwartet JSON.
+	"fmt"           // Fehlertexte.
+	"html/template" // HTML-Template: Titel etc. werden für parse_mode=HTML escaped.
+	"io"            // Response-Body lesen.
+	"net/http"      // API-Call.
+	"regexp"        // Erstes <img> im Content finden.
+	"strings"       // Trim/Builder.
+	"time"          // Timeout-Dauer.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const telegramAPI = "https://api.telegram.org"                                         // Basis-URL der Bot API.
+const telegramDefaultTemplate = "<b>{{.Title}}</b>\n\n{{.Link}}"                       // Default: fetter Titel + Link.
+const telegramCaptionLimit = 1024                                                      // Telegram-Limit für Foto-Captions.
+var imgSrcPattern = regexp.MustCompile(`(?is)<img\b[^>]*\ssrc\s*=\s*["']([^"']+)["']`) // src des ersten <img>.
+
+type telegram struct { // Konfiguration eines Telegram-Bots + Zielkanals.
+	token    string // Bot-Token (TELEGRAM_BOT_TOKEN).
+	chat     string // Chat-ID oder @kanalname (TELEGRAM_CHAT_ID).
+	template string // HTML-Template (TELEGRAM_TEMPLATE).
+}
+
+func newTelegram() (*telegram, bool) { // Aktiv, sobald Token und Chat gesetzt sind.
+	t := &telegram{
+		token:    env.ReadEnv("TELEGRAM_BOT_TOKEN"),
+		chat:     env.ReadEnv("TELEGRAM_CHAT_ID"),
+		template: env.ReadEnv("TELEGRAM_TEMPLATE"),
+	}
+	return t, t.token != "" && t.chat != ""
+}
+
+func (t *telegram) Name() string { return "telegram" }
+
+func (t *telegram) Notify(notice Notice) error { // Mit Bild: sendPhoto + Caption, sonst sendMessage.
+	text, err := t.render(notice)
+	if err != nil {
+		return err
+	}
+	image := notice.Image
+	if image == "" { // Kein Beitragsbild am Entry: erstes <img> im Content.
+		image = firstImage(notice.Content)
+	}
+	if image != "" && len([]rune(text)) <= telegramCaptionLimit {
+		return t.call("sendPhoto", map[string]any{
+			"chat_id":    t.chat,
+			"photo":      image, // Telegram lädt das Bild selbst von der URL.
+			"caption":    text,
+			"parse_mode": "HTML",
+		})
+	}
+	return t.call("sendMessage", map[string]any{
+		"chat_id":    t.chat,
+		"text":       text,
+		"parse_mode": "HTML",
+	})
+}
+
+func (t *telegram) render(notice Notice) (string, error) { // Wie render(), aber mit HTML-Escaping der Werte.
+	pattern := t.template
+	if strings.TrimSpace(pattern) == "" {
+		pattern = telegramDefaultTemplate
+	}
+	pattern = strings.ReplaceAll(pattern, `\n`, "\n")
+	tpl, err := template.New("telegram").Parse(pattern)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tpl.Execute(&b, notice); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+func (t *telegram) call(method string, payload map[string]any) error { // POST /bot{token}/{method}.
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/bot%s/%s", telegramAPI, t.token, method), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("telegram api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func firstImage(content string) string { // Liefert die URL des ersten <img> im HTML (oder "").
+	if match := imgSrcPattern.FindStringSubmatch(content); len(match) == 2 {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package notify // Paket "notify": generische Webhooks (JSON-POST mit HMAC-Signatur), z.B. für das WapuuGotchi-Plugin-Backend, Discord oder Slack.
+
+import ( // Import-Block: Standardbibliothek für HTTP/JSON/HMAC.
+	"bytes"         // Request-Body.
+	"context"       // Timeout.
+	"crypto/hmac"   // Signatur.
+	"crypto/sha256" // HMAC-SHA256.
+	"encoding/hex"  // Signatur als Hex.
+	"encoding/json" // Payload.
+	"fmt"           // Fehlertexte.
+	"io"            // Response-Body lesen.
+	"net/http"      // API-Call.
+	"strings"       // URLs splitten.
+	"time"          // Timeout-Dauer.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type webhook struct { // Konfiguration eines Webhook-Empfängers.
+	url      string // Ziel-URL (ein Eintrag aus WEBHOOK_URLS).
+	channel  string // Schlüssel in state.json: jede URL merkt sich ihre Zustellungen selbst.
+	secret   string // Schlüssel für die HMAC-Signatur (WEBHOOK_SECRET); leer = unsigniert.
+	template string // Text für Chat-Webhooks (WEBHOOK_TEMPLATE).
+}
+
+type webhookPayload struct { // Body jedes Webhooks.
+	Event      string   `json:"event"` // Immer "entry.created".
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Link       string   `json:"link"`
+	Provider   string   `json:"provider,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+	Text       string   `json:"text"`    // Slack: Nachrichtentext.
+	Content    string   `json:"content"` // Discord: Nachrichtentext.
+}
+
+func newWebhooks() []Notifier { // Ein Kanal pro URL in WEBHOOK_URLS: scheitert eine, bekommen die anderen die Notice nicht erneut.
+	urls := []string{}
+	for _, u := range strings.Split(env.ReadEnv("WEBHOOK_URLS"), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	notifiers := []Notifier{}
+	for _, u := range urls {
+		w := &webhook{url: u, channel: "webhook", secret: env.ReadEnv("WEBHOOK_SECRET"), template: env.ReadEnv("WEBHOOK_TEMPLATE")}
+		if len(urls) > 1 { // Eine einzelne URL behält "webhook" (bisheriger Stand); sonst Hash statt URL: Webhook-URLs enthalten oft ein Token, state.json liegt im Repo.
+			sum := sha256.Sum256([]byte(u))
+			w.channel = "webhook:" + hex.EncodeToString(sum[:6])
+		}
+		notifiers = append(notifiers, w)
+	}
+	return notifiers
+}
+
+func (w *webhook) Name() string    { return "webhook" } // WEBHOOK_CATEGORIES/WEBHOOK_PROVIDERS gelten für alle URLs.
+func (w *webhook) Channel() string { return w.channel }
+
+func (w *webhook) Notify(notice Notice) error { // POSTet die Payload an diese URL.
+	text, err := render(w.template, notice)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(webhookPayload{Event: "entry.created", ID: notice.ID, Title: notice.Title, Link: notice.Link, Provider: notice.Provider, Categories: notice.Categories, Text: text, Content: text})
+	if err != nil {
+		return err
+	}
+	return w.post(w.url, notice.ID, body)
+}
+
+func (w *webhook) post(endpoint, id string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Feed-Event", "entry.created")
+	req.Header.Set("X-Feed-Delivery", id) // Entry-ID: Empfänger können wiederholte Zustellungen erkennen.
+	if w.secret != "" {                   // Wie GitHub: sha256=HEX(HMAC-SHA256(secret, body)).
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		req.Header.Set("X-Feed-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: status %s: %s", req.URL.Host, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package notify // Paket "notify": X/Twitter-Kanal (API v2, OAuth 1.0a User Context).
+
+import ( // Import-Block: Standardbibliothek für HTTP, JSON und OAuth-Signatur.
+	"bytes"           // Request-Body.
+	"context"         // Timeout.
+	"crypto/hmac"     // HMAC-SHA1 Signatur.
+	"crypto/rand"     // Nonce.
+	"crypto/sha1"     // HMAC-SHA1 Signatur.
+	"encoding/base64" // Signatur-Kodierung.
+	"encoding/hex"    // Nonce-Kodierung.
+	"encoding/json"   // Tweet-Payload.
+	"fmt"             // Fehlertexte + Percent-Encoding.
+	"io"              // Response-Body lesen.
+	"net/http"        // API-Call.
+	"sort"            // OAuth-Parameter sortieren.
+	"strconv"         // Timestamp.
+	"strings"         // Builder/Join.
+	"time"            // Timestamp + Timeout.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const xTweetsEndpoint = "https://api.twitter.com/2/tweets" // POST /2/tweets.
+
+type xPoster struct { // OAuth 1.0a Zugangsdaten eines X-Accounts.
+	apiKey       string // Consumer Key (X_API_KEY).
+	apiSecret    string // Consumer Secret (X_API_SECRET).
+	accessToken  string // Access Token des Accounts (X_ACCESS_TOKEN).
+	accessSecret string // Access Token Secret (X_ACCESS_SECRET).
+	template     string // Text-Template (X_TEMPLATE).
+}
+
+func newX() (*xPoster, bool) { // Aktiv, sobald alle vier OAuth-Werte gesetzt sind.
+	x := &xPoster{
+		apiKey:       env.ReadEnv("X_API_KEY"),
+		apiSecret:    env.ReadEnv("X_API_SECRET"),
+		accessToken:  env.ReadEnv("X_ACCESS_TOKEN"),
+		accessSecret: env.ReadEnv("X_ACCESS_SECRET"),
+		template:     env.ReadEnv("X_TEMPLATE"),
+	}
+	return x, x.apiKey != "" && x.apiSecret != "" && x.accessToken != "" && x.accessSecret != ""
+}
+
+func (x *xPoster) Name() string { return "x" }
+
+func (x *xPoster) Notify(notice Notice) error { // Postet den gerenderten Text als Tweet.
+	text, err := render(x.template, notice)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, xTweetsEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", x.authorization(http.MethodPost, xTweetsEndpoint))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("x api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func (x *xPoster) authorization(method, endpoint string) string { // OAuth 1.0a Header (JSON-Body wird nicht mitsigniert).
+	nonce := make([]byte, 16)
+	_, _ = rand.Read(nonce)
+	params := map[string]string{
+		"oauth_consumer_key":     x.apiKey,
+		"oauth_nonce":            hex.EncodeToString(nonce),
+		"oauth_signature_method": "HMAC-SHA1",
+		"oauth_timestamp":        strconv.FormatInt(time.Now().Unix(), 10),
+		"oauth_token":            x.accessToken,
+		"oauth_version":          "1.0",
+	}
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // Signatur-Basis verlangt sortierte Parameter.
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, percentEncode(key)+"="+percentEncode(params[key]))
+	}
+	base := method + "&" + percentEncode(endpoint) + "&" + percentEncode(strings.Join(pairs, "&"))
+
+	mac := hmac.New(sha1.New, []byte(percentEncode(x.apiSecret)+"&"+percentEncode(x.accessSecret)))
+	mac.Write([]byte(base))
+	params["oauth_signature"] = base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	keys = append(keys, "oauth_signature")
+	sort.Strings(keys)
+	header := make([]string, 0, len(keys))
+	for _, key := range keys {
+		header = append(header, fmt.Sprintf(`%s="%s"`, percentEncode(key), percentEncode(params[key])))
+	}
+	return "OAuth " + strings.Join(header, ", ")
+}
+
+func percentEncode(value string) string { // RFC 3986 Percent-Encoding, wie OAuth 1.0a es verlangt.
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
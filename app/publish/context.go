@@ -0,0 +1,27 @@
+package publish // Paket "publish": Laufkontext für Uploads, Purges und Pings – bricht laufende Requests bei SIGINT/SIGTERM ab.
+
+import ( // Import-Block: Standardbibliothek.
+	"context" // Abbruch + Timeout.
+	"sync"    // SetContext und Requests aus verschiedenen Goroutinen.
+	"time"    // Frist pro Request.
+)
+
+var run = struct { // Kontext des laufenden Updates; ohne SetContext: context.Background().
+	sync.Mutex
+	ctx context.Context
+}{ctx: context.Background()}
+
+func SetContext(ctx context.Context) { // Laufkontext setzen (RunFeedUpdate); nil = ohne Abbruch.
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	run.Lock()
+	defer run.Unlock()
+	run.ctx = ctx
+}
+
+func requestContext(timeout time.Duration) (context.Context, context.CancelFunc) { // Frist pro Request, abgeleitet vom Laufkontext.
+	run.Lock()
+	defer run.Unlock()
+	return context.WithTimeout(run.ctx, timeout)
+}
@@ -0,0 +1,152 @@
+package publish // Paket "publish": FTP-Upload (klassisches Shared Hosting) über net/textproto.
+
+import ( // Import-Block: Standardbibliothek für TCP und das FTP-Protokoll.
+	"fmt"           // Befehle formatieren + Fehlertexte.
+	"net"           // Control- und Data-Verbindung.
+	"net/textproto" // Zeilenbasiertes Protokoll mit Statuscodes (wie SMTP/FTP).
+	"path"          // Remote-Pfade sind immer "/"-getrennt.
+	"strconv"       // Port aus der PASV-Antwort.
+	"strings"       // Parsen der PASV-Antwort.
+	"time"          // Timeouts.
+
+	"wapuugotchi/feed/app/env"
+)
+
+func publishFTP(artifacts []artifact) error { // Lädt alle Artefakte per FTP (passiv, binär) hoch.
+	host, err := requireEnv("PUBLISH_FTP_HOST")
+	if err != nil {
+		return err
+	}
+	user, err := requireEnv("PUBLISH_FTP_USER")
+	if err != nil {
+		return err
+	}
+	password := env.ReadEnv("PUBLISH_FTP_PASSWORD")
+	port := env.ReadEnv("PUBLISH_FTP_PORT")
+	if port == "" {
+		port = "21"
+	}
+	dir := env.ReadEnv("PUBLISH_FTP_DIR") // Zielverzeichnis auf dem Server, z.B. "/htdocs".
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), 30*time.Second)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Minute)) // Harte Obergrenze für die gesamte Session.
+	tp := textproto.NewConn(conn)
+	defer tp.Close()
+
+	if _, _, err := tp.ReadResponse(220); err != nil { // Begrüßung des Servers.
+		return err
+	}
+	code, _, err := ftpCmd(tp, 0, "USER %s", user)
+	if err != nil {
+		return err
+	}
+	if code == 331 { // Server verlangt ein Passwort.
+		if _, _, err := ftpCmd(tp, 230, "PASS %s", password); err != nil {
+			return err
+		}
+	}
+	if _, _, err := ftpCmd(tp, 200, "TYPE I"); err != nil { // Binärmodus: keine Zeilenenden-Konvertierung.
+		return err
+	}
+
+	for _, a := range artifacts {
+		remote := path.Join(dir, a.Name)
+		ftpMkdirAll(tp, path.Dir(remote))
+		tmp := remote + ".tmp" // Erst temporär hochladen und dann umbenennen, damit nie ein halber Feed ausgeliefert wird.
+		if err := ftpStore(tp, host, tmp, a.Data); err != nil {
+			return fmt.Errorf("%s: %w", remote, err)
+		}
+		if _, _, err := ftpCmd(tp, 350, "RNFR %s", tmp); err != nil {
+			return err
+		}
+		if _, _, err := ftpCmd(tp, 250, "RNTO %s", remote); err != nil {
+			return err
+		}
+	}
+
+	_, _, _ = ftpCmd(tp, 221, "QUIT")
+	return nil
+}
+
+func ftpCmd(tp *textproto.Conn, expect int, format string, args ...any) (int, string, error) { // Sendet einen Befehl und liest die Antwort.
+	id, err := tp.Cmd(format, args...)
+	if err != nil {
+		return 0, "", err
+	}
+	tp.StartResponse(id)
+	defer tp.EndResponse(id)
+	return tp.ReadResponse(expect) // expect=0 akzeptiert jeden Code (Caller entscheidet).
+}
+
+func ftpMkdirAll(tp *textproto.Conn, dir string) { // Legt Verzeichnisse an; Fehler (z.B. "existiert schon") werden ignoriert.
+	if dir == "" || dir == "." || dir == "/" {
+		return
+	}
+	current := ""
+	if strings.HasPrefix(dir, "/") {
+		current = "/"
+	}
+	for _, part := range strings.Split(strings.Trim(dir, "/"), "/") {
+		current = path.Join(current, part)
+		_, _, _ = ftpCmd(tp, 0, "MKD %s", current)
+	}
+}
+
+func ftpStore(tp *textproto.Conn, host, remote string, data []byte) error { // STOR über eine passive Datenverbindung.
+	_, msg, err := ftpCmd(tp, 227, "PASV")
+	if err != nil {
+		return err
+	}
+	port, err := parsePasvPort(msg)
+	if err != nil {
+		return err
+	}
+	// Die IP aus der PASV-Antwort wird ignoriert: hinter NAT liefern viele Hoster eine interne Adresse.
+	dataConn, err := net.DialTimeout("tcp", net.JoinHostPort(host, strconv.Itoa(port)), 30*time.Second)
+	if err != nil {
+		return err
+	}
+
+	id, err := tp.Cmd("STOR %s", remote)
+	if err != nil {
+		dataConn.Close()
+		return err
+	}
+	tp.StartResponse(id)
+	_, _, err = tp.ReadResponse(1) // 1xx: Server ist bereit, Daten zu empfangen.
+	tp.EndResponse(id)
+	if err != nil {
+		dataConn.Close()
+		return err
+	}
+	if _, err := dataConn.Write(data); err != nil {
+		dataConn.Close()
+		return err
+	}
+	if err := dataConn.Close(); err != nil { // Schließen signalisiert dem Server das Dateiende.
+		return err
+	}
+	_, _, err = tp.ReadResponse(226) // Transfer abgeschlossen.
+	return err
+}
+
+func parsePasvPort(msg string) (int, error) { // Parst "Entering Passive Mode (h1,h2,h3,h4,p1,p2)".
+	start := strings.Index(msg, "(")
+	end := strings.LastIndex(msg, ")")
+	if start == -1 || end <= start {
+		return 0, fmt.Errorf("invalid PASV response: %s", msg)
+	}
+	parts := strings.Split(msg[start+1:end], ",")
+	if len(parts) != 6 {
+		return 0, fmt.Errorf("invalid PASV response: %s", msg)
+	}
+	p1, err1 := strconv.Atoi(strings.TrimSpace(parts[4]))
+	p2, err2 := strconv.Atoi(strings.TrimSpace(parts[5]))
+	if err1 != nil || err2 != nil {
+		return 0, fmt.Errorf("invalid PASV response: %s", msg)
+	}
+	return p1*256 + p2, nil
+}
@@ -0,0 +1,82 @@
+package publish // Paket "publish": Veröffentlichung in einen gh-pages Branch über Git-Plumbing.
+
+import ( // Import-Block: Standardbibliothek für Prozesse und Dateien.
+	"bytes"         // Stdin für git hash-object.
+	"fmt"           // Fehlertexte.
+	"os"            // Temporärer Index, Umgebung.
+	"os/exec"       // Aufruf des git Binaries.
+	"path/filepath" // Pfad zum temporären Index.
+	"strings"       // Output trimmen.
+
+	"wapuugotchi/feed/app/env"
+)
+
+func publishGitHubPages(root string, artifacts []artifact) error { // Committet die Artefakte auf den Pages-Branch und pusht ihn.
+	branch := env.ReadEnv("PUBLISH_GH_PAGES_BRANCH") // Zielbranch, Default gh-pages.
+	if branch == "" {
+		branch = "gh-pages"
+	}
+	remote := env.ReadEnv("PUBLISH_GH_PAGES_REMOTE") // Remote, Default origin (in Actions bereits authentifiziert).
+	if remote == "" {
+		remote = "origin"
+	}
+
+	tmp, err := os.MkdirTemp("", "gh-pages-") // Eigener Index, damit der Arbeitsbaum/Index des Repos unberührt bleibt.
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	git := func(stdin []byte, args ...string) (string, error) { // Kleiner Wrapper: git im Repo-Root mit temporärem Index.
+		cmd := exec.Command("git", args...)
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(tmp, "index"))
+		if stdin != nil {
+			cmd.Stdin = bytes.NewReader(stdin)
+		}
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
+	parent := "" // Bisheriger Stand des Branches (leer, wenn der Branch noch nicht existiert).
+	if _, err := git(nil, "fetch", "--depth", "1", remote, branch); err == nil {
+		if parent, err = git(nil, "rev-parse", "FETCH_HEAD"); err != nil {
+			return err
+		}
+		if _, err := git(nil, "read-tree", parent); err != nil { // Bestehende Dateien im Branch behalten (z.B. CNAME).
+			return err
+		}
+	}
+
+	for _, a := range artifacts { // Jede Datei als Blob schreiben und in den temporären Index eintragen.
+		blob, err := git(a.Data, "hash-object", "-w", "--stdin")
+		if err != nil {
+			return err
+		}
+		if _, err := git(nil, "update-index", "--add", "--cacheinfo", "100644,"+blob+","+a.Name); err != nil {
+			return err
+		}
+	}
+	tree, err := git(nil, "write-tree")
+	if err != nil {
+		return err
+	}
+	if parent != "" { // Keine Änderung gegenüber dem Branch → kein leerer Commit.
+		if parentTree, err := git(nil, "rev-parse", parent+"^{tree}"); err == nil && parentTree == tree {
+			return nil
+		}
+	}
+
+	args := []string{"commit-tree", tree, "-m", "Publish feed"}
+	if parent != "" {
+		args = append(args, "-p", parent)
+	}
+	commit, err := git(nil, args...)
+	if err != nil {
+		return err
+	}
+	_, err = git(nil, "push", remote, commit+":refs/heads/"+branch)
+	return err
+}
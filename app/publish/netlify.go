@@ -0,0 +1,103 @@
+package publish // Paket "publish": Deploy über die Netlify API (File-Digest-Deploy: übrige Dateien der Site bleiben erhalten).
+
+import ( // Import-Block: Standardbibliothek für Hashes, JSON und HTTP.
+	"bytes"         // Request-Bodies.
+	"crypto/sha1"   // Netlify identifiziert Dateien per SHA1.
+	"encoding/hex"  // SHA1 als Hex-String.
+	"encoding/json" // API-Requests/-Antworten.
+	"fmt"           // Fehlertexte.
+	"io"            // Response-Body lesen.
+	"net/http"      // HTTP-Requests an die Netlify API.
+	"net/url"       // Dateipfade in der Upload-URL.
+	"strings"       // Trim für Fehlermeldungen.
+	"time"          // Timeout-Dauer.
+)
+
+const netlifyAPI = "https://api.netlify.com/api/v1" // Basis-URL der Netlify API.
+
+type netlifyFile struct { // Datei des veröffentlichten Deploys (GET /sites/{id}/files).
+	Path string `json:"path"` // Mit führendem "/".
+	SHA  string `json:"sha"`
+}
+
+type netlifyDeploy struct { // Antwort auf POST /sites/{id}/deploys.
+	ID       string   `json:"id"`
+	Required []string `json:"required"` // SHA1 der Dateien, die Netlify noch nicht kennt.
+}
+
+func publishNetlify(artifacts []artifact) error { // Neuer Deploy = aktuelle Dateien der Site + Artefakte; hochgeladen wird nur, was Netlify noch fehlt. Netlify Functions werden dabei nicht übertragen.
+	token, err := requireEnv("NETLIFY_AUTH_TOKEN") // Personal Access Token.
+	if err != nil {
+		return err
+	}
+	site, err := requireEnv("NETLIFY_SITE_ID") // Site-ID (API ID aus den Site-Settings).
+	if err != nil {
+		return err
+	}
+
+	var current []netlifyFile // Ein Deploy enthält genau die gelisteten Dateien: ohne die bestehenden wäre der Rest der Site weg.
+	if err := netlifyRequest(token, http.MethodGet, fmt.Sprintf("%s/sites/%s/files", netlifyAPI, site), "", nil, &current); err != nil {
+		return fmt.Errorf("list site files: %w", err)
+	}
+	files := map[string]string{} // Pfad → SHA1.
+	for _, file := range current {
+		files[file.Path] = file.SHA
+	}
+	data := map[string][]byte{}  // SHA1 → Inhalt der Artefakte (für den Upload).
+	paths := map[string]string{} // SHA1 → Pfad des Artefakts.
+	for _, a := range artifacts {
+		sum := sha1.Sum(a.Data)
+		sha := hex.EncodeToString(sum[:])
+		files["/"+a.Name] = sha
+		data[sha], paths[sha] = a.Data, "/"+a.Name
+	}
+
+	body, err := json.Marshal(map[string]any{"files": files})
+	if err != nil {
+		return err
+	}
+	var deploy netlifyDeploy
+	if err := netlifyRequest(token, http.MethodPost, fmt.Sprintf("%s/sites/%s/deploys", netlifyAPI, site), "application/json", body, &deploy); err != nil {
+		return fmt.Errorf("create deploy: %w", err)
+	}
+	for _, sha := range deploy.Required {
+		content, ok := data[sha]
+		if !ok { // Bestehende Datei, die Netlify nicht mehr hat: kann nur der ursprüngliche Deploy liefern.
+			return fmt.Errorf("deploy %s requires unknown file %s", deploy.ID, sha)
+		}
+		upload := fmt.Sprintf("%s/deploys/%s/files%s", netlifyAPI, deploy.ID, (&url.URL{Path: paths[sha]}).EscapedPath())
+		if err := netlifyRequest(token, http.MethodPut, upload, "application/octet-stream", content, nil); err != nil {
+			return fmt.Errorf("upload %s: %w", paths[sha], err)
+		}
+	}
+	return nil
+}
+
+func netlifyRequest(token, method, endpoint, ctype string, body []byte, result any) error { // Gemeinsamer API-Call; result (falls gesetzt) wird aus dem JSON der Antwort gefüllt.
+	ctx, cancel := requestContext(60 * time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if ctype != "" {
+		req.Header.Set("Content-Type", ctype)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("netlify api status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if result == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
@@ -0,0 +1,91 @@
+package publish // Paket "publish": Update-Pings (IndexNow + klassische weblogUpdates-Endpunkte).
+
+import ( // Import-Block: Standardbibliothek für HTTP und XML-RPC.
+	"encoding/xml" // Escaping der XML-RPC Parameter.
+	"fmt"          // Fehlertexte + Payload.
+	"io"           // Response-Body verwerfen/lesen.
+	"net/http"     // Ping-Requests.
+	"net/url"      // Query-Parameter für IndexNow.
+	"strings"      // Trim/Builder.
+	"time"         // Timeout-Dauer.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const indexNowEndpoint = "https://api.indexnow.org/indexnow" // Gemeinsamer Endpoint, leitet an alle IndexNow-Suchmaschinen weiter.
+
+func FeedURL() string { // Öffentliche URL des Feeds: FEED_URL oder PUBLISH_BASE_URL + "/feed.xml".
+	_ = env.LoadDotEnv()
+	if feedURL := env.ReadEnv("FEED_URL"); feedURL != "" {
+		return feedURL
+	}
+	if base := strings.TrimRight(env.ReadEnv("PUBLISH_BASE_URL"), "/"); base != "" {
+		return base + "/feed.xml"
+	}
+	return ""
+}
+
+func Ping(name, siteURL string) []error { // Pingt alle konfigurierten Dienste; liefert alle Fehler (Pings sind best-effort).
+	feedURL := FeedURL()
+	if feedURL == "" {
+		return nil // Ohne öffentliche Feed-URL gibt es nichts anzukündigen.
+	}
+	errs := []error{}
+	if key := env.ReadEnv("INDEXNOW_KEY"); key != "" {
+		if err := pingIndexNow(feedURL, key); err != nil {
+			errs = append(errs, fmt.Errorf("indexnow: %w", err))
+		}
+	}
+	for _, endpoint := range splitList(env.ReadEnv("FEED_PING_URLS")) { // z.B. "https://rpc.pingomatic.com/".
+		if err := pingWeblogUpdates(endpoint, name, siteURL, feedURL); err != nil {
+			errs = append(errs, fmt.Errorf("ping %s: %w", endpoint, err))
+		}
+	}
+	return errs
+}
+
+func pingIndexNow(feedURL, key string) error { // GET /indexnow?url=…&key=… (Key-Datei muss auf dem Host liegen).
+	query := url.Values{"url": {feedURL}, "key": {key}}
+	if location := env.ReadEnv("INDEXNOW_KEY_LOCATION"); location != "" { // Optional, wenn die Key-Datei nicht unter /{key}.txt liegt.
+		query.Set("keyLocation", location)
+	}
+	return pingRequest(http.MethodGet, indexNowEndpoint+"?"+query.Encode(), "", "")
+}
+
+func pingWeblogUpdates(endpoint, name, siteURL, feedURL string) error { // XML-RPC weblogUpdates.extendedPing(name, url, changesURL, rssURL).
+	if siteURL == "" {
+		siteURL = feedURL
+	}
+	var params strings.Builder
+	for _, value := range []string{name, siteURL, siteURL, feedURL} {
+		params.WriteString("<param><value><string>")
+		_ = xml.EscapeText(&params, []byte(value))
+		params.WriteString("</string></value></param>")
+	}
+	body := xml.Header + "<methodCall><methodName>weblogUpdates.extendedPing</methodName><params>" + params.String() + "</params></methodCall>"
+	return pingRequest(http.MethodPost, endpoint, body, "text/xml")
+}
+
+func pingRequest(method, target, body, ctype string) error { // Gemeinsamer HTTP-Call; nur 2xx gilt als Erfolg.
+	ctx, cancel := requestContext(15 * time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, target, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if ctype != "" {
+		req.Header.Set("Content-Type", ctype)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
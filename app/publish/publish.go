@@ -0,0 +1,94 @@
+package publish // Paket "publish": lädt die generierten Artefakte (feed.xml, index.html, …) zu einem Hosting-Ziel hoch.
+
+import ( // Import-Block: Standardbibliothek + Env-Helper.
+	"fmt"           // Formatierte Fehler (unknown target, fehlende Konfiguration).
+	"os"            // Dateien lesen, die hochgeladen werden sollen.
+	"path/filepath" // Relative Pfade (Keys) aus Root + Datei ableiten.
+	"strings"       // Normalisieren/Splitten der Target-Liste.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type artifact struct { // Eine hochzuladende Datei: relativer Name + Inhalt.
+	Name string // Relativer Pfad (z.B. "feed.xml"); dient als S3-Key bzw. Pfad im Branch/Deploy.
+	Data []byte // Dateiinhalt.
+}
+
+func Configured() bool { // Prüft, ob überhaupt ein Publish-Ziel konfiguriert ist.
+	return len(targets()) > 0
+}
+
+func Publish(root string, files []string) error { // Öffentliche API: lädt files (absolute Pfade unter root) zu allen konfigurierten Zielen hoch.
+	artifacts, err := readArtifacts(root, files) // Dateien einmal lesen, damit alle Ziele denselben Stand bekommen.
+	if err != nil {
+		return err
+	}
+	for _, target := range targets() { // PUBLISH_TARGET kann mehrere Ziele enthalten (kommagetrennt).
+		var err error
+		switch target {
+		case "s3":
+			err = publishS3(artifacts)
+		case "gh-pages":
+			err = publishGitHubPages(root, artifacts)
+		case "netlify":
+			err = publishNetlify(artifacts)
+		case "ftp":
+			err = publishFTP(artifacts)
+		case "sftp":
+			err = publishSFTP(artifacts)
+		default:
+			err = fmt.Errorf("unknown publish target: %s", target) // Klarer Fehler: falscher Target-Wert.
+		}
+		if err != nil {
+			return fmt.Errorf("publish %s: %w", target, err) // Ziel im Fehler nennen, damit man im CI-Log sieht, was scheiterte.
+		}
+	}
+	return purgeCDN(artifacts) // Erst nach erfolgreichem Upload: Edge-Caches leeren (optional, CDN_PURGE).
+}
+
+func targets() []string { // Liest PUBLISH_TARGET (ENV oder .env) und liefert die normalisierte Liste der Ziele.
+	_ = env.LoadDotEnv()                                             // Best-effort: .env laden, setzt nur fehlende Variablen.
+	return splitList(strings.ToLower(env.ReadEnv("PUBLISH_TARGET"))) // Lowercase für stabile Switch-Logik.
+}
+
+func readArtifacts(root string, files []string) ([]artifact, error) { // Liest alle Dateien und berechnet ihre relativen Namen.
+	artifacts := make([]artifact, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		name, err := filepath.Rel(root, file) // Key relativ zum Repo-Root, damit die Struktur beim Ziel gleich bleibt.
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, artifact{Name: filepath.ToSlash(name), Data: data}) // Immer "/" als Trenner (S3, Git, Netlify).
+	}
+	return artifacts, nil
+}
+
+func ContentType(name string) string { // Liefert den Content-Type für ein Artefakt anhand der Endung.
+	base := strings.ToLower(filepath.Base(name))
+	if strings.HasSuffix(base, ".xml") && (strings.HasPrefix(base, "atom.") || strings.HasSuffix(base, ".atom.xml")) { // Atom-Feeds (atom.xml, atom.videos.de.xml, news.atom.xml).
+		return "application/atom+xml; charset=utf-8"
+	}
+	switch filepath.Ext(base) {
+	case ".xml":
+		return "application/rss+xml; charset=utf-8"
+	case ".html":
+		return "text/html; charset=utf-8"
+	case ".json":
+		return "application/json; charset=utf-8"
+	case ".minisig", ".txt":
+		return "text/plain; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func requireEnv(key string) (string, error) { // Liest eine Pflicht-Variable und liefert einen klaren Fehler, wenn sie fehlt.
+	if val := env.ReadEnv(key); val != "" {
+		return val, nil
+	}
+	return "", fmt.Errorf("missing %s", key)
+}
@@ -0,0 +1,134 @@
+package publish // Paket "publish": optionaler CDN-Purge (Cloudflare/Fastly) nach dem Upload.
+
+import ( // Import-Block: Standardbibliothek für HTTP/JSON.
+	"bytes"         // Request-Body.
+	"encoding/json" // Cloudflare erwartet JSON.
+	"fmt"           // Fehlertexte.
+	"io"            // Response-Body lesen.
+	"net/http"      // API-Calls.
+	"strings"       // Split/Trim.
+	"time"          // Timeout-Dauer.
+
+	"wapuugotchi/feed/app/env"
+)
+
+const cloudflareAPI = "https://api.cloudflare.com/client/v4" // Basis-URL der Cloudflare API.
+const fastlyAPI = "https://api.fastly.com"                   // Basis-URL der Fastly API.
+const cloudflareBatch = 30                                   // Cloudflare nimmt höchstens 30 URLs pro purge_cache-Request an.
+
+func purgeCDN(artifacts []artifact) error { // Purgt die öffentlichen URLs der Artefakte bei allen konfigurierten CDNs.
+	providers := splitList(strings.ToLower(env.ReadEnv("CDN_PURGE"))) // z.B. "cloudflare" oder "cloudflare,fastly".
+	if len(providers) == 0 {
+		return nil // Purge ist optional.
+	}
+	urls := purgeURLs(artifacts)
+	if len(urls) == 0 {
+		return fmt.Errorf("cdn purge: set CDN_PURGE_URLS or PUBLISH_BASE_URL")
+	}
+	for _, provider := range providers {
+		var err error
+		switch provider {
+		case "cloudflare":
+			err = purgeCloudflare(urls)
+		case "fastly":
+			err = purgeFastly(urls)
+		default:
+			err = fmt.Errorf("unknown cdn: %s", provider)
+		}
+		if err != nil {
+			return fmt.Errorf("cdn purge %s: %w", provider, err)
+		}
+	}
+	return nil
+}
+
+func purgeURLs(artifacts []artifact) []string { // Explizite Liste (CDN_PURGE_URLS) oder Basis-URL + Artefaktnamen.
+	if urls := splitList(env.ReadEnv("CDN_PURGE_URLS")); len(urls) > 0 {
+		return urls
+	}
+	base := strings.TrimRight(env.ReadEnv("PUBLISH_BASE_URL", "FEED_LINK"), "/")
+	if base == "" {
+		return nil
+	}
+	urls := make([]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		urls = append(urls, base+"/"+a.Name)
+	}
+	return urls
+}
+
+func purgeCloudflare(urls []string) error { // POST /zones/{zone}/purge_cache mit {"files": [...]}, in Batches zu cloudflareBatch URLs.
+	token, err := requireEnv("CLOUDFLARE_API_TOKEN")
+	if err != nil {
+		return err
+	}
+	zone, err := requireEnv("CLOUDFLARE_ZONE_ID")
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(urls); start += cloudflareBatch {
+		batch := urls[start:min(start+cloudflareBatch, len(urls))]
+		body, err := json.Marshal(map[string][]string{"files": batch})
+		if err != nil {
+			return err
+		}
+		err = purgeRequest(http.MethodPost, fmt.Sprintf("%s/zones/%s/purge_cache", cloudflareAPI, zone), body, map[string]string{
+			"Authorization": "Bearer " + token,
+			"Content-Type":  "application/json",
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func purgeFastly(urls []string) error { // Fastly purgt einzelne URLs per POST /purge/{host/path}.
+	token, err := requireEnv("FASTLY_API_TOKEN")
+	if err != nil {
+		return err
+	}
+	for _, url := range urls {
+		target := url
+		if _, rest, found := strings.Cut(url, "://"); found { // Die API erwartet die URL ohne Schema.
+			target = rest
+		}
+		if err := purgeRequest(http.MethodPost, fastlyAPI+"/purge/"+target, nil, map[string]string{"Fastly-Key": token}); err != nil {
+			return fmt.Errorf("%s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+func purgeRequest(method, url string, body []byte, headers map[string]string) error { // Gemeinsamer HTTP-Call für beide CDNs.
+	ctx, cancel := requestContext(30 * time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func splitList(value string) []string { // Kommagetrennte Liste → getrimmte, nicht-leere Werte.
+	result := []string{}
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
@@ -0,0 +1,185 @@
+package publish // Paket "publish": S3-Upload via REST-API mit AWS Signature Version 4.
+
+import ( // Import-Block: Standardbibliothek für HTTP, Hashing und Signatur.
+	"bytes"         // Request-Body aus []byte.
+	"crypto/hmac"   // HMAC-SHA256 für die SigV4-Schlüsselableitung.
+	"crypto/sha256" // Payload- und Canonical-Request-Hashes.
+	"encoding/hex"  // Hex-Kodierung der Hashes/Signatur.
+	"fmt"           // Fehlertexte + String-Building.
+	"io"            // Response-Body lesen (Fehlermeldung).
+	"net/http"      // HTTP PUT an S3.
+	"sort"          // Canonical Headers müssen sortiert sein.
+	"strings"       // String-Building/Trim.
+	"time"          // x-amz-date + Timeout.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type s3Config struct { // Konfiguration für den S3-Upload (aus ENV).
+	bucket       string // Bucket-Name (PUBLISH_S3_BUCKET).
+	region       string // Region (PUBLISH_S3_REGION, Default us-east-1).
+	prefix       string // Optionaler Key-Präfix (PUBLISH_S3_PREFIX), z.B. "feed/".
+	endpoint     string // Optionaler Endpoint für S3-kompatible Speicher (PUBLISH_S3_ENDPOINT), dann Path-Style.
+	accessKey    string // AWS_ACCESS_KEY_ID.
+	secretKey    string // AWS_SECRET_ACCESS_KEY.
+	sessionToken string // Optional: AWS_SESSION_TOKEN (z.B. OIDC in GitHub Actions).
+}
+
+func loadS3Config() (s3Config, error) { // Liest alle S3-Variablen; Pflichtfelder liefern klare Fehler.
+	cfg := s3Config{
+		region:       env.ReadEnv("PUBLISH_S3_REGION", "AWS_REGION"),
+		prefix:       strings.Trim(env.ReadEnv("PUBLISH_S3_PREFIX"), "/"),
+		endpoint:     strings.TrimRight(env.ReadEnv("PUBLISH_S3_ENDPOINT"), "/"),
+		sessionToken: env.ReadEnv("AWS_SESSION_TOKEN"),
+	}
+	if cfg.region == "" {
+		cfg.region = "us-east-1" // Default-Region wie bei der AWS CLI.
+	}
+	var err error
+	if cfg.bucket, err = requireEnv("PUBLISH_S3_BUCKET"); err != nil {
+		return cfg, err
+	}
+	if cfg.accessKey, err = requireEnv("AWS_ACCESS_KEY_ID"); err != nil {
+		return cfg, err
+	}
+	if cfg.secretKey, err = requireEnv("AWS_SECRET_ACCESS_KEY"); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func publishS3(artifacts []artifact) error { // Lädt alle Artefakte per PUT in den Bucket.
+	cfg, err := loadS3Config()
+	if err != nil {
+		return err
+	}
+	for _, a := range artifacts {
+		key := a.Name
+		if cfg.prefix != "" {
+			key = cfg.prefix + "/" + key
+		}
+		if err := putS3Object(cfg, key, a.Data, ContentType(a.Name)); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func putS3Object(cfg s3Config, key string, body []byte, ctype string) error { // Einzelner signierter PUT-Request.
+	host := fmt.Sprintf("%s.s3.%s.amazonaws.com", cfg.bucket, cfg.region) // Virtual-Hosted-Style (AWS Standard).
+	path := "/" + uriEncode(key, false)
+	scheme := "https"
+	if cfg.endpoint != "" { // S3-kompatibel (MinIO, R2, …): Path-Style unter dem Endpoint.
+		if before, after, found := strings.Cut(cfg.endpoint, "://"); found {
+			scheme, host = before, after
+		} else {
+			host = cfg.endpoint
+		}
+		path = "/" + uriEncode(cfg.bucket, true) + path
+	}
+
+	ctx, cancel := requestContext(60 * time.Second) // Timeout: Uploads dürfen nicht ewig hängen.
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, scheme+"://"+host+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(body)
+	req.Header.Set("Content-Type", ctype)
+	req.Header.Set("Cache-Control", "max-age=300") // Kurzer Cache, damit neue Items zügig sichtbar werden.
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if cfg.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", cfg.sessionToken)
+	}
+	req.Header.Set("Authorization", signV4(cfg, req, host, path, payloadHash, now))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 { // S3 liefert Fehlerdetails als XML im Body.
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("s3 status: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	return nil
+}
+
+func signV4(cfg s3Config, req *http.Request, host, path, payloadHash string, now time.Time) string { // Baut den Authorization-Header nach AWS SigV4.
+	headers := map[string]string{ // Alle signierten Header (lowercase).
+		"content-type":         req.Header.Get("Content-Type"),
+		"host":                 host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           req.Header.Get("X-Amz-Date"),
+	}
+	if cfg.sessionToken != "" {
+		headers["x-amz-security-token"] = cfg.sessionToken
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names) // SigV4 verlangt sortierte Header-Namen.
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{ // Canonical Request: Methode, Pfad, Query, Header, signierte Header, Payload-Hash.
+		req.Method,
+		path,
+		"",
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	date := now.Format("20060102")
+	scope := date + "/" + cfg.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+cfg.secretKey), date) // Schlüsselableitung: Datum → Region → Service → aws4_request.
+	key = hmacSHA256(key, cfg.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s", cfg.accessKey, scope, signedHeaders, signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte { // HMAC-SHA256 Hilfsfunktion.
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string { // SHA256 als Hex-String (für Payload + Canonical Request).
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func uriEncode(value string, encodeSlash bool) string { // URI-Encoding nach AWS-Regeln (nur unreserved Zeichen bleiben).
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash: // Im Key bleibt "/" als Pfadtrenner erhalten.
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
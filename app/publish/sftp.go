@@ -0,0 +1,81 @@
+package publish // Paket "publish": SFTP-Upload über den OpenSSH sftp-Client im Batch-Modus.
+
+import ( // Import-Block: Standardbibliothek für Prozesse und temporäre Dateien.
+	"bytes"         // Batch-Skript als Stdin.
+	"fmt"           // Batch-Befehle + Fehlertexte.
+	"os"            // Temporäres Verzeichnis für die lokalen Kopien.
+	"os/exec"       // Aufruf von sftp.
+	"path"          // Remote-Pfade ("/"-getrennt).
+	"path/filepath" // Lokale Pfade.
+	"strings"       // Output trimmen + Quoting.
+
+	"wapuugotchi/feed/app/env"
+)
+
+func publishSFTP(artifacts []artifact) error { // Lädt alle Artefakte per SFTP hoch (Authentifizierung über SSH-Key/Agent).
+	host, err := requireEnv("PUBLISH_SFTP_HOST")
+	if err != nil {
+		return err
+	}
+	user, err := requireEnv("PUBLISH_SFTP_USER")
+	if err != nil {
+		return err
+	}
+	dir := env.ReadEnv("PUBLISH_SFTP_DIR") // Zielverzeichnis, z.B. "public_html".
+
+	tmp, err := os.MkdirTemp("", "sftp-") // sftp lädt nur Dateien hoch, daher lokale Kopien der Artefakte.
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	var batch bytes.Buffer // Batch-Skript: "-" vor mkdir ignoriert Fehler, falls das Verzeichnis schon existiert.
+	created := map[string]bool{}
+	for i, a := range artifacts {
+		local := filepath.Join(tmp, fmt.Sprintf("%d", i))
+		if err := os.WriteFile(local, a.Data, 0o644); err != nil {
+			return err
+		}
+		remote := path.Join(dir, a.Name)
+		for _, parent := range ancestors(path.Dir(remote)) { // sftp mkdir legt keine Zwischenverzeichnisse an: von oben nach unten jedes einzeln.
+			if !created[parent] {
+				created[parent] = true
+				fmt.Fprintf(&batch, "-mkdir %s\n", sftpQuote(parent))
+			}
+		}
+		fmt.Fprintf(&batch, "put %s %s\n", sftpQuote(local), sftpQuote(remote+".tmp")) // Erst temporär, dann umbenennen.
+		fmt.Fprintf(&batch, "-rm %s\n", sftpQuote(remote))
+		fmt.Fprintf(&batch, "rename %s %s\n", sftpQuote(remote+".tmp"), sftpQuote(remote))
+	}
+
+	args := []string{"-b", "-", "-o", "BatchMode=yes"} // BatchMode: nie interaktiv nach Passwörtern fragen.
+	if port := env.ReadEnv("PUBLISH_SFTP_PORT"); port != "" {
+		args = append(args, "-P", port)
+	}
+	if key := env.ReadEnv("PUBLISH_SFTP_KEY"); key != "" { // Pfad zum privaten Schlüssel.
+		args = append(args, "-i", key)
+	}
+	if knownHosts := env.ReadEnv("PUBLISH_SFTP_KNOWN_HOSTS"); knownHosts != "" { // Eigene known_hosts Datei (CI ohne ~/.ssh).
+		args = append(args, "-o", "UserKnownHostsFile="+knownHosts)
+	}
+	args = append(args, user+"@"+host)
+
+	cmd := exec.Command("sftp", args...)
+	cmd.Stdin = &batch
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("sftp: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func ancestors(dir string) []string { // "a/b/c" → ["a", "a/b", "a/b/c"]; "." und "/" entfallen.
+	if dir == "." || dir == "/" {
+		return nil
+	}
+	return append(ancestors(path.Dir(dir)), dir)
+}
+
+func sftpQuote(value string) string { // Quoting für sftp-Batch-Befehle (Leerzeichen in Pfaden).
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
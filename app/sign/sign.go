@@ -0,0 +1,69 @@
+package sign // Paket "sign": detached Ed25519-Signaturen im minisign-Format (legacy "Ed") für die generierten Feeds.
+
+import ( // Import-Block: Standardbibliothek für Ed25519 und Kodierung.
+	"crypto/ed25519"  // Signaturverfahren (wie minisign).
+	"crypto/rand"     // Schlüssel + Key-ID erzeugen.
+	"encoding/base64" // minisign kodiert Schlüssel/Signaturen als Base64.
+	"encoding/binary" // Key-ID als little-endian uint64 (Anzeige wie minisign).
+	"fmt"             // Signaturdatei + Fehlertexte.
+	"strings"         // Trim.
+	"time"            // Zeitstempel im trusted comment.
+
+	"wapuugotchi/feed/app/env"
+)
+
+type key struct { // Geladener Signaturschlüssel.
+	id      [8]byte            // Key-ID (keynum), verbindet Signatur und Public Key.
+	private ed25519.PrivateKey // Privater Ed25519-Schlüssel.
+}
+
+func Enabled() bool { // Signieren ist aktiv, sobald FEED_SIGNING_KEY gesetzt ist.
+	_ = env.LoadDotEnv()
+	return env.ReadEnv("FEED_SIGNING_KEY") != ""
+}
+
+func Sign(data []byte, fileName string) (string, error) { // Liefert den Inhalt der .minisig Datei für data.
+	k, err := loadKey()
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(k.private, data) // Legacy-Modus "Ed": signiert die Datei direkt (ohne BLAKE2b-Prehash).
+	sigBlob := append(append([]byte("Ed"), k.id[:]...), signature...)
+
+	trusted := fmt.Sprintf("timestamp:%d\tfile:%s", time.Now().Unix(), fileName)
+	global := ed25519.Sign(k.private, append(append([]byte{}, signature...), []byte(trusted)...)) // Schützt den trusted comment.
+
+	return fmt.Sprintf("untrusted comment: signature from wapuugotchi feed key %X\n%s\ntrusted comment: %s\n%s\n",
+		binary.LittleEndian.Uint64(k.id[:]),
+		base64.StdEncoding.EncodeToString(sigBlob),
+		trusted,
+		base64.StdEncoding.EncodeToString(global),
+	), nil
+}
+
+func GenerateKey() (publicKey, secret string, err error) { // Erzeugt ein neues Schlüsselpaar: minisign Public Key + Wert für FEED_SIGNING_KEY.
+	pub, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", "", err
+	}
+	publicKey = base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), id[:]...), pub...))
+	secret = base64.StdEncoding.EncodeToString(append(id[:], private.Seed()...)) // Key-ID + 32-Byte Seed.
+	return publicKey, secret, nil
+}
+
+func loadKey() (key, error) { // Liest FEED_SIGNING_KEY: Base64 aus Key-ID (8 Byte) + Ed25519-Seed (32 Byte).
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(env.ReadEnv("FEED_SIGNING_KEY")))
+	if err != nil {
+		return key{}, fmt.Errorf("invalid FEED_SIGNING_KEY: %w", err)
+	}
+	if len(raw) != 8+ed25519.SeedSize {
+		return key{}, fmt.Errorf("invalid FEED_SIGNING_KEY: expected %d bytes, got %d", 8+ed25519.SeedSize, len(raw))
+	}
+	k := key{private: ed25519.NewKeyFromSeed(raw[8:])}
+	copy(k.id[:], raw[:8])
+	return k, nil
+}